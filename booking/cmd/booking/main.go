@@ -11,6 +11,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	_ "time/tzdata"
 )
 
 const (
@@ -35,7 +36,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	application := app.New(ctx, log, *paymentsClient, cfg.Interval, cfg.GRPC.Addr, cfg.StoragePath)
+	application := app.New(ctx, log, *paymentsClient, cfg.Interval, cfg.Saga, cfg.Refund, cfg.Waitlist, cfg.Holds, cfg.Access, cfg.Notify, cfg.Outbox, cfg.Idempotency, cfg.GRPC.Addr, cfg.StoragePath)
 
 	go application.GRPCSrv.MustRun()
 
@@ -0,0 +1,65 @@
+// Command lock simulates the door controller of a box: every line read from
+// stdin is a PIN or a scanned token, the door opens if the booking service
+// grants access.
+//
+//	go run ./cmd/lock -box LeninaBox -door main
+package main
+
+import (
+	"booking/internal/clients/lock"
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+func main() {
+	var addr, boxName, door string
+	var timeout time.Duration
+
+	flag.StringVar(&addr, "addr", "localhost:45055", "address of the booking service")
+	flag.StringVar(&boxName, "box", "", "name of the box the door belongs to")
+	flag.StringVar(&door, "door", "main", "ID of the door")
+	flag.DurationVar(&timeout, "timeout", 5*time.Second, "timeout of one check")
+	flag.Parse()
+
+	if boxName == "" {
+		panic("box is required")
+	}
+
+	l, err := lock.New(context.Background(), addr, boxName, door)
+	if err != nil {
+		panic(err)
+	}
+	defer l.Close()
+
+	fmt.Printf("door %q of %s is locked, enter a PIN or a token\n", door, boxName)
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		code := strings.TrimSpace(scanner.Text())
+		if code == "" {
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		result, err := l.Try(ctx, code)
+		cancel()
+
+		switch {
+		case err != nil:
+			fmt.Printf("LOCKED: %v\n", err)
+		case result.Open:
+			fmt.Printf("OPEN: booking %s, valid until %s\n", result.BookingUID, result.ValidUntil)
+		default:
+			fmt.Printf("LOCKED: %s\n", result.Reason)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		panic(err)
+	}
+}
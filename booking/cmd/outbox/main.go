@@ -0,0 +1,68 @@
+// Command outbox replays booking events: the published events matching the
+// flags become pending again and the relay of the running service publishes
+// them once more.
+//
+//	outbox -storage-path ./storage/booking.db -since 2024-05-01T00:00:00Z -type booking.cancelled
+package main
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/storage/sqlite"
+	"context"
+	"flag"
+	"fmt"
+	"time"
+)
+
+func main() {
+	var (
+		storagePath string
+		fromID      int64
+		since       string
+		eventType   string
+		bookingID   string
+		all         bool
+	)
+
+	flag.StringVar(&storagePath, "storage-path", "", "path to storage")
+	flag.Int64Var(&fromID, "from", 0, "replay events starting with this outbox ID")
+	flag.StringVar(&since, "since", "", "replay events written since this RFC 3339 time")
+	flag.StringVar(&eventType, "type", "", "replay events of this type, e.g. booking.created")
+	flag.StringVar(&bookingID, "booking", "", "replay events of this booking")
+	flag.BoolVar(&all, "all", false, "replay every event")
+	flag.Parse()
+
+	if storagePath == "" {
+		panic("storage-path is required")
+	}
+
+	filter := models.EventFilter{
+		FromID:     fromID,
+		Type:       models.EventType(eventType),
+		BookingUID: bookingID,
+	}
+
+	if since != "" {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			panic("since must be an RFC 3339 time: " + err.Error())
+		}
+		filter.Since = t
+	}
+
+	if filter == (models.EventFilter{}) && !all {
+		panic("pass a filter or -all to replay every event")
+	}
+
+	storage, err := sqlite.New(storagePath)
+	if err != nil {
+		panic(err)
+	}
+
+	replayed, err := storage.ReplayEvents(context.Background(), filter)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("%d events queued for replay\n", replayed)
+}
@@ -6,11 +6,17 @@ require github.com/ilyakaznacheev/cleanenv v1.5.0
 
 require github.com/mattn/go-sqlite3 v1.14.32
 
-require github.com/MKode312/protos v0.0.13
+require (
+	github.com/MKode312/protos v0.0.13
+	github.com/google/uuid v1.6.0
+	github.com/stretchr/testify v1.11.1
+)
 
 require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/hashicorp/errwrap v1.1.0 // indirect
 	github.com/hashicorp/go-multierror v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
 )
 
 require (
@@ -33,3 +39,5 @@ require (
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 	olympos.io/encoding/edn v0.0.0-20201019073823-d3554ca0b0a3 // indirect
 )
+
+replace github.com/MKode312/protos => ../protos
@@ -2,9 +2,18 @@ package app
 
 import (
 	grpcapp "booking/internal/app/grpc"
+	"booking/internal/clients/channels"
 	"booking/internal/clients/payments"
+	"booking/internal/clients/publisher"
+	"booking/internal/config"
+	"booking/internal/domain/models"
 	"booking/internal/lib/logger/sl"
+	"booking/internal/services/access"
 	"booking/internal/services/book"
+	"booking/internal/services/notify"
+	"booking/internal/services/outbox"
+	"booking/internal/services/pricing"
+	"booking/internal/services/refund"
 	"booking/internal/storage/sqlite"
 	"context"
 	"log/slog"
@@ -14,7 +23,7 @@ type App struct {
 	GRPCSrv *grpcapp.App
 }
 
-func New(ctx context.Context, log *slog.Logger, paymclient payments.Client, interval int64, grpcAddr string, storagePath string) *App {
+func New(ctx context.Context, log *slog.Logger, paymclient payments.Client, interval int64, sagaCfg config.SagaConfig, refundCfg config.RefundConfig, waitlistCfg config.WaitlistConfig, holdCfg config.HoldConfig, accessCfg config.AccessConfig, notifyCfg config.NotifyConfig, outboxCfg config.OutboxConfig, idempotencyCfg config.IdempotencyConfig, grpcAddr string, storagePath string) *App {
 	storage, err := sqlite.New(storagePath)
 	if err != nil {
 		panic(err)
@@ -29,11 +38,148 @@ func New(ctx context.Context, log *slog.Logger, paymclient payments.Client, inte
 		}
 	}()
 
-	bookingService := book.NewBooker(log, storage)
+	pricingService := pricing.New(log, storage, storage)
 
-	grpcApp := grpcapp.New(log, bookingService, paymclient, grpcAddr)
+	refundPolicy := refund.New(refundCfg.FullRefundBefore, refundCfg.PartialPercent)
+
+	accessSigner := access.New(accessCfg.Secret, accessCfg.EarlyEntry)
+
+	notifier := notify.New(log, storage, notifyChannels(log, notifyCfg), notifyCfg.Reminders, models.Locale(notifyCfg.Locale), notifyCfg.MaxAttempts, notifyCfg.RetryBackoff)
+
+	bookingService := book.NewBooker(log, book.Deps{
+		Booker:       storage,
+		Boxes:        storage,
+		Sagas:        storage,
+		Payments:     &paymclient,
+		Pricer:       pricingService,
+		Refunds:      refundPolicy,
+		Series:       storage,
+		Waitlist:     storage,
+		Rescheduler:  storage,
+		Schedule:     storage,
+		Access:       accessSigner,
+		CheckIns:     storage,
+		Calendar:     storage,
+		Notifier:     notifier,
+		Idempotency:  storage,
+		Holds:        storage,
+		Promos:       storage,
+		Venues:       storage,
+		Participants: storage,
+	}, book.Config{
+		OfferTTL:                waitlistCfg.OfferTTL,
+		HoldTTL:                 holdCfg.TTL,
+		MaxHolds:                holdCfg.MaxPerUser,
+		IdempotencyClaimTimeout: idempotencyCfg.ClaimTimeout,
+		MaxPINAttempts:          accessCfg.MaxPINAttempts,
+		PINLockout:              accessCfg.PINLockout,
+	})
+
+	sagaErrCh := bookingService.StartSagaRecovery(ctx, sagaCfg.RecoveryInterval, sagaCfg.StaleAfter)
+
+	go func() {
+		for err := range sagaErrCh {
+			if err != nil {
+				log.Error("saga recovery worker error", sl.Err(err))
+			}
+		}
+	}()
+
+	waitlistErrCh := bookingService.StartWaitlistWorker(ctx, waitlistCfg.Interval)
+
+	go func() {
+		for err := range waitlistErrCh {
+			if err != nil {
+				log.Error("waitlist worker error", sl.Err(err))
+			}
+		}
+	}()
+
+	holdErrCh := bookingService.StartHoldSweeper(ctx, holdCfg.Interval)
+
+	go func() {
+		for err := range holdErrCh {
+			if err != nil {
+				log.Error("hold sweeper error", sl.Err(err))
+			}
+		}
+	}()
+
+	notifyErrCh := notifier.StartDispatcher(ctx, notifyCfg.Interval)
+
+	go func() {
+		for err := range notifyErrCh {
+			if err != nil {
+				log.Error("notification dispatcher error", sl.Err(err))
+			}
+		}
+	}()
+
+	relay := outbox.New(log, storage, eventPublisher(log, outboxCfg), outboxCfg.RetryBackoff)
+
+	relayErrCh := relay.StartRelay(ctx, outboxCfg.Interval)
+
+	go func() {
+		for err := range relayErrCh {
+			if err != nil {
+				log.Error("outbox relay error", sl.Err(err))
+			}
+		}
+	}()
+
+	grpcApp := grpcapp.New(log, bookingService, grpcAddr)
 
 	return &App{
 		GRPCSrv: grpcApp,
 	}
+}
+
+// notifyChannels returns the notification channels that are configured.
+func notifyChannels(log *slog.Logger, cfg config.NotifyConfig) []notify.Channel {
+	var result []notify.Channel
+
+	if cfg.SMTP.Host != "" {
+		smtp, err := channels.NewSMTP(cfg.SMTP.Host, cfg.SMTP.Port, cfg.SMTP.Username, cfg.SMTP.Password, cfg.SMTP.From)
+		if err != nil {
+			panic(err)
+		}
+		result = append(result, smtp)
+	}
+
+	if cfg.Webhook.URL != "" {
+		result = append(result, channels.NewWebhook(cfg.Webhook.URL, cfg.Webhook.Secret, cfg.Webhook.Timeout))
+	}
+
+	if cfg.Log.Enabled {
+		sink, err := channels.NewLog(log, cfg.Log.Path)
+		if err != nil {
+			panic(err)
+		}
+		result = append(result, sink)
+	}
+
+	if len(result) == 0 {
+		log.Warn("no notification channels configured, notifications are not sent")
+	}
+
+	return result
+}
+
+// eventPublisher returns the webhook publisher when it is configured and an
+// in-process bus otherwise.
+func eventPublisher(log *slog.Logger, cfg config.OutboxConfig) outbox.Publisher {
+	if cfg.Webhook.URL != "" {
+		return publisher.NewWebhook(cfg.Webhook.URL, cfg.Webhook.Secret, cfg.Webhook.Timeout)
+	}
+
+	bus := publisher.NewBus()
+	bus.Subscribe(func(_ context.Context, event models.Event) error {
+		log.Debug("booking event",
+			slog.String("event_id", event.UID),
+			slog.String("type", string(event.Type)),
+			slog.String("booking_id", event.BookingUID))
+		return nil
+	})
+
+	return bus
 }
\ No newline at end of file
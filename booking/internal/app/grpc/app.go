@@ -1,7 +1,6 @@
 package grpcapp
 
 import (
-	"booking/internal/clients/payments"
 	bookgrpc "booking/internal/grpc/book"
 	"fmt"
 	"log/slog"
@@ -16,10 +15,10 @@ type App struct {
 	addr       string
 }
 
-func New(log *slog.Logger, bookingService bookgrpc.Book, paymclient payments.Client, addr string) *App {
+func New(log *slog.Logger, bookingService bookgrpc.Book, addr string) *App {
 	gRPCServer := grpc.NewServer()
 
-	bookgrpc.Register(gRPCServer, bookingService, paymclient)
+	bookgrpc.Register(gRPCServer, bookingService)
 
 	return &App{
 		log:        log,
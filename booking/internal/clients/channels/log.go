@@ -0,0 +1,77 @@
+package channels
+
+import (
+	"booking/internal/domain/models"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// Log writes notifications to a file, or to the service log when there is
+// no file, for local development without a mail server.
+type Log struct {
+	log *slog.Logger
+	mu  sync.Mutex
+	out io.WriteCloser
+}
+
+// NewLog returns a channel appending to the file at path, or logging with
+// log when path is empty.
+func NewLog(log *slog.Logger, path string) (*Log, error) {
+	const op = "channels.NewLog"
+
+	l := &Log{log: log}
+
+	if path != "" {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		l.out = file
+	}
+
+	return l, nil
+}
+
+func (l *Log) Name() string {
+	return "log"
+}
+
+func (l *Log) Send(_ context.Context, msg models.NotificationMessage) error {
+	const op = "channels.Log.Send"
+
+	if l.out == nil {
+		l.log.Info("notification",
+			slog.String("notification_id", msg.ID),
+			slog.String("kind", string(msg.Kind)),
+			slog.String("to", msg.To),
+			slog.String("subject", msg.Subject),
+			slog.String("body", msg.Body))
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	_, err := fmt.Fprintf(l.out, "--- %s %s\nTo: %s\nSubject: %s\n\n%s\n\n",
+		time.Now().Format(time.RFC3339), msg.ID, msg.To, msg.Subject, msg.Body)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Close closes the file of the channel.
+func (l *Log) Close() error {
+	if l.out == nil {
+		return nil
+	}
+
+	return l.out.Close()
+}
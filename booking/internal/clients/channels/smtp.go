@@ -0,0 +1,103 @@
+package channels
+
+import (
+	"booking/internal/domain/models"
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// SMTP sends notifications by email to the address of the user.
+type SMTP struct {
+	addr string
+	host string
+	from mail.Address
+	auth smtp.Auth
+}
+
+// NewSMTP returns an email channel sending from the given address through
+// the server at host:port. The server is logged into when username is set.
+func NewSMTP(host string, port int, username string, password string, from string) (*SMTP, error) {
+	const op = "channels.NewSMTP"
+
+	sender, err := mail.ParseAddress(from)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &SMTP{
+		addr: net.JoinHostPort(host, strconv.Itoa(port)),
+		host: host,
+		from: *sender,
+		auth: auth,
+	}, nil
+}
+
+func (s *SMTP) Name() string {
+	return "smtp"
+}
+
+// Send mails the message. net/smtp can't be cancelled, the context is only
+// checked before connecting.
+func (s *SMTP) Send(ctx context.Context, msg models.NotificationMessage) error {
+	const op = "channels.SMTP.Send"
+
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	to := mail.Address{Address: msg.To}
+
+	body, err := s.compose(msg, to)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := smtp.SendMail(s.addr, s.auth, s.from.Address, []string{to.Address}, body); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// compose renders a plain text UTF-8 email.
+func (s *SMTP) compose(msg models.NotificationMessage, to mail.Address) ([]byte, error) {
+	var buf bytes.Buffer
+
+	header := func(name string, value string) {
+		buf.WriteString(name + ": " + value + "\r\n")
+	}
+
+	header("From", s.from.String())
+	header("To", to.String())
+	header("Subject", mime.QEncoding.Encode("utf-8", msg.Subject))
+	header("Date", time.Now().Format(time.RFC1123Z))
+	header("Message-ID", "<"+msg.ID+"@"+s.host+">")
+	header("Content-Language", string(msg.Locale))
+	header("MIME-Version", "1.0")
+	header("Content-Type", "text/plain; charset=utf-8")
+	header("Content-Transfer-Encoding", "quoted-printable")
+	buf.WriteString("\r\n")
+
+	w := quotedprintable.NewWriter(&buf)
+	if _, err := w.Write([]byte(msg.Body)); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,94 @@
+package channels
+
+import (
+	"booking/internal/domain/models"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// signatureHeader carries the HMAC-SHA256 of the request body when the
+// webhook has a secret.
+const signatureHeader = "X-SportBox-Signature"
+
+// Webhook posts notifications as JSON to a URL, e.g. a bridge to a
+// messenger bot. Any 2xx response counts as delivered.
+type Webhook struct {
+	url    string
+	secret []byte
+	client *http.Client
+}
+
+type webhookPayload struct {
+	ID         string `json:"id"`
+	Kind       string `json:"kind"`
+	To         string `json:"to"`
+	Locale     string `json:"locale"`
+	Subject    string `json:"subject"`
+	Body       string `json:"body"`
+	BookingUID string `json:"bookingId"`
+}
+
+// NewWebhook returns a channel posting to url. Requests are signed when
+// secret is set.
+func NewWebhook(url string, secret string, timeout time.Duration) *Webhook {
+	return &Webhook{
+		url:    url,
+		secret: []byte(secret),
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (w *Webhook) Name() string {
+	return "webhook"
+}
+
+func (w *Webhook) Send(ctx context.Context, msg models.NotificationMessage) error {
+	const op = "channels.Webhook.Send"
+
+	body, err := json.Marshal(webhookPayload{
+		ID:         msg.ID,
+		Kind:       string(msg.Kind),
+		To:         msg.To,
+		Locale:     string(msg.Locale),
+		Subject:    msg.Subject,
+		Body:       msg.Body,
+		BookingUID: msg.BookingUID,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if len(w.secret) > 0 {
+		mac := hmac.New(sha256.New, w.secret)
+		mac.Write(body)
+		req.Header.Set(signatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s: unexpected status %s", op, resp.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package lock
+
+import (
+	"context"
+	"fmt"
+
+	bookingv1 "github.com/MKode312/protos/gen/go/booking"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// Lock is a simulated door controller of a box. It asks the booking service
+// whether a code entered or scanned at the door may open it.
+type Lock struct {
+	api     bookingv1.BookClient
+	conn    *grpc.ClientConn
+	boxName string
+	door    string
+}
+
+// Result is what the door does with a code. Reason tells why it stayed
+// locked.
+type Result struct {
+	Open       bool
+	BookingUID string
+	ValidUntil string
+	Reason     string
+}
+
+func New(ctx context.Context, addr string, boxName string, door string) (*Lock, error) {
+	const op = "lock.New"
+
+	cc, err := grpc.DialContext(ctx, addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &Lock{
+		api:     bookingv1.NewBookClient(cc),
+		conn:    cc,
+		boxName: boxName,
+		door:    door,
+	}, nil
+}
+
+// Try verifies the code. A denied code is a locked result, not an error.
+func (l *Lock) Try(ctx context.Context, code string) (Result, error) {
+	const op = "lock.Try"
+
+	resp, err := l.api.VerifyAccess(ctx, &bookingv1.VerifyAccessRequest{
+		BoxName: l.boxName,
+		Code:    code,
+		DoorId:  l.door,
+	})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if ok && (st.Code() == codes.PermissionDenied || st.Code() == codes.InvalidArgument) {
+			return Result{Reason: st.Message()}, nil
+		}
+
+		return Result{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return Result{
+		Open:       resp.GetGranted(),
+		BookingUID: resp.GetBookingUid(),
+		ValidUntil: resp.GetValidUntil(),
+	}, nil
+}
+
+func (l *Lock) Close() error {
+	return l.conn.Close()
+}
@@ -52,12 +52,15 @@ func New(ctx context.Context, log *slog.Logger, addr string, timeout time.Durati
 	}, nil
 }
 
-func (c *Client) Pay(ctx context.Context, email string, amount int64) (balance int64, success bool, err error) {
+// Pay charges the wallet. A retried call with the same idempotency key is
+// charged once, so retries on timeouts are safe.
+func (c *Client) Pay(ctx context.Context, email string, amount int64, idempotencyKey string) (balance int64, success bool, err error) {
 	const op = "paymgrpc.Pay"
 
 	resp, err := c.api.Pay(ctx, &paymentsv1.PayRequest{
-		Email:  email,
-		Amount: amount,
+		Email:          email,
+		Amount:         amount,
+		IdempotencyKey: idempotencyKey,
 	})
 	if err != nil {
 		st, ok := status.FromError(err)
@@ -74,6 +77,12 @@ func (c *Client) Pay(ctx context.Context, email string, amount int64) (balance i
 			if st.Code() == codes.Canceled {
 				return emptyBalanceValue, false, fmt.Errorf("%s", st.Message())
 			}
+			if st.Code() == codes.OutOfRange {
+				return emptyBalanceValue, false, fmt.Errorf("%s", st.Message())
+			}
+			if st.Code() == codes.FailedPrecondition {
+				return emptyBalanceValue, false, fmt.Errorf("%s", st.Message())
+			}
 		}
 		return emptyBalanceValue, false, fmt.Errorf("%s: %w", op, err)
 	}
@@ -81,12 +90,14 @@ func (c *Client) Pay(ctx context.Context, email string, amount int64) (balance i
 	return resp.Balance, resp.Success, nil
 }
 
-func (c *Client) AddFunds(ctx context.Context, email string, amount int64) (balance int64, success bool, err error) {
+// AddFunds credits the wallet, once per idempotency key.
+func (c *Client) AddFunds(ctx context.Context, email string, amount int64, idempotencyKey string) (balance int64, success bool, err error) {
 	const op = "paymgrpc.AddFunds"
 
 	resp, err := c.api.AddFunds(ctx, &paymentsv1.AddFundsRequest{
-		Email:  email,
-		Amount: amount,
+		Email:          email,
+		Amount:         amount,
+		IdempotencyKey: idempotencyKey,
 	})
 	if err != nil {
 		st, ok := status.FromError(err)
@@ -97,6 +108,9 @@ func (c *Client) AddFunds(ctx context.Context, email string, amount int64) (bala
 			if st.Code() == codes.InvalidArgument {
 				return emptyBalanceValue, false, fmt.Errorf("%s", st.Message())
 			}
+			if st.Code() == codes.FailedPrecondition {
+				return emptyBalanceValue, false, fmt.Errorf("%s", st.Message())
+			}
 		}
 		return emptyBalanceValue, false, fmt.Errorf("%s: %w", op, err)
 	}
@@ -104,6 +118,28 @@ func (c *Client) AddFunds(ctx context.Context, email string, amount int64) (bala
 	return resp.Balance, resp.Success, nil
 }
 
+func (c *Client) Balance(ctx context.Context, email string) (int64, error) {
+	const op = "paymgrpc.Balance"
+
+	resp, err := c.api.GetBalance(ctx, &paymentsv1.GetBalanceRequest{
+		Email: email,
+	})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if ok {
+			if st.Code() == codes.NotFound {
+				return emptyBalanceValue, fmt.Errorf("%s", st.Message())
+			}
+			if st.Code() == codes.InvalidArgument {
+				return emptyBalanceValue, fmt.Errorf("%s", st.Message())
+			}
+		}
+		return emptyBalanceValue, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp.Balance, nil
+}
+
 func InterceptorLogger(l *slog.Logger) grpclog.Logger {
 	return grpclog.LoggerFunc(func(ctx context.Context, lvl grpclog.Level, msg string, fields ...any) {
 		l.Log(ctx, slog.Level(lvl), msg, fields...)
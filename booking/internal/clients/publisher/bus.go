@@ -0,0 +1,57 @@
+package publisher
+
+import (
+	"booking/internal/domain/models"
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Handler consumes an event. Events are delivered at least once, handlers
+// drop the ones they have seen by the event UID.
+type Handler func(ctx context.Context, event models.Event) error
+
+// Bus publishes events to handlers in the same process. An event counts as
+// published once every handler subscribed to it has returned without error,
+// a failing handler makes all of them get the event again.
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[models.EventType][]Handler
+	all      []Handler
+}
+
+func NewBus() *Bus {
+	return &Bus{handlers: make(map[models.EventType][]Handler)}
+}
+
+// Subscribe calls handler for the events of the given types, or for every
+// event when there are none.
+func (b *Bus) Subscribe(handler Handler, types ...models.EventType) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(types) == 0 {
+		b.all = append(b.all, handler)
+		return
+	}
+
+	for _, t := range types {
+		b.handlers[t] = append(b.handlers[t], handler)
+	}
+}
+
+func (b *Bus) Publish(ctx context.Context, event models.Event) error {
+	const op = "publisher.Bus.Publish"
+
+	b.mu.RLock()
+	handlers := append(append([]Handler(nil), b.handlers[event.Type]...), b.all...)
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return nil
+}
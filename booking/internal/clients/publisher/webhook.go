@@ -0,0 +1,90 @@
+package publisher
+
+import (
+	"booking/internal/domain/models"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// signatureHeader carries the HMAC-SHA256 of the request body when the
+// webhook has a secret.
+const signatureHeader = "X-SportBox-Signature"
+
+// Webhook posts events as JSON to a URL. Any 2xx response counts as
+// published, anything else is retried.
+type Webhook struct {
+	url    string
+	secret []byte
+	client *http.Client
+}
+
+type envelope struct {
+	ID         string          `json:"id"`
+	Sequence   int64           `json:"sequence"`
+	Type       string          `json:"type"`
+	BookingUID string          `json:"bookingId"`
+	CreatedAt  time.Time       `json:"createdAt"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// NewWebhook returns a publisher posting to url. Requests are signed when
+// secret is set.
+func NewWebhook(url string, secret string, timeout time.Duration) *Webhook {
+	return &Webhook{
+		url:    url,
+		secret: []byte(secret),
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (w *Webhook) Publish(ctx context.Context, event models.Event) error {
+	const op = "publisher.Webhook.Publish"
+
+	body, err := json.Marshal(envelope{
+		ID:         event.UID,
+		Sequence:   event.ID,
+		Type:       string(event.Type),
+		BookingUID: event.BookingUID,
+		CreatedAt:  event.CreatedAt.UTC(),
+		Data:       event.Payload,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event-ID", event.UID)
+	req.Header.Set("X-Event-Type", string(event.Type))
+	if len(w.secret) > 0 {
+		mac := hmac.New(sha256.New, w.secret)
+		mac.Write(body)
+		req.Header.Set(signatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s: unexpected status %s", op, resp.Status)
+	}
+
+	return nil
+}
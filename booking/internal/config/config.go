@@ -9,11 +9,19 @@ import (
 )
 
 type Config struct {
-	Env         string        `yaml:"env" env-default:"local"`
-	StoragePath string        `yaml:"storage_path" env-required:"true"`
-	Interval    int64         `yaml:"tCheckerSeconds" env-default:"1"`
-	GRPC        GRPCConfig    `yaml:"grpc"`
-	Clients     ClientsConfig `yaml:"clients"`
+	Env         string            `yaml:"env" env-default:"local"`
+	StoragePath string            `yaml:"storage_path" env-required:"true"`
+	Interval    int64             `yaml:"tCheckerSeconds" env-default:"1"`
+	GRPC        GRPCConfig        `yaml:"grpc"`
+	Clients     ClientsConfig     `yaml:"clients"`
+	Saga        SagaConfig        `yaml:"saga"`
+	Refund      RefundConfig      `yaml:"refund"`
+	Waitlist    WaitlistConfig    `yaml:"waitlist"`
+	Holds       HoldConfig        `yaml:"holds"`
+	Access      AccessConfig      `yaml:"access"`
+	Notify      NotifyConfig      `yaml:"notifications"`
+	Outbox      OutboxConfig      `yaml:"outbox"`
+	Idempotency IdempotencyConfig `yaml:"idempotency"`
 }
 
 type GRPCConfig struct {
@@ -21,6 +29,105 @@ type GRPCConfig struct {
 	Timeout time.Duration `yaml:"timeout"`
 }
 
+// SagaConfig controls the worker that resumes interrupted booking and
+// cancellation sagas.
+type SagaConfig struct {
+	RecoveryInterval time.Duration `yaml:"recoveryInterval" env-default:"30s"`
+	StaleAfter       time.Duration `yaml:"staleAfter" env-default:"1m"`
+}
+
+// RefundConfig is the refund policy of user cancellations: the whole price
+// is refunded until FullRefundBefore the start, PartialPercent of it after
+// that and nothing once the booking has started.
+type RefundConfig struct {
+	FullRefundBefore time.Duration `yaml:"fullRefundBefore" env-default:"24h"`
+	PartialPercent   int64         `yaml:"partialPercent" env-default:"50"`
+}
+
+// WaitlistConfig controls the waitlist: how long an offer holds a freed slot
+// and how often lapsed offers are expired and free slots promoted.
+type WaitlistConfig struct {
+	OfferTTL time.Duration `yaml:"offerTTL" env-default:"15m"`
+	Interval time.Duration `yaml:"interval" env-default:"30s"`
+}
+
+// HoldConfig controls checkout holds: how long a hold keeps the slot, how
+// many active holds a user may have and how often lapsed ones are released.
+type HoldConfig struct {
+	TTL        time.Duration `yaml:"ttl" env-default:"10m"`
+	MaxPerUser int64         `yaml:"maxPerUser" env-default:"3"`
+	Interval   time.Duration `yaml:"interval" env-default:"30s"`
+}
+
+// AccessConfig controls the door credentials of bookings: Secret signs the
+// tokens and EarlyEntry is how long before the start the door opens.
+// MaxPINAttempts wrong PINs within PINLockout lock PIN entry at the door for
+// PINLockout.
+type AccessConfig struct {
+	Secret         string        `yaml:"secret" env:"ACCESS_SECRET" env-required:"true"`
+	EarlyEntry     time.Duration `yaml:"earlyEntry" env-default:"10m"`
+	MaxPINAttempts int64         `yaml:"maxPinAttempts" env-default:"5"`
+	PINLockout     time.Duration `yaml:"pinLockout" env-default:"15m"`
+}
+
+// NotifyConfig controls booking notifications: reminders go out Reminders
+// before the start and failed deliveries are retried after RetryBackoff,
+// doubled every time, up to MaxAttempts attempts. Users without a locale of
+// their own get Locale. A channel is used when it is configured.
+type NotifyConfig struct {
+	Interval     time.Duration   `yaml:"interval" env-default:"15s"`
+	Reminders    []time.Duration `yaml:"reminders" env-default:"24h,1h"`
+	Locale       string          `yaml:"locale" env-default:"ru"`
+	MaxAttempts  int             `yaml:"maxAttempts" env-default:"5"`
+	RetryBackoff time.Duration   `yaml:"retryBackoff" env-default:"30s"`
+	SMTP         SMTPConfig      `yaml:"smtp"`
+	Webhook      WebhookConfig   `yaml:"webhook"`
+	Log          LogSinkConfig   `yaml:"log"`
+}
+
+type SMTPConfig struct {
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port" env-default:"587"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password" env:"SMTP_PASSWORD"`
+	From     string `yaml:"from"`
+}
+
+type WebhookConfig struct {
+	URL     string        `yaml:"url"`
+	Secret  string        `yaml:"secret" env:"NOTIFY_WEBHOOK_SECRET"`
+	Timeout time.Duration `yaml:"timeout" env-default:"5s"`
+}
+
+// LogSinkConfig writes notifications to the file at Path, or to the service
+// log without one.
+type LogSinkConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Path    string `yaml:"path"`
+}
+
+// OutboxConfig controls the relay publishing booking events from the outbox:
+// to the webhook when it has a URL, to subscribers in the process otherwise.
+// A failed event is retried after RetryBackoff, doubled every time.
+type OutboxConfig struct {
+	Interval     time.Duration       `yaml:"interval" env-default:"5s"`
+	RetryBackoff time.Duration       `yaml:"retryBackoff" env-default:"10s"`
+	Webhook      OutboxWebhookConfig `yaml:"webhook"`
+}
+
+// IdempotencyConfig controls idempotency keys: a key claimed by a request
+// that has not stored its result after ClaimTimeout is taken to belong to a
+// request that died, and a retry takes it over.
+type IdempotencyConfig struct {
+	ClaimTimeout time.Duration `yaml:"claimTimeout" env-default:"2m"`
+}
+
+type OutboxWebhookConfig struct {
+	URL     string        `yaml:"url"`
+	Secret  string        `yaml:"secret" env:"OUTBOX_WEBHOOK_SECRET"`
+	Timeout time.Duration `yaml:"timeout" env-default:"5s"`
+}
+
 type Client struct {
 	Address      string        `yaml:"address"`
 	Timeout      time.Duration `yaml:"timeout"`
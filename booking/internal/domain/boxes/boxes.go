@@ -1,12 +0,0 @@
-package boxes
-
-var Boxes = map[string]int64{
-	"SibirskayaBox": 1,
-	"LeninaBox": 2,
-	"LunacharskogoBox": 3,
-}
-
-var (
-	MinAmount = 13
-	HrsAmount = MinAmount * 60
-)
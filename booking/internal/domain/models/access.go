@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// AccessCredentials open the door of the box for a booking from ValidFrom
+// until ValidUntil. Token is signed and can be rendered as a QR code.
+type AccessCredentials struct {
+	PIN        string
+	Token      string
+	ValidFrom  time.Time
+	ValidUntil time.Time
+}
+
+// CheckInMethod tells which credential opened the door.
+type CheckInMethod string
+
+const (
+	CheckInMethodPIN   CheckInMethod = "pin"
+	CheckInMethodToken CheckInMethod = "token"
+)
+
+// CheckIn is a door opened for a booking.
+type CheckIn struct {
+	UID        string
+	BookingUID string
+	BoxName    string
+	Door       string
+	Method     CheckInMethod
+	CreatedAt  time.Time
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+type Interval struct {
+	StartsAt  time.Time
+	ExpiresAt time.Time
+	// PeopleAmount is the number of people holding a busy interval.
+	PeopleAmount int64
+}
+
+// Availability is the schedule of one box on one day.
+type Availability struct {
+	Box      Box
+	OpensAt  time.Time
+	ClosesAt time.Time
+	Free     []Interval
+	Busy     []Interval
+	Slots    []Interval
+}
@@ -0,0 +1,76 @@
+package models
+
+import "time"
+
+// BookingStatus is the lifecycle state of a booking. Rows are never deleted,
+// they only move from active to one of the final states.
+type BookingStatus string
+
+const (
+	BookingStatusActive    BookingStatus = "active"
+	BookingStatusCompleted BookingStatus = "completed"
+	BookingStatusCancelled BookingStatus = "cancelled"
+	BookingStatusNoShow    BookingStatus = "no_show"
+
+	// BookingStatusPending holds the slot while the booking is being paid for.
+	BookingStatusPending BookingStatus = "pending"
+	// BookingStatusCancelling holds the slot while the refund is processed.
+	BookingStatusCancelling BookingStatus = "cancelling"
+	// BookingStatusFailed is a reservation that was released because the payment failed.
+	BookingStatusFailed BookingStatus = "failed"
+	// BookingStatusHeld keeps the slot for one user until HeldUntil, e.g. for
+	// a waitlist offer. Nothing has been paid yet.
+	BookingStatusHeld BookingStatus = "held"
+	// BookingStatusExpired is a hold that ran out or was given up.
+	BookingStatusExpired BookingStatus = "expired"
+)
+
+func (s BookingStatus) Valid() bool {
+	switch s {
+	case BookingStatusActive, BookingStatusCompleted, BookingStatusCancelled, BookingStatusNoShow,
+		BookingStatusPending, BookingStatusCancelling, BookingStatusFailed, BookingStatusHeld, BookingStatusExpired:
+		return true
+	}
+
+	return false
+}
+
+type Booking struct {
+	// UID is the opaque booking ID handed out to clients.
+	UID string
+	// ID is the legacy numeric booking ID, still accepted during the move to UID.
+	ID           int64
+	Email        string
+	BoxName      string
+	StartsAt     time.Time
+	ExpiresAt    time.Time
+	PeopleAmount int64
+	PricePaid    int64
+	Status       BookingStatus
+	CancelledAt  time.Time
+	// SeriesUID is set on the occurrences of a recurring series.
+	SeriesUID string
+	// AccessPIN opens the door of the box together with the box name, once.
+	AccessPIN string
+	// CheckedInAt is when the door was first opened for the booking.
+	CheckedInAt time.Time
+	// Revision grows whenever the booking is moved or cancelled.
+	Revision int64
+}
+
+// BookingFilter narrows a bookings listing. Zero values mean "no filter".
+// From and To are matched against the booking start, To is exclusive.
+type BookingFilter struct {
+	Status BookingStatus
+	From   time.Time
+	To     time.Time
+	Limit  int
+	After  *BookingCursor
+}
+
+// BookingCursor points at the last booking of a page. Listings are ordered by
+// start time and ID, newest first.
+type BookingCursor struct {
+	StartsAt int64
+	ID       int64
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// ClockLayout is the format of the daily opening and closing times of a box.
+const ClockLayout = "15:04"
+
+// EndOfDay is the closing time of a box open until midnight.
+const EndOfDay = "24:00"
+
+type Box struct {
+	ID          int64
+	Name        string
+	Address     string
+	Description string
+	// Capacity is the largest number of people the box takes at once.
+	Capacity     int64
+	PricePerHour int64
+	TimeZone     string
+	Active       bool
+	OpensAt      string
+	ClosesAt     string
+	// SharedSessions lets independent bookings share a slot until the box is
+	// full. Otherwise every booking takes the whole box.
+	SharedSessions bool
+	// MinCharge is the smallest price of one booking.
+	MinCharge int64
+	// SurgePercent applies to bookings on days when at least SurgeOccupancy
+	// percent of the opening hours are booked. Zero occupancy turns it off.
+	SurgeOccupancy int64
+	SurgePercent   int64
+	// VenueID is the venue the box stands at, zero without one.
+	VenueID int64
+}
+
+// Location returns the time zone local booking times of the box are given in.
+func (b Box) Location() (*time.Location, error) {
+	return time.LoadLocation(b.TimeZone)
+}
+
+// OpeningHours returns the opening and closing moments of the box on the
+// calendar day of date, in the box's time zone.
+func (b Box) OpeningHours(date time.Time) (time.Time, time.Time, error) {
+	loc, err := b.Location()
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	y, m, d := date.In(loc).Date()
+
+	opens, err := ClockTime(y, m, d, b.OpensAt, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid opening time %q: %w", b.OpensAt, err)
+	}
+
+	closes, err := ClockTime(y, m, d, b.ClosesAt, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid closing time %q: %w", b.ClosesAt, err)
+	}
+
+	return opens, closes, nil
+}
+
+// ClockTime returns the moment of a ClockLayout clock time on the calendar day
+// y-m-d in loc. "24:00" is the end of the day.
+func ClockTime(y int, m time.Month, d int, clock string, loc *time.Location) (time.Time, error) {
+	if clock == EndOfDay {
+		return time.Date(y, m, d+1, 0, 0, 0, 0, loc), nil
+	}
+
+	t, err := time.Parse(ClockLayout, clock)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Date(y, m, d, t.Hour(), t.Minute(), 0, 0, loc), nil
+}
@@ -0,0 +1,8 @@
+package models
+
+// CalendarFeed is the private calendar feed of the bookings of a user. The
+// token is the only credential of the feed URL.
+type CalendarFeed struct {
+	Email string
+	Token string
+}
@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// EventType is the kind of a booking lifecycle event.
+type EventType string
+
+const (
+	EventBookingCreated   EventType = "booking.created"
+	EventBookingCancelled EventType = "booking.cancelled"
+	EventBookingCompleted EventType = "booking.completed"
+	// EventBookingRefunded is money given back for a booking: a cancellation,
+	// a booking that could not be confirmed or a cheaper reschedule.
+	EventBookingRefunded EventType = "booking.refunded"
+)
+
+// Event is a booking lifecycle event in the outbox. It is written in the
+// same transaction as the booking change and published at least once, so
+// consumers drop duplicates by UID.
+type Event struct {
+	// ID orders the events of the outbox.
+	ID         int64
+	UID        string
+	Type       EventType
+	BookingUID string
+	// Payload is the JSON of the booking as it was after the change.
+	Payload   []byte
+	Attempts  int
+	Error     string
+	CreatedAt time.Time
+}
+
+// EventFilter selects the events to replay. Zero fields match every event.
+type EventFilter struct {
+	FromID     int64
+	Since      time.Time
+	Type       EventType
+	BookingUID string
+}
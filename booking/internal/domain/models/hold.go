@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// Hold is a slot reserved for a user during checkout. It is a booking in the
+// held status until HeldUntil, Book turns it into a real booking.
+type Hold struct {
+	UID          string
+	Email        string
+	BoxName      string
+	StartsAt     time.Time
+	ExpiresAt    time.Time
+	PeopleAmount int64
+	HeldUntil    time.Time
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// IdempotentOperation names the requests that can be made with an
+// idempotency key.
+type IdempotentOperation string
+
+const (
+	IdempotentBook   IdempotentOperation = "book"
+	IdempotentCancel IdempotentOperation = "cancel"
+)
+
+// IdempotencyRecord is a request remembered under the idempotency key of a
+// user. Request describes the parameters, a repeated key must come with the
+// same ones. Result is empty while the first request is running.
+//
+// A request whose payment outcome is unknown is left to the saga recovery:
+// SagaID is its saga and SagaState the state the saga is in now. Its Result
+// only stands once the saga has completed.
+type IdempotencyRecord struct {
+	Email     string
+	Key       string
+	Operation IdempotentOperation
+	Request   string
+	Result    []byte
+	SagaID    int64
+	SagaState SagaState
+	CreatedAt time.Time
+}
@@ -0,0 +1,99 @@
+package models
+
+import "time"
+
+// NotificationKind tells what a notification is about.
+type NotificationKind string
+
+const (
+	NotificationBooked      NotificationKind = "booked"
+	NotificationRescheduled NotificationKind = "rescheduled"
+	NotificationCancelled   NotificationKind = "cancelled"
+	// NotificationRefunded is money given back outside of a cancellation,
+	// e.g. a booking that could not be confirmed or a cheaper reschedule.
+	NotificationRefunded NotificationKind = "refunded"
+	// NotificationReminder goes out a while before the booking starts.
+	NotificationReminder NotificationKind = "reminder"
+	// NotificationInvited tells a participant they are invited to a booking.
+	NotificationInvited NotificationKind = "invited"
+)
+
+type NotificationStatus string
+
+const (
+	// NotificationStatusPending waits for NextAttemptAt to be sent.
+	NotificationStatusPending NotificationStatus = "pending"
+	NotificationStatusSent    NotificationStatus = "sent"
+	// NotificationStatusFailed ran out of attempts.
+	NotificationStatusFailed NotificationStatus = "failed"
+	// NotificationStatusSkipped is a reminder of a booking that was moved or
+	// cancelled before it went out.
+	NotificationStatusSkipped NotificationStatus = "skipped"
+)
+
+// Locale is the language of the notifications of a user.
+type Locale string
+
+const (
+	LocaleRU Locale = "ru"
+	LocaleEN Locale = "en"
+)
+
+func (l Locale) Valid() bool {
+	return l == LocaleRU || l == LocaleEN
+}
+
+// Notice is a change of a booking the user is told about.
+type Notice struct {
+	Kind    NotificationKind
+	Booking Booking
+	// Amount is the money paid or refunded with the change, if any.
+	Amount int64
+	// Ref tells notices of the same kind about one booking apart, e.g. the
+	// saga that refunded the money.
+	Ref string
+	// To is the recipient when it isn't the owner of the booking, e.g. an
+	// invited participant.
+	To string
+}
+
+// Notification is the delivery of a notice over one channel. The booking is
+// copied as it was when the notice was made.
+type Notification struct {
+	ID  int64
+	UID string
+	// Key keeps the same notice from being queued twice.
+	Key          string
+	Email        string
+	Kind         NotificationKind
+	Channel      string
+	Locale       Locale
+	BookingUID   string
+	BoxName      string
+	StartsAt     time.Time
+	ExpiresAt    time.Time
+	PeopleAmount int64
+	Amount       int64
+	// RemindBefore is how long before the start a reminder goes out.
+	RemindBefore time.Duration
+	Status       NotificationStatus
+	Attempts     int
+	// NextAttemptAt is when the notification is sent or retried.
+	NextAttemptAt time.Time
+	Error         string
+	SentAt        time.Time
+	CreatedAt     time.Time
+}
+
+// NotificationMessage is a rendered notification ready to go out over a
+// channel. ID stays the same between retries, so receivers can drop
+// duplicates.
+type NotificationMessage struct {
+	ID         string
+	Kind       NotificationKind
+	To         string
+	Locale     Locale
+	Subject    string
+	Body       string
+	BookingUID string
+}
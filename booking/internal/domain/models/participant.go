@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+type ParticipantStatus string
+
+const (
+	ParticipantStatusInvited  ParticipantStatus = "invited"
+	ParticipantStatusAccepted ParticipantStatus = "accepted"
+	ParticipantStatusDeclined ParticipantStatus = "declined"
+)
+
+// Participant is someone the owner of a booking invited to come along.
+type Participant struct {
+	BookingUID  string
+	Email       string
+	Status      ParticipantStatus
+	InvitedAt   time.Time
+	RespondedAt time.Time
+}
+
+// Invitation is a booking the user is invited to and has not answered yet.
+type Invitation struct {
+	Booking   Booking
+	InvitedAt time.Time
+}
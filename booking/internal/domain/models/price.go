@@ -0,0 +1,60 @@
+package models
+
+import "time"
+
+// PriceRule multiplies the base rate of a box during a part of the day.
+type PriceRule struct {
+	Name    string
+	BoxName string
+	// Weekday is nil for rules that apply every day.
+	Weekday  *time.Weekday
+	StartsAt string
+	EndsAt   string
+	Percent  int64
+}
+
+// HolidayRate replaces every price rule of the box on one date.
+type HolidayRate struct {
+	Name    string
+	BoxName string
+	Date    string
+	Percent int64
+}
+
+// PriceLine is a part of the booking charged at one rate.
+type PriceLine struct {
+	Name      string
+	StartsAt  time.Time
+	ExpiresAt time.Time
+	Percent   int64
+	Amount    int64
+}
+
+// Price is the cost of a booking with the way it was calculated.
+type Price struct {
+	PricePerHour int64
+	// PeopleAmount is the number of people charged for, 1 unless the box
+	// has shared sessions.
+	PeopleAmount int64
+	Lines        []PriceLine
+	Subtotal     int64
+	SurgePercent int64
+	MinCharge    int64
+	// PromoCode is the code the Discount was taken off with, if any.
+	PromoCode string
+	Discount  int64
+	Total     int64
+}
+
+// Quote is a price offer for a booking that has not been made yet.
+type Quote struct {
+	Price    Price
+	Balance  int64
+	SlotFree bool
+}
+
+// EnoughFunds reports whether the wallet covers the price. An unknown balance
+// is negative and never does.
+func (q Quote) EnoughFunds() bool {
+	return q.Balance >= q.Price.Total
+}
@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+type PromoKind string
+
+const (
+	// PromoKindPercent takes Value percent off the price.
+	PromoKindPercent PromoKind = "percent"
+	// PromoKindFixed takes Value off the price.
+	PromoKindFixed PromoKind = "fixed"
+)
+
+// PromoCode is a discount on bookings of BoxName, every box when it is empty,
+// that fit between the StartsAt and EndsAt clock times local to the box.
+// Zero ValidFrom and ValidUntil leave the validity window open, zero
+// MaxRedemptions and MaxPerUser mean no limit.
+type PromoCode struct {
+	Code           string
+	Kind           PromoKind
+	Value          int64
+	BoxName        string
+	StartsAt       string
+	EndsAt         string
+	ValidFrom      time.Time
+	ValidUntil     time.Time
+	MaxRedemptions int64
+	MaxPerUser     int64
+}
+
+// Discount returns the amount the code takes off total, never more than total.
+func (p PromoCode) Discount(total int64) int64 {
+	var discount int64
+
+	switch p.Kind {
+	case PromoKindPercent:
+		discount = total * p.Value / 100
+	case PromoKindFixed:
+		discount = p.Value
+	}
+
+	return min(max(discount, 0), total)
+}
+
+// PromoUsage is how many redemptions of a code count against its limits.
+type PromoUsage struct {
+	Total  int64
+	ByUser int64
+}
@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// CancelInitiator tells who asked for a cancellation.
+type CancelInitiator string
+
+const (
+	CancelByUser     CancelInitiator = "user"
+	CancelByOperator CancelInitiator = "operator"
+)
+
+// RefundPolicyName is the rule of the refund policy applied to a cancellation.
+type RefundPolicyName string
+
+const (
+	// RefundPolicyFull is applied until the full refund deadline.
+	RefundPolicyFull RefundPolicyName = "full"
+	// RefundPolicyPartial is applied between the deadline and the start.
+	RefundPolicyPartial RefundPolicyName = "partial"
+	// RefundPolicyNone is applied once the booking has started.
+	RefundPolicyNone RefundPolicyName = "none"
+	// RefundPolicyOperator is applied to cancellations made by an operator,
+	// which are always refunded in full.
+	RefundPolicyOperator RefundPolicyName = "operator"
+)
+
+// Refund is the part of the paid price given back on cancellation.
+type Refund struct {
+	Policy  RefundPolicyName
+	Percent int64
+	Amount  int64
+	// FullRefundUntil is the moment the booking stops being refunded in full.
+	FullRefundUntil time.Time
+}
@@ -0,0 +1,59 @@
+package models
+
+import "time"
+
+// SagaKind tells which flow a saga coordinates between the booking storage
+// and the payments service.
+type SagaKind string
+
+const (
+	// SagaKindBook: reserve the slot, charge the wallet, confirm the booking.
+	SagaKindBook SagaKind = "book"
+	// SagaKindCancel: hold the booking as cancelling, refund, confirm the cancellation.
+	SagaKindCancel SagaKind = "cancel"
+	// SagaKindReschedule: settle the price difference of a moved booking. A
+	// higher price is charged before the move, a lower one refunded after it.
+	SagaKindReschedule SagaKind = "reschedule"
+)
+
+type SagaState string
+
+const (
+	// SagaStateReserved: the booking row is pending, the wallet is not charged yet.
+	SagaStateReserved SagaState = "reserved"
+	// SagaStateCharged: the wallet is charged, the booking is not confirmed yet.
+	SagaStateCharged SagaState = "charged"
+	// SagaStateRefunding: money has to go back to the wallet.
+	SagaStateRefunding SagaState = "refunding"
+	// SagaStateRefunded: the refund went through, the booking change is not stored yet.
+	SagaStateRefunded SagaState = "refunded"
+
+	SagaStateCompleted   SagaState = "completed"
+	SagaStateCompensated SagaState = "compensated"
+	// SagaStateFailed needs an operator. Older versions left the sagas whose
+	// payment outcome was unknown in it.
+	SagaStateFailed SagaState = "failed"
+)
+
+// Final reports whether the saga needs no further processing.
+func (s SagaState) Final() bool {
+	return s == SagaStateCompleted || s == SagaStateCompensated || s == SagaStateFailed
+}
+
+type Saga struct {
+	ID        int64
+	Kind      SagaKind
+	State     SagaState
+	BookingID string
+	// LegacyBookingID is the numeric ID of the booking, kept while clients
+	// move over to opaque IDs.
+	LegacyBookingID int64
+	Email           string
+	Amount          int64
+	Error           string
+	// PaymentKey and PaymentAmount are the idempotency key and the total of a
+	// payment shared with other sagas. A saga charged on its own has none.
+	PaymentKey    string
+	PaymentAmount int64
+	UpdatedAt     time.Time
+}
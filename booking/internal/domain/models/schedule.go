@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+// WeeklyHours are the opening hours of a box on one day of the week. They
+// replace the daily OpensAt and ClosesAt of the box on that day.
+type WeeklyHours struct {
+	Weekday  time.Weekday
+	OpensAt  string
+	ClosesAt string
+	// Closed keeps the box shut the whole day.
+	Closed bool
+}
+
+type ClosureKind string
+
+const (
+	ClosureKindHoliday ClosureKind = "holiday"
+	ClosureKindClosure ClosureKind = "closure"
+)
+
+func (k ClosureKind) Valid() bool {
+	return k == ClosureKindHoliday || k == ClosureKindClosure
+}
+
+// Closure keeps a box shut for a whole local date. An empty BoxName closes
+// every box.
+type Closure struct {
+	UID     string
+	BoxName string
+	// Date is the calendar day in the time zone of the box, YYYY-MM-DD.
+	Date string
+	Kind ClosureKind
+	Name string
+}
+
+// Blackout takes a box out of service for maintenance.
+type Blackout struct {
+	UID      string
+	BoxName  string
+	StartsAt time.Time
+	EndsAt   time.Time
+	Reason   string
+}
+
+// Schedule is everything besides other bookings that decides when a box can
+// be booked.
+type Schedule struct {
+	Box       Box
+	Weekly    []WeeklyHours
+	Closures  []Closure
+	Blackouts []Blackout
+}
@@ -0,0 +1,66 @@
+package models
+
+import "time"
+
+// SeriesPayment tells how a booking series is paid for.
+type SeriesPayment string
+
+const (
+	// SeriesPaymentPerOccurrence charges every occurrence on its own, so a
+	// failed payment only loses that occurrence.
+	SeriesPaymentPerOccurrence SeriesPayment = "per_occurrence"
+	// SeriesPaymentUpfront charges the whole series at once. Either every free
+	// occurrence is booked or none is.
+	SeriesPaymentUpfront SeriesPayment = "upfront"
+)
+
+func (p SeriesPayment) Valid() bool {
+	return p == SeriesPaymentPerOccurrence || p == SeriesPaymentUpfront
+}
+
+// Series is a group of bookings of one box repeated by a recurrence rule.
+type Series struct {
+	UID          string
+	ID           int64
+	Email        string
+	BoxName      string
+	Rule         string
+	Payment      SeriesPayment
+	StartsAt     time.Time
+	Duration     time.Duration
+	PeopleAmount int64
+	CreatedAt    time.Time
+}
+
+// OccurrenceStatus is the outcome of booking one occurrence of a series.
+type OccurrenceStatus string
+
+const (
+	OccurrenceBooked        OccurrenceStatus = "booked"
+	OccurrenceConflict      OccurrenceStatus = "conflict"
+	OccurrencePaymentFailed OccurrenceStatus = "payment_failed"
+	// OccurrencePaymentPending: the outcome of the payment is unknown, the
+	// booking is settled by the saga recovery.
+	OccurrencePaymentPending OccurrenceStatus = "payment_pending"
+	OccurrenceFailed         OccurrenceStatus = "failed"
+)
+
+// Occurrence is one date of a series and what happened to it.
+type Occurrence struct {
+	StartsAt  time.Time
+	ExpiresAt time.Time
+	Status    OccurrenceStatus
+	// BookingUID is empty unless the occurrence was booked or its payment is
+	// pending.
+	BookingUID string
+	Price      Price
+	Error      string
+}
+
+// SeriesCancellation is the outcome of cancelling one booking of a series.
+type SeriesCancellation struct {
+	BookingUID string
+	StartsAt   time.Time
+	Refund     Refund
+	Error      string
+}
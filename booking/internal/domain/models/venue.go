@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// Venue is the place boxes stand at. Its boxes take its time zone.
+type Venue struct {
+	ID        int64
+	Name      string
+	Address   string
+	Latitude  float64
+	Longitude float64
+	TimeZone  string
+	Phone     string
+	Email     string
+	Website   string
+}
+
+// NearbyVenue is a venue found around a point with the summary of its boxes
+// for the current day.
+type NearbyVenue struct {
+	Venue      Venue
+	DistanceKm float64
+	Boxes      []BoxDaySummary
+}
+
+// BoxDaySummary sums up the availability of a box on one local day. NextFree
+// is the start of the first free slot left, zero when none is.
+type BoxDaySummary struct {
+	Box         Box
+	Date        time.Time
+	OpensAt     time.Time
+	ClosesAt    time.Time
+	FreeMinutes int64
+	FreeSlots   int64
+	NextFree    time.Time
+}
@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+// WaitlistMode tells what happens when the slot of a waitlist entry frees up.
+type WaitlistMode string
+
+const (
+	// WaitlistModeOffer holds the slot for the user for a while, the user has
+	// to accept the offer to book and pay.
+	WaitlistModeOffer WaitlistMode = "offer"
+	// WaitlistModeAutoBook books the slot and charges the user right away.
+	WaitlistModeAutoBook WaitlistMode = "auto_book"
+)
+
+func (m WaitlistMode) Valid() bool {
+	return m == WaitlistModeOffer || m == WaitlistModeAutoBook
+}
+
+type WaitlistStatus string
+
+const (
+	WaitlistStatusWaiting WaitlistStatus = "waiting"
+	// WaitlistStatusOffered holds the slot until OfferExpiresAt.
+	WaitlistStatusOffered   WaitlistStatus = "offered"
+	WaitlistStatusBooked    WaitlistStatus = "booked"
+	WaitlistStatusExpired   WaitlistStatus = "expired"
+	WaitlistStatusCancelled WaitlistStatus = "cancelled"
+	// WaitlistStatusFailed is an entry whose booking failed, e.g. because its
+	// payment was declined or the box closed. Error tells why.
+	WaitlistStatusFailed WaitlistStatus = "failed"
+)
+
+// WaitlistEntry is a user waiting for a slot of a fully booked box.
+type WaitlistEntry struct {
+	UID          string
+	ID           int64
+	Email        string
+	BoxName      string
+	StartsAt     time.Time
+	ExpiresAt    time.Time
+	PeopleAmount int64
+	Mode         WaitlistMode
+	Status       WaitlistStatus
+	// OfferExpiresAt is set while the entry is offered.
+	OfferExpiresAt time.Time
+	// BookingUID is the held booking of an offer or the booking made for the entry.
+	BookingUID string
+	Error      string
+	CreatedAt  time.Time
+}
@@ -0,0 +1,80 @@
+package bookgrpc
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/services/book"
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	bookingv1 "github.com/MKode312/protos/gen/go/booking"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (b *bookingServerAdapter) VerifyAccess(ctx context.Context, req *bookingv1.VerifyAccessRequest) (*bookingv1.VerifyAccessResponse, error) {
+	if req.GetBoxName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "boxName is required")
+	}
+
+	code := strings.TrimSpace(req.GetCode())
+	if code == "" {
+		return nil, status.Error(codes.InvalidArgument, "code is required")
+	}
+
+	checkIn, booking, err := b.originalServer.book.VerifyAccess(ctx, req.GetBoxName(), code, req.GetDoorId())
+	if err != nil {
+		if errors.Is(err, book.ErrInvalidAccessCode) {
+			return nil, status.Error(codes.PermissionDenied, "invalid access code")
+		}
+		if errors.Is(err, book.ErrBookingNotActive) {
+			return nil, status.Error(codes.PermissionDenied, "booking is not active")
+		}
+		if errors.Is(err, book.ErrAccessNotYetValid) {
+			return nil, status.Error(codes.PermissionDenied, "access code is not valid yet")
+		}
+		if errors.Is(err, book.ErrAccessExpired) {
+			return nil, status.Error(codes.PermissionDenied, "access code has expired")
+		}
+		if errors.Is(err, book.ErrAccessLocked) {
+			return nil, status.Error(codes.PermissionDenied, book.ErrAccessLocked.Error())
+		}
+		return nil, status.Error(codes.Internal, "failed to verify access")
+	}
+
+	return &bookingv1.VerifyAccessResponse{
+		Granted:    true,
+		BookingUid: booking.UID,
+		CheckInUid: checkIn.UID,
+		ValidUntil: booking.ExpiresAt.Format(time.RFC3339),
+	}, nil
+}
+
+// bookingAccess returns the door credentials of a just made booking, nil
+// when the booking can't be read.
+func (b *bookingServerAdapter) bookingAccess(ctx context.Context, bookingUID string) *bookingv1.AccessCredentials {
+	booking, err := b.originalServer.book.Booking(ctx, bookingUID)
+	if err != nil {
+		return nil
+	}
+
+	return b.toProtoAccess(booking)
+}
+
+// toProtoAccess returns the door credentials of an active booking, nil for
+// any other booking.
+func (b *bookingServerAdapter) toProtoAccess(booking models.Booking) *bookingv1.AccessCredentials {
+	if booking.Status != models.BookingStatusActive {
+		return nil
+	}
+
+	credentials := b.originalServer.book.Credentials(booking)
+
+	return &bookingv1.AccessCredentials{
+		Pin:        credentials.PIN,
+		Token:      credentials.Token,
+		ValidFrom:  credentials.ValidFrom.Format(time.RFC3339),
+		ValidUntil: credentials.ValidUntil.Format(time.RFC3339),
+	}
+}
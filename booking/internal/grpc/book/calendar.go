@@ -0,0 +1,56 @@
+package bookgrpc
+
+import (
+	"booking/internal/services/book"
+	"context"
+	"errors"
+
+	bookingv1 "github.com/MKode312/protos/gen/go/booking"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (b *bookingServerAdapter) GetCalendarFeed(ctx context.Context, req *bookingv1.GetCalendarFeedRequest) (*bookingv1.GetCalendarFeedResponse, error) {
+	if req.GetEmail() == "" {
+		return nil, status.Error(codes.InvalidArgument, "email is required")
+	}
+
+	feed, err := b.originalServer.book.CalendarFeed(ctx, req.GetEmail(), req.GetRotate())
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get calendar feed")
+	}
+
+	return &bookingv1.GetCalendarFeedResponse{Token: feed.Token}, nil
+}
+
+func (b *bookingServerAdapter) GetBookingsCalendar(ctx context.Context, req *bookingv1.GetBookingsCalendarRequest) (*bookingv1.CalendarResponse, error) {
+	if req.GetToken() == "" {
+		return nil, status.Error(codes.InvalidArgument, "token is required")
+	}
+
+	calendar, err := b.originalServer.book.UserCalendar(ctx, req.GetToken())
+	if err != nil {
+		if errors.Is(err, book.ErrCalendarFeedNotFound) {
+			return nil, status.Error(codes.NotFound, "calendar feed not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to get calendar")
+	}
+
+	return &bookingv1.CalendarResponse{Calendar: string(calendar)}, nil
+}
+
+func (b *bookingServerAdapter) GetBoxCalendar(ctx context.Context, req *bookingv1.GetBoxCalendarRequest) (*bookingv1.CalendarResponse, error) {
+	if req.GetBoxName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "boxName is required")
+	}
+
+	calendar, err := b.originalServer.book.BoxCalendar(ctx, req.GetBoxName())
+	if err != nil {
+		if errors.Is(err, book.ErrBoxNotFound) {
+			return nil, status.Error(codes.NotFound, "box not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to get calendar")
+	}
+
+	return &bookingv1.CalendarResponse{Calendar: string(calendar)}, nil
+}
@@ -0,0 +1,123 @@
+package bookgrpc
+
+import (
+	"booking/internal/services/book"
+	"context"
+	"errors"
+	"time"
+
+	bookingv1 "github.com/MKode312/protos/gen/go/booking"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (b *bookingServerAdapter) HoldSlot(ctx context.Context, req *bookingv1.HoldSlotRequest) (*bookingv1.HoldSlotResponse, error) {
+	if req.GetBoxName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "boxName is required")
+	}
+
+	box, err := b.originalServer.book.Box(ctx, req.GetBoxName())
+	if err != nil {
+		if errors.Is(err, book.ErrBoxNotFound) {
+			return nil, status.Error(codes.NotFound, "boxName not found")
+		}
+		return nil, status.Error(codes.Internal, "internal error occured")
+	}
+
+	bookReq := &bookingv1.BookRequest{
+		Email:        req.GetEmail(),
+		BoxName:      req.GetBoxName(),
+		PeopleAmount: req.GetPeopleAmount(),
+		TimeStart:    req.GetTimeStart(),
+		TimeHrs:      req.GetTimeHrs(),
+		TimeMins:     req.GetTimeMins(),
+	}
+
+	startsAt, err := validate(bookReq, box)
+	if err != nil {
+		return nil, err
+	}
+
+	duration := time.Duration(req.GetTimeHrs())*time.Hour + time.Duration(req.GetTimeMins())*time.Minute
+
+	hold, price, err := b.originalServer.book.HoldSlot(ctx, req.GetEmail(), req.GetBoxName(), startsAt, duration, req.GetPeopleAmount())
+	if err != nil {
+		if err := scheduleError(err); err != nil {
+			return nil, err
+		}
+		if err := holdError(err); err != nil {
+			return nil, err
+		}
+		if errors.Is(err, book.ErrAlreadyBooked) {
+			return nil, status.Error(codes.AlreadyExists, "this box is already booked")
+		}
+		return nil, status.Error(codes.Internal, "failed to hold the slot")
+	}
+
+	return &bookingv1.HoldSlotResponse{
+		HoldId:    hold.UID,
+		HeldUntil: hold.HeldUntil.Format(time.RFC3339),
+		Price:     toProtoPrice(price),
+	}, nil
+}
+
+// bookHold books the slot of a hold, the slot fields of the request are not
+// used then.
+func (b *bookingServerAdapter) bookHold(ctx context.Context, req *bookingv1.BookRequest) (*bookingv1.BookResponse, error) {
+	if req.GetEmail() == "" {
+		return nil, status.Error(codes.InvalidArgument, "email is required")
+	}
+
+	booking, price, balance, err := b.originalServer.book.BookHold(ctx, req.GetEmail(), req.GetHoldId(), req.GetPromoCode(), req.GetIdempotencyKey())
+	if err != nil {
+		if err := idempotencyError(err); err != nil {
+			return nil, err
+		}
+		if err := holdError(err); err != nil {
+			return nil, err
+		}
+		if err := scheduleError(err); err != nil {
+			return nil, err
+		}
+		if err := promoError(err); err != nil {
+			return nil, err
+		}
+		if errors.Is(err, book.ErrNotEnoughFunds) {
+			return nil, status.Error(codes.OutOfRange, "not enough funds to pay")
+		}
+		if errors.Is(err, book.ErrCardNotFound) {
+			return nil, status.Error(codes.NotFound, "card not found")
+		}
+		if errors.Is(err, book.ErrPaymentFailed) {
+			return nil, status.Error(codes.Canceled, "failed to pay for the booking")
+		}
+		if errors.Is(err, book.ErrPaymentPending) {
+			return nil, status.Error(codes.Unavailable, "payment is being processed")
+		}
+		return nil, status.Error(codes.Internal, "failed to book a box")
+	}
+
+	return &bookingv1.BookResponse{
+		ReserveId:  booking.ID,
+		BookingUid: booking.UID,
+		Balance:    balance,
+		Success:    true,
+		Price:      toProtoPrice(price),
+		Access:     b.bookingAccess(ctx, booking.UID),
+	}, nil
+}
+
+func holdError(err error) error {
+	switch {
+	case errors.Is(err, book.ErrHoldNotFound):
+		return status.Error(codes.NotFound, book.ErrHoldNotFound.Error())
+	case errors.Is(err, book.ErrNotYourHold):
+		return status.Error(codes.PermissionDenied, book.ErrNotYourHold.Error())
+	case errors.Is(err, book.ErrHoldExpired):
+		return status.Error(codes.FailedPrecondition, book.ErrHoldExpired.Error())
+	case errors.Is(err, book.ErrTooManyHolds):
+		return status.Error(codes.ResourceExhausted, book.ErrTooManyHolds.Error())
+	}
+
+	return nil
+}
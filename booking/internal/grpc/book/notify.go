@@ -0,0 +1,29 @@
+package bookgrpc
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/services/book"
+	"context"
+	"errors"
+
+	bookingv1 "github.com/MKode312/protos/gen/go/booking"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (b *bookingServerAdapter) SetNotificationSettings(ctx context.Context, req *bookingv1.SetNotificationSettingsRequest) (*bookingv1.SetNotificationSettingsResponse, error) {
+	if req.GetEmail() == "" {
+		return nil, status.Error(codes.InvalidArgument, "email is required")
+	}
+
+	locale := models.Locale(req.GetLocale())
+
+	if err := b.originalServer.book.SetNotificationLocale(ctx, req.GetEmail(), locale); err != nil {
+		if errors.Is(err, book.ErrInvalidLocale) {
+			return nil, status.Error(codes.InvalidArgument, book.ErrInvalidLocale.Error())
+		}
+		return nil, status.Error(codes.Internal, "failed to save notification settings")
+	}
+
+	return &bookingv1.SetNotificationSettingsResponse{Locale: string(locale)}, nil
+}
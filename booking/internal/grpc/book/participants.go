@@ -0,0 +1,149 @@
+package bookgrpc
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/services/book"
+	"context"
+	"errors"
+	"time"
+
+	bookingv1 "github.com/MKode312/protos/gen/go/booking"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (b *bookingServerAdapter) InviteParticipant(ctx context.Context, req *bookingv1.InviteParticipantRequest) (*bookingv1.InviteParticipantResponse, error) {
+	if req.GetEmail() == "" {
+		return nil, status.Error(codes.InvalidArgument, "email is required")
+	}
+	if req.GetBookingUid() == "" {
+		return nil, status.Error(codes.InvalidArgument, "booking ID is required")
+	}
+	if req.GetParticipantEmail() == "" {
+		return nil, status.Error(codes.InvalidArgument, "participant email is required")
+	}
+
+	participant, err := b.originalServer.book.InviteParticipant(ctx, req.GetEmail(), req.GetBookingUid(), req.GetParticipantEmail())
+	if err != nil {
+		if err := participantError(err); err != nil {
+			return nil, err
+		}
+		return nil, status.Error(codes.Internal, "failed to invite the participant")
+	}
+
+	return &bookingv1.InviteParticipantResponse{
+		Participant: toProtoParticipant(participant),
+	}, nil
+}
+
+func (b *bookingServerAdapter) RespondToInvitation(ctx context.Context, req *bookingv1.RespondToInvitationRequest) (*bookingv1.RespondToInvitationResponse, error) {
+	if req.GetEmail() == "" {
+		return nil, status.Error(codes.InvalidArgument, "email is required")
+	}
+	if req.GetBookingUid() == "" {
+		return nil, status.Error(codes.InvalidArgument, "booking ID is required")
+	}
+
+	participant, err := b.originalServer.book.RespondToInvitation(ctx, req.GetEmail(), req.GetBookingUid(), req.GetAccept())
+	if err != nil {
+		if err := participantError(err); err != nil {
+			return nil, err
+		}
+		return nil, status.Error(codes.Internal, "failed to answer the invitation")
+	}
+
+	return &bookingv1.RespondToInvitationResponse{
+		Participant: toProtoParticipant(participant),
+	}, nil
+}
+
+func (b *bookingServerAdapter) GetParticipants(ctx context.Context, req *bookingv1.GetParticipantsRequest) (*bookingv1.GetParticipantsResponse, error) {
+	if req.GetEmail() == "" {
+		return nil, status.Error(codes.InvalidArgument, "email is required")
+	}
+	if req.GetBookingUid() == "" {
+		return nil, status.Error(codes.InvalidArgument, "booking ID is required")
+	}
+
+	participants, err := b.originalServer.book.Participants(ctx, req.GetEmail(), req.GetBookingUid())
+	if err != nil {
+		if err := participantError(err); err != nil {
+			return nil, err
+		}
+		return nil, status.Error(codes.Internal, "failed to get participants")
+	}
+
+	resp := &bookingv1.GetParticipantsResponse{
+		Participants: make([]*bookingv1.Participant, 0, len(participants)),
+	}
+
+	for _, participant := range participants {
+		resp.Participants = append(resp.Participants, toProtoParticipant(participant))
+	}
+
+	return resp, nil
+}
+
+func (b *bookingServerAdapter) GetInvitations(ctx context.Context, req *bookingv1.GetInvitationsRequest) (*bookingv1.GetInvitationsResponse, error) {
+	if req.GetEmail() == "" {
+		return nil, status.Error(codes.InvalidArgument, "email is required")
+	}
+
+	invitations, err := b.originalServer.book.Invitations(ctx, req.GetEmail())
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get invitations")
+	}
+
+	resp := &bookingv1.GetInvitationsResponse{
+		Invitations: make([]*bookingv1.Invitation, 0, len(invitations)),
+	}
+
+	for _, invitation := range invitations {
+		resp.Invitations = append(resp.Invitations, &bookingv1.Invitation{
+			Booking:   toProtoBooking(invitation.Booking),
+			InvitedAt: invitation.InvitedAt.Format(time.RFC3339),
+		})
+	}
+
+	return resp, nil
+}
+
+func participantError(err error) error {
+	switch {
+	case errors.Is(err, book.ErrBookingNotFound):
+		return status.Error(codes.NotFound, book.ErrBookingNotFound.Error())
+	case errors.Is(err, book.ErrInvitationNotFound):
+		return status.Error(codes.NotFound, book.ErrInvitationNotFound.Error())
+	case errors.Is(err, book.ErrNotYourBooking):
+		return status.Error(codes.PermissionDenied, book.ErrNotYourBooking.Error())
+	case errors.Is(err, book.ErrInviteOwner):
+		return status.Error(codes.InvalidArgument, book.ErrInviteOwner.Error())
+	case errors.Is(err, book.ErrAlreadyInvited):
+		return status.Error(codes.AlreadyExists, book.ErrAlreadyInvited.Error())
+	case errors.Is(err, book.ErrTooManyParticipants):
+		return status.Error(codes.ResourceExhausted, book.ErrTooManyParticipants.Error())
+	case errors.Is(err, book.ErrBookingNotActive):
+		return status.Error(codes.FailedPrecondition, book.ErrBookingNotActive.Error())
+	case errors.Is(err, book.ErrBookingStarted):
+		return status.Error(codes.FailedPrecondition, book.ErrBookingStarted.Error())
+	case errors.Is(err, book.ErrInvitationDeclined):
+		return status.Error(codes.FailedPrecondition, book.ErrInvitationDeclined.Error())
+	}
+
+	return nil
+}
+
+func toProtoParticipant(participant models.Participant) *bookingv1.Participant {
+	pb := &bookingv1.Participant{
+		BookingUid: participant.BookingUID,
+		Email:      participant.Email,
+		Status:     string(participant.Status),
+		InvitedAt:  participant.InvitedAt.Format(time.RFC3339),
+	}
+
+	if !participant.RespondedAt.IsZero() {
+		pb.RespondedAt = participant.RespondedAt.Format(time.RFC3339)
+	}
+
+	return pb
+}
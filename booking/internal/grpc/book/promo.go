@@ -0,0 +1,24 @@
+package bookgrpc
+
+import (
+	"booking/internal/services/book"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func promoError(err error) error {
+	switch {
+	case errors.Is(err, book.ErrPromoCodeNotFound):
+		return status.Error(codes.NotFound, book.ErrPromoCodeNotFound.Error())
+	case errors.Is(err, book.ErrPromoCodeExpired):
+		return status.Error(codes.FailedPrecondition, book.ErrPromoCodeExpired.Error())
+	case errors.Is(err, book.ErrPromoCodeNotApplicable):
+		return status.Error(codes.FailedPrecondition, book.ErrPromoCodeNotApplicable.Error())
+	case errors.Is(err, book.ErrPromoCodeExhausted):
+		return status.Error(codes.ResourceExhausted, book.ErrPromoCodeExhausted.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package bookgrpc
+
+import (
+	"booking/internal/services/book"
+	"context"
+	"errors"
+	"time"
+
+	bookingv1 "github.com/MKode312/protos/gen/go/booking"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (b *bookingServerAdapter) RescheduleBooking(ctx context.Context, req *bookingv1.RescheduleBookingRequest) (*bookingv1.RescheduleBookingResponse, error) {
+	if req.GetBookingUid() == "" {
+		return nil, status.Error(codes.InvalidArgument, "booking ID is required")
+	}
+
+	if req.GetEmail() == "" {
+		return nil, status.Error(codes.InvalidArgument, "email is required")
+	}
+
+	current, err := b.originalServer.book.Booking(ctx, req.GetBookingUid())
+	if err != nil {
+		if errors.Is(err, book.ErrBookingNotFound) {
+			return nil, status.Error(codes.NotFound, "booking not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to reschedule booking")
+	}
+
+	boxName := req.GetBoxName()
+	if boxName == "" {
+		boxName = current.BoxName
+	}
+
+	box, err := b.originalServer.book.Box(ctx, boxName)
+	if err != nil {
+		if errors.Is(err, book.ErrBoxNotFound) {
+			return nil, status.Error(codes.NotFound, "boxName not found")
+		}
+		return nil, status.Error(codes.Internal, "internal error occured")
+	}
+
+	bookReq := &bookingv1.BookRequest{
+		Email:        req.GetEmail(),
+		BoxName:      boxName,
+		PeopleAmount: current.PeopleAmount,
+		TimeStart:    req.GetTimeStart(),
+		TimeHrs:      req.GetTimeHrs(),
+		TimeMins:     req.GetTimeMins(),
+	}
+
+	if bookReq.TimeStart == "" {
+		bookReq.TimeStart = current.StartsAt.Format(time.RFC3339)
+	}
+
+	if bookReq.TimeHrs == 0 && bookReq.TimeMins == 0 {
+		minutes := int64(current.ExpiresAt.Sub(current.StartsAt) / time.Minute)
+		bookReq.TimeHrs, bookReq.TimeMins = minutes/60, minutes%60
+	}
+
+	startsAt, err := validate(bookReq, box)
+	if err != nil {
+		return nil, err
+	}
+
+	duration := time.Duration(bookReq.TimeHrs)*time.Hour + time.Duration(bookReq.TimeMins)*time.Minute
+
+	booking, price, balance, err := b.originalServer.book.RescheduleBooking(ctx, req.GetEmail(), req.GetBookingUid(), boxName, startsAt, duration)
+	if err != nil {
+		if errors.Is(err, book.ErrBookingNotFound) {
+			return nil, status.Error(codes.NotFound, "booking not found")
+		}
+		if errors.Is(err, book.ErrNotYourBooking) {
+			return nil, status.Error(codes.PermissionDenied, "this booking belongs to another user")
+		}
+		if errors.Is(err, book.ErrBookingNotActive) {
+			return nil, status.Error(codes.FailedPrecondition, "booking is not active")
+		}
+		if errors.Is(err, book.ErrBookingStarted) {
+			return nil, status.Error(codes.FailedPrecondition, "booking has already started")
+		}
+		if err := scheduleError(err); err != nil {
+			return nil, err
+		}
+		if errors.Is(err, book.ErrAlreadyBooked) {
+			return nil, status.Error(codes.AlreadyExists, "this box is already booked")
+		}
+		if errors.Is(err, book.ErrNotEnoughFunds) {
+			return nil, status.Error(codes.OutOfRange, "not enough funds to pay")
+		}
+		if errors.Is(err, book.ErrCardNotFound) {
+			return nil, status.Error(codes.NotFound, "card not found")
+		}
+		if errors.Is(err, book.ErrPaymentFailed) {
+			return nil, status.Error(codes.Canceled, "failed to pay for the booking")
+		}
+		if errors.Is(err, book.ErrPaymentPending) {
+			return nil, status.Error(codes.Unavailable, "payment is being processed")
+		}
+		return nil, status.Error(codes.Internal, "failed to reschedule booking")
+	}
+
+	pb := toProtoBooking(booking)
+	pb.Access = b.toProtoAccess(booking)
+
+	resp := &bookingv1.RescheduleBookingResponse{
+		Booking: pb,
+		Price:   toProtoPrice(price),
+		Balance: balance,
+	}
+
+	if difference := booking.PricePaid - current.PricePaid; difference > 0 {
+		resp.ChargedAmount = difference
+	} else {
+		resp.RefundedAmount = -difference
+	}
+
+	return resp, nil
+}
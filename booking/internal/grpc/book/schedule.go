@@ -0,0 +1,248 @@
+package bookgrpc
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/services/book"
+	"context"
+	"errors"
+	"time"
+
+	bookingv1 "github.com/MKode312/protos/gen/go/booking"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultScheduleDays is how far GetSchedule looks ahead without a to date.
+const defaultScheduleDays = 30
+
+func (b *bookingServerAdapter) GetSchedule(ctx context.Context, req *bookingv1.GetScheduleRequest) (*bookingv1.GetScheduleResponse, error) {
+	if req.GetBoxName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "boxName is required")
+	}
+
+	box, err := b.originalServer.book.Box(ctx, req.GetBoxName())
+	if err != nil {
+		if errors.Is(err, book.ErrBoxNotFound) {
+			return nil, status.Error(codes.NotFound, "box not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to get schedule")
+	}
+
+	loc, err := box.Location()
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get schedule")
+	}
+
+	y, m, d := time.Now().In(loc).Date()
+	from := time.Date(y, m, d, 0, 0, 0, 0, loc)
+
+	if req.GetFrom() != "" {
+		from, err = time.ParseInLocation(dateLayout, req.GetFrom(), loc)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "from must be in YYYY-MM-DD format")
+		}
+	}
+
+	to := from.AddDate(0, 0, defaultScheduleDays)
+
+	if req.GetTo() != "" {
+		to, err = time.ParseInLocation(dateLayout, req.GetTo(), loc)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "to must be in YYYY-MM-DD format")
+		}
+		to = to.AddDate(0, 0, 1)
+	}
+
+	if !to.After(from) {
+		return nil, status.Error(codes.InvalidArgument, "invalid date range")
+	}
+
+	schedule, err := b.originalServer.book.Schedule(ctx, box.Name, from, to)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get schedule")
+	}
+
+	resp := &bookingv1.GetScheduleResponse{
+		Box:       toProtoBox(schedule.Box),
+		Weekly:    make([]*bookingv1.WeeklyHours, 0, len(schedule.Weekly)),
+		Closures:  make([]*bookingv1.Closure, 0, len(schedule.Closures)),
+		Blackouts: make([]*bookingv1.Blackout, 0, len(schedule.Blackouts)),
+	}
+
+	for _, hours := range schedule.Weekly {
+		resp.Weekly = append(resp.Weekly, &bookingv1.WeeklyHours{
+			Weekday:  int64(hours.Weekday),
+			OpensAt:  hours.OpensAt,
+			ClosesAt: hours.ClosesAt,
+			Closed:   hours.Closed,
+		})
+	}
+
+	for _, closure := range schedule.Closures {
+		resp.Closures = append(resp.Closures, toProtoClosure(closure))
+	}
+
+	for _, blackout := range schedule.Blackouts {
+		resp.Blackouts = append(resp.Blackouts, toProtoBlackout(blackout, loc))
+	}
+
+	return resp, nil
+}
+
+func (b *bookingServerAdapter) SetOpeningHours(ctx context.Context, req *bookingv1.SetOpeningHoursRequest) (*bookingv1.SetOpeningHoursResponse, error) {
+	if req.GetBoxName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "boxName is required")
+	}
+
+	hours := make([]models.WeeklyHours, 0, len(req.GetHours()))
+
+	for _, h := range req.GetHours() {
+		hours = append(hours, models.WeeklyHours{
+			Weekday:  time.Weekday(h.GetWeekday()),
+			OpensAt:  h.GetOpensAt(),
+			ClosesAt: h.GetClosesAt(),
+			Closed:   h.GetClosed(),
+		})
+	}
+
+	if err := b.originalServer.book.SetOpeningHours(ctx, req.GetBoxName(), hours); err != nil {
+		if err := scheduleAdminError(err); err != nil {
+			return nil, err
+		}
+		return nil, status.Error(codes.Internal, "failed to set opening hours")
+	}
+
+	return &bookingv1.SetOpeningHoursResponse{Success: true}, nil
+}
+
+func (b *bookingServerAdapter) AddClosure(ctx context.Context, req *bookingv1.AddClosureRequest) (*bookingv1.AddClosureResponse, error) {
+	pb := req.GetClosure()
+	if pb == nil {
+		return nil, status.Error(codes.InvalidArgument, "closure is required")
+	}
+
+	closure, err := b.originalServer.book.AddClosure(ctx, models.Closure{
+		BoxName: pb.GetBoxName(),
+		Date:    pb.GetDate(),
+		Kind:    models.ClosureKind(pb.GetKind()),
+		Name:    pb.GetName(),
+	})
+	if err != nil {
+		if err := scheduleAdminError(err); err != nil {
+			return nil, err
+		}
+		return nil, status.Error(codes.Internal, "failed to add closure")
+	}
+
+	return &bookingv1.AddClosureResponse{Closure: toProtoClosure(closure)}, nil
+}
+
+func (b *bookingServerAdapter) RemoveClosure(ctx context.Context, req *bookingv1.RemoveClosureRequest) (*bookingv1.RemoveClosureResponse, error) {
+	if req.GetUid() == "" {
+		return nil, status.Error(codes.InvalidArgument, "closure ID is required")
+	}
+
+	if err := b.originalServer.book.RemoveClosure(ctx, req.GetUid()); err != nil {
+		if err := scheduleAdminError(err); err != nil {
+			return nil, err
+		}
+		return nil, status.Error(codes.Internal, "failed to remove closure")
+	}
+
+	return &bookingv1.RemoveClosureResponse{Success: true}, nil
+}
+
+func (b *bookingServerAdapter) AddBlackout(ctx context.Context, req *bookingv1.AddBlackoutRequest) (*bookingv1.AddBlackoutResponse, error) {
+	pb := req.GetBlackout()
+	if pb == nil {
+		return nil, status.Error(codes.InvalidArgument, "blackout is required")
+	}
+
+	startsAt, err := time.Parse(time.RFC3339, pb.GetStartsAt())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "startsAt must be in RFC3339 format")
+	}
+
+	endsAt, err := time.Parse(time.RFC3339, pb.GetEndsAt())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "endsAt must be in RFC3339 format")
+	}
+
+	blackout, err := b.originalServer.book.AddBlackout(ctx, models.Blackout{
+		BoxName:  pb.GetBoxName(),
+		StartsAt: startsAt,
+		EndsAt:   endsAt,
+		Reason:   pb.GetReason(),
+	})
+	if err != nil {
+		if err := scheduleAdminError(err); err != nil {
+			return nil, err
+		}
+		return nil, status.Error(codes.Internal, "failed to add blackout")
+	}
+
+	return &bookingv1.AddBlackoutResponse{Blackout: toProtoBlackout(blackout, startsAt.Location())}, nil
+}
+
+func (b *bookingServerAdapter) RemoveBlackout(ctx context.Context, req *bookingv1.RemoveBlackoutRequest) (*bookingv1.RemoveBlackoutResponse, error) {
+	if req.GetUid() == "" {
+		return nil, status.Error(codes.InvalidArgument, "blackout ID is required")
+	}
+
+	if err := b.originalServer.book.RemoveBlackout(ctx, req.GetUid()); err != nil {
+		if err := scheduleAdminError(err); err != nil {
+			return nil, err
+		}
+		return nil, status.Error(codes.Internal, "failed to remove blackout")
+	}
+
+	return &bookingv1.RemoveBlackoutResponse{Success: true}, nil
+}
+
+// scheduleError maps the errors of a slot outside the schedule of the box,
+// nil means the error is not one of them.
+func scheduleError(err error) error {
+	switch {
+	case errors.Is(err, book.ErrOutsideOpeningHours):
+		return status.Error(codes.FailedPrecondition, book.ErrOutsideOpeningHours.Error())
+	case errors.Is(err, book.ErrMaintenance):
+		return status.Error(codes.FailedPrecondition, book.ErrMaintenance.Error())
+	}
+
+	return nil
+}
+
+func scheduleAdminError(err error) error {
+	switch {
+	case errors.Is(err, book.ErrBoxNotFound):
+		return status.Error(codes.NotFound, "box not found")
+	case errors.Is(err, book.ErrInvalidSchedule):
+		return status.Error(codes.InvalidArgument, book.ErrInvalidSchedule.Error())
+	case errors.Is(err, book.ErrClosureNotFound):
+		return status.Error(codes.NotFound, book.ErrClosureNotFound.Error())
+	case errors.Is(err, book.ErrBlackoutNotFound):
+		return status.Error(codes.NotFound, book.ErrBlackoutNotFound.Error())
+	}
+
+	return nil
+}
+
+func toProtoClosure(closure models.Closure) *bookingv1.Closure {
+	return &bookingv1.Closure{
+		Uid:     closure.UID,
+		BoxName: closure.BoxName,
+		Date:    closure.Date,
+		Kind:    string(closure.Kind),
+		Name:    closure.Name,
+	}
+}
+
+func toProtoBlackout(blackout models.Blackout, loc *time.Location) *bookingv1.Blackout {
+	return &bookingv1.Blackout{
+		Uid:      blackout.UID,
+		BoxName:  blackout.BoxName,
+		StartsAt: blackout.StartsAt.In(loc).Format(time.RFC3339),
+		EndsAt:   blackout.EndsAt.In(loc).Format(time.RFC3339),
+		Reason:   blackout.Reason,
+	}
+}
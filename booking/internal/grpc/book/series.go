@@ -0,0 +1,144 @@
+package bookgrpc
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/lib/rrule"
+	"booking/internal/services/book"
+	"context"
+	"errors"
+	"time"
+
+	bookingv1 "github.com/MKode312/protos/gen/go/booking"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (b *bookingServerAdapter) BookSeries(ctx context.Context, req *bookingv1.BookSeriesRequest) (*bookingv1.BookSeriesResponse, error) {
+	if req.GetBoxName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "boxName is required")
+	}
+
+	if req.GetRrule() == "" {
+		return nil, status.Error(codes.InvalidArgument, "rrule is required")
+	}
+
+	payment := models.SeriesPayment(req.GetPayment())
+	if payment == "" {
+		payment = models.SeriesPaymentPerOccurrence
+	}
+
+	if !payment.Valid() {
+		return nil, status.Error(codes.InvalidArgument, "invalid payment, expected per_occurrence or upfront")
+	}
+
+	box, err := b.originalServer.book.Box(ctx, req.GetBoxName())
+	if err != nil {
+		if errors.Is(err, book.ErrBoxNotFound) {
+			return nil, status.Error(codes.NotFound, "boxName not found")
+		}
+		return nil, status.Error(codes.Internal, "internal error occured")
+	}
+
+	bookReq := &bookingv1.BookRequest{
+		Email:        req.GetEmail(),
+		BoxName:      req.GetBoxName(),
+		PeopleAmount: req.GetPeopleAmount(),
+		TimeStart:    req.GetTimeStart(),
+		TimeHrs:      req.GetTimeHrs(),
+		TimeMins:     req.GetTimeMins(),
+	}
+
+	startsAt, err := validate(bookReq, box)
+	if err != nil {
+		return nil, err
+	}
+
+	duration := time.Duration(req.GetTimeHrs())*time.Hour + time.Duration(req.GetTimeMins())*time.Minute
+
+	series, occurrences, balance, err := b.originalServer.book.BookSeries(ctx, req.GetEmail(), req.GetBoxName(), startsAt, duration, req.GetPeopleAmount(), req.GetRrule(), payment)
+	if err != nil {
+		for _, ruleErr := range []error{rrule.ErrInvalidRule, rrule.ErrNoEnd, rrule.ErrTooManyEvents} {
+			if errors.Is(err, ruleErr) {
+				return nil, status.Error(codes.InvalidArgument, ruleErr.Error())
+			}
+		}
+		return nil, status.Error(codes.Internal, "failed to book a series")
+	}
+
+	resp := &bookingv1.BookSeriesResponse{
+		SeriesUid:   series.UID,
+		Rrule:       series.Rule,
+		Occurrences: make([]*bookingv1.Occurrence, 0, len(occurrences)),
+		Balance:     balance,
+	}
+
+	for _, occurrence := range occurrences {
+		pb := &bookingv1.Occurrence{
+			StartsAt:   occurrence.StartsAt.Format(time.RFC3339),
+			ExpiresAt:  occurrence.ExpiresAt.Format(time.RFC3339),
+			Status:     string(occurrence.Status),
+			BookingUid: occurrence.BookingUID,
+			Error:      occurrence.Error,
+		}
+
+		if len(occurrence.Price.Lines) > 0 {
+			pb.Price = toProtoPrice(occurrence.Price)
+		}
+
+		resp.Occurrences = append(resp.Occurrences, pb)
+	}
+
+	return resp, nil
+}
+
+func (b *bookingServerAdapter) CancelSeries(ctx context.Context, req *bookingv1.CancelSeriesRequest) (*bookingv1.CancelSeriesResponse, error) {
+	if req.GetSeriesUid() == "" {
+		return nil, status.Error(codes.InvalidArgument, "series ID is required")
+	}
+
+	initiator := models.CancelByUser
+	if req.GetByOperator() {
+		initiator = models.CancelByOperator
+	}
+
+	if req.GetEmail() == "" && initiator == models.CancelByUser {
+		return nil, status.Error(codes.InvalidArgument, "email is required")
+	}
+
+	cancellations, balance, err := b.originalServer.book.CancelSeries(ctx, req.GetEmail(), req.GetSeriesUid(), initiator)
+	if err != nil {
+		if errors.Is(err, book.ErrSeriesNotFound) {
+			return nil, status.Error(codes.NotFound, "series not found")
+		}
+		if errors.Is(err, book.ErrNotYourBooking) {
+			return nil, status.Error(codes.PermissionDenied, "this booking belongs to another user")
+		}
+		if errors.Is(err, book.ErrBookingNotActive) {
+			return nil, status.Error(codes.FailedPrecondition, "booking is not active")
+		}
+		return nil, status.Error(codes.Internal, "failed to cancel series")
+	}
+
+	resp := &bookingv1.CancelSeriesResponse{
+		Cancellations: make([]*bookingv1.SeriesCancellation, 0, len(cancellations)),
+		Balance:       balance,
+	}
+
+	for _, cancellation := range cancellations {
+		pb := &bookingv1.SeriesCancellation{
+			BookingUid: cancellation.BookingUID,
+			StartsAt:   cancellation.StartsAt.Format(time.RFC3339),
+			Error:      cancellation.Error,
+		}
+
+		if cancellation.Error == "" {
+			pb.RefundedAmount = cancellation.Refund.Amount
+			pb.RefundPolicy = toProtoRefundPolicy(cancellation.Refund)
+			resp.RefundedAmount += cancellation.Refund.Amount
+		}
+
+		resp.Cancellations = append(resp.Cancellations, pb)
+	}
+
+	return resp, nil
+}
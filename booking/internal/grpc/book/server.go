@@ -1,11 +1,13 @@
 package bookgrpc
 
 import (
-	"booking/internal/clients/payments"
-	"booking/internal/domain/boxes"
+	"booking/internal/domain/models"
+	"booking/internal/lib/booktime"
+	"booking/internal/lib/geo"
+	"booking/internal/services/book"
 	"context"
 	"errors"
-	"fmt"
+	"strconv"
 	"time"
 
 	bookingv1 "github.com/MKode312/protos/gen/go/booking"
@@ -15,21 +17,54 @@ import (
 )
 
 var (
-	ErrInvalidCredentials  = errors.New("invalid credentials")
-	ErrNotEnoughFundsToPay = errors.New("not enough funds to pay")
-	ErrCardNotFound        = errors.New("card not found")
-	ErrAlreadyBooked       = errors.New("this box is already booked")
-	ErrBookingNotFound     = errors.New("booking not found")
-	ErrNotYourBooking      = errors.New("this booking belongs to another user")
+	ErrBookingInPast    = errors.New("booking start time is in the past")
+	ErrCapacityExceeded = errors.New("the amount of people exceeds the box capacity")
+	ErrBookingTooLong   = errors.New("booking can't be longer than 24 hours")
 )
 
 const (
-	emptyBalanceValue = -1
+	dateLayout         = "2006-01-02"
+	defaultSlotMinutes = 60
+	minSlotMinutes     = 15
+	maxSlotMinutes     = 12 * 60
+	maxBookingDuration = 24 * time.Hour
 )
 
 type Book interface {
-	Book(ctx context.Context, email string, boxName string, timeStart string, timeHrs int64, timeMins int64) (reserveID int64, success bool, err error)
-	CancelBooking(ctx context.Context, email string, bookingID int64) (refundedAmount int64, success bool, err error)
+	Book(ctx context.Context, email string, boxName string, startsAt time.Time, duration time.Duration, peopleAmount int64, promoCode string, idempotencyKey string) (booking models.Booking, price models.Price, balance int64, err error)
+	HoldSlot(ctx context.Context, email string, boxName string, startsAt time.Time, duration time.Duration, peopleAmount int64) (models.Hold, models.Price, error)
+	BookHold(ctx context.Context, email string, holdID string, promoCode string, idempotencyKey string) (booking models.Booking, price models.Price, balance int64, err error)
+	CancelBooking(ctx context.Context, email string, bookingID string, initiator models.CancelInitiator, idempotencyKey string) (refund models.Refund, balance int64, err error)
+	Bookings(ctx context.Context, email string, filter models.BookingFilter, cursor string) (bookings []models.Booking, nextCursor string, err error)
+	InviteParticipant(ctx context.Context, email string, bookingID string, participantEmail string) (models.Participant, error)
+	RespondToInvitation(ctx context.Context, email string, bookingID string, accept bool) (models.Participant, error)
+	Participants(ctx context.Context, email string, bookingID string) ([]models.Participant, error)
+	Invitations(ctx context.Context, email string) ([]models.Invitation, error)
+	Box(ctx context.Context, name string) (models.Box, error)
+	Boxes(ctx context.Context, includeInactive bool) ([]models.Box, error)
+	Availability(ctx context.Context, boxName string, date time.Time, slot time.Duration) (models.Availability, error)
+	NearbyVenues(ctx context.Context, center geo.Point, radiusKm float64, limit int, slot time.Duration) ([]models.NearbyVenue, error)
+	Quote(ctx context.Context, email string, boxName string, startsAt time.Time, duration time.Duration, peopleAmount int64, promoCode string) (models.Quote, error)
+	BookSeries(ctx context.Context, email string, boxName string, startsAt time.Time, duration time.Duration, peopleAmount int64, rule string, payment models.SeriesPayment) (series models.Series, occurrences []models.Occurrence, balance int64, err error)
+	CancelSeries(ctx context.Context, email string, seriesUID string, initiator models.CancelInitiator) (cancellations []models.SeriesCancellation, balance int64, err error)
+	JoinWaitlist(ctx context.Context, email string, boxName string, startsAt time.Time, duration time.Duration, peopleAmount int64, mode models.WaitlistMode) (models.WaitlistEntry, error)
+	Waitlist(ctx context.Context, email string) ([]models.WaitlistEntry, error)
+	AcceptOffer(ctx context.Context, email string, entryUID string) (booking models.Booking, price models.Price, balance int64, err error)
+	LeaveWaitlist(ctx context.Context, email string, entryUID string) error
+	Booking(ctx context.Context, bookingID string) (models.Booking, error)
+	RescheduleBooking(ctx context.Context, email string, bookingID string, boxName string, startsAt time.Time, duration time.Duration) (booking models.Booking, price models.Price, balance int64, err error)
+	Schedule(ctx context.Context, boxName string, from time.Time, to time.Time) (models.Schedule, error)
+	SetOpeningHours(ctx context.Context, boxName string, hours []models.WeeklyHours) error
+	AddClosure(ctx context.Context, closure models.Closure) (models.Closure, error)
+	RemoveClosure(ctx context.Context, closureUID string) error
+	AddBlackout(ctx context.Context, blackout models.Blackout) (models.Blackout, error)
+	RemoveBlackout(ctx context.Context, blackoutUID string) error
+	Credentials(booking models.Booking) models.AccessCredentials
+	VerifyAccess(ctx context.Context, boxName string, code string, door string) (models.CheckIn, models.Booking, error)
+	CalendarFeed(ctx context.Context, email string, rotate bool) (models.CalendarFeed, error)
+	UserCalendar(ctx context.Context, token string) ([]byte, error)
+	BoxCalendar(ctx context.Context, boxName string) ([]byte, error)
+	SetNotificationLocale(ctx context.Context, email string, locale models.Locale) error
 }
 
 type serverAPI struct {
@@ -40,159 +75,455 @@ type serverAPI struct {
 type bookingServerAdapter struct {
 	bookingv1.UnimplementedBookServer
 	originalServer *serverAPI
-	paymentsClient payments.Client
 }
 
-func Register(gRPC *grpc.Server, book Book, paymclient payments.Client) {
+func Register(gRPC *grpc.Server, book Book) {
 	realSrv := &serverAPI{book: book}
 	wrapped := &bookingServerAdapter{
-
 		originalServer: realSrv,
-		paymentsClient: paymclient,
 	}
 	bookingv1.RegisterBookServer(gRPC, wrapped)
 }
 
 func (b *bookingServerAdapter) CancelBooking(ctx context.Context, req *bookingv1.CancelBookingRequest) (*bookingv1.CancelBookingResponse, error) {
-	if req.GetBookingId() <= 0 {
+	bookingID := req.GetBookingUid()
+	if bookingID == "" && req.GetBookingId() > 0 {
+		bookingID = strconv.FormatInt(req.GetBookingId(), 10)
+	}
+
+	if bookingID == "" {
 		return nil, status.Error(codes.InvalidArgument, "booking ID is required")
 	}
 
-	if req.GetEmail() == "" {
+	initiator := models.CancelByUser
+	if req.GetByOperator() {
+		initiator = models.CancelByOperator
+	}
+
+	if req.GetEmail() == "" && initiator == models.CancelByUser {
 		return nil, status.Error(codes.InvalidArgument, "email is required")
 	}
 
-	refundedAmount, _, err := b.originalServer.book.CancelBooking(ctx, req.GetEmail(), req.GetBookingId())
+	refund, balance, err := b.originalServer.book.CancelBooking(ctx, req.GetEmail(), bookingID, initiator, req.GetIdempotencyKey())
 	if err != nil {
-		if errors.Is(err, ErrBookingNotFound) {
+		if err := idempotencyError(err); err != nil {
+			return nil, err
+		}
+		if errors.Is(err, book.ErrBookingNotFound) {
 			return nil, status.Error(codes.NotFound, "booking not found")
 		}
-		if err.Error() == ErrNotYourBooking.Error() {
+		if errors.Is(err, book.ErrNotYourBooking) {
 			return nil, status.Error(codes.PermissionDenied, "this booking belongs to another user")
 		}
+		if errors.Is(err, book.ErrBookingNotActive) {
+			return nil, status.Error(codes.FailedPrecondition, "booking is not active")
+		}
+		if errors.Is(err, book.ErrRefundFailed) {
+			return nil, status.Error(codes.Unavailable, "failed to process refund")
+		}
+		if errors.Is(err, book.ErrRefundPending) {
+			return nil, status.Error(codes.Unavailable, "refund is being processed")
+		}
 		return nil, status.Error(codes.Internal, "failed to cancel booking")
 	}
 
-	// Process refund
-	balance, success, err := b.paymentsClient.AddFunds(ctx, req.GetEmail(), refundedAmount)
-	if err != nil {
-		return nil, status.Error(codes.Internal, "failed to process refund")
-	}
-
 	return &bookingv1.CancelBookingResponse{
-		Success:        success,
-		RefundedAmount: refundedAmount,
+		Success:        true,
+		RefundedAmount: refund.Amount,
 		Balance:        balance,
+		RefundPolicy:   toProtoRefundPolicy(refund),
 	}, nil
 }
 
+// idempotencyError maps the errors of a repeated idempotency key to statuses,
+// it returns nil for other errors. A request in progress is aborted, so the
+// client retries it.
+func idempotencyError(err error) error {
+	switch {
+	case errors.Is(err, book.ErrIdempotencyKeyReused):
+		return status.Error(codes.FailedPrecondition, book.ErrIdempotencyKeyReused.Error())
+	case errors.Is(err, book.ErrRequestInProgress):
+		return status.Error(codes.Aborted, book.ErrRequestInProgress.Error())
+	}
+
+	return nil
+}
+
+func toProtoRefundPolicy(refund models.Refund) *bookingv1.RefundPolicy {
+	return &bookingv1.RefundPolicy{
+		Name:            string(refund.Policy),
+		Percent:         refund.Percent,
+		FullRefundUntil: refund.FullRefundUntil.Format(time.RFC3339),
+	}
+}
+
 func (b *bookingServerAdapter) Book(ctx context.Context, req *bookingv1.BookRequest) (*bookingv1.BookResponse, error) {
-	if err := validate(req); err != nil {
+	if req.GetHoldId() != "" {
+		return b.bookHold(ctx, req)
+	}
+
+	if req.GetBoxName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "boxName is required")
+	}
+
+	box, err := b.originalServer.book.Box(ctx, req.GetBoxName())
+	if err != nil {
+		if errors.Is(err, book.ErrBoxNotFound) {
+			return nil, status.Error(codes.NotFound, "boxName not found")
+		}
+		return nil, status.Error(codes.Internal, "internal error occured")
+	}
+
+	startsAt, err := validate(req, box)
+	if err != nil {
 		return nil, err
 	}
 
-	balance, paysuccess, err := compilePayment(ctx, req, b.paymentsClient)
+	duration := time.Duration(req.GetTimeHrs())*time.Hour + time.Duration(req.GetTimeMins())*time.Minute
+
+	booking, price, balance, err := b.originalServer.book.Book(ctx, req.GetEmail(), req.GetBoxName(), startsAt, duration, req.GetPeopleAmount(), req.GetPromoCode(), req.GetIdempotencyKey())
 	if err != nil {
-		if err.Error() == ErrInvalidCredentials.Error() {
-			return nil, status.Error(codes.InvalidArgument, "invalid email")
+		if err := idempotencyError(err); err != nil {
+			return nil, err
+		}
+		if err := promoError(err); err != nil {
+			return nil, err
+		}
+		if err := scheduleError(err); err != nil {
+			return nil, err
 		}
+		if errors.Is(err, book.ErrAlreadyBooked) {
+			return nil, status.Error(codes.AlreadyExists, "this box is already booked")
+		}
+		if errors.Is(err, book.ErrNotEnoughFunds) {
+			return nil, status.Error(codes.OutOfRange, "not enough funds to pay")
+		}
+		if errors.Is(err, book.ErrCardNotFound) {
+			return nil, status.Error(codes.NotFound, "card not found")
+		}
+		if errors.Is(err, book.ErrPaymentFailed) {
+			return nil, status.Error(codes.Canceled, "failed to pay for the booking")
+		}
+		if errors.Is(err, book.ErrPaymentPending) {
+			return nil, status.Error(codes.Unavailable, "payment is being processed")
+		}
+		return nil, status.Error(codes.Internal, "failed to book a box")
+	}
 
-		if err.Error() == ErrNotEnoughFundsToPay.Error() {
-			return nil, status.Error(codes.OutOfRange, "not enough funds to pay for the booking")
+	return &bookingv1.BookResponse{
+		ReserveId:  booking.ID,
+		BookingUid: booking.UID,
+		Balance:    balance,
+		Success:    true,
+		Price:      toProtoPrice(price),
+		Access:     b.bookingAccess(ctx, booking.UID),
+	}, nil
+}
+
+func (b *bookingServerAdapter) QuotePrice(ctx context.Context, req *bookingv1.QuotePriceRequest) (*bookingv1.QuotePriceResponse, error) {
+	if req.GetBoxName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "boxName is required")
+	}
+
+	box, err := b.originalServer.book.Box(ctx, req.GetBoxName())
+	if err != nil {
+		if errors.Is(err, book.ErrBoxNotFound) {
+			return nil, status.Error(codes.NotFound, "boxName not found")
 		}
+		return nil, status.Error(codes.Internal, "internal error occured")
+	}
 
-		if err.Error() == ErrCardNotFound.Error() {
-			return nil, status.Error(codes.NotFound, "card not found")
+	bookReq := &bookingv1.BookRequest{
+		Email:        req.GetEmail(),
+		BoxName:      req.GetBoxName(),
+		PeopleAmount: req.GetPeopleAmount(),
+		TimeStart:    req.GetTimeStart(),
+		TimeHrs:      req.GetTimeHrs(),
+		TimeMins:     req.GetTimeMins(),
+	}
+
+	startsAt, err := validate(bookReq, box)
+	if err != nil {
+		return nil, err
+	}
+
+	duration := time.Duration(req.GetTimeHrs())*time.Hour + time.Duration(req.GetTimeMins())*time.Minute
+
+	quote, err := b.originalServer.book.Quote(ctx, req.GetEmail(), req.GetBoxName(), startsAt, duration, req.GetPeopleAmount(), req.GetPromoCode())
+	if err != nil {
+		if err := promoError(err); err != nil {
+			return nil, err
 		}
+		return nil, status.Error(codes.Internal, "failed to quote the price")
+	}
+
+	return &bookingv1.QuotePriceResponse{
+		Price:       toProtoPrice(quote.Price),
+		Balance:     quote.Balance,
+		EnoughFunds: quote.EnoughFunds(),
+		SlotFree:    quote.SlotFree,
+	}, nil
+}
+
+func (b *bookingServerAdapter) GetBookings(ctx context.Context, req *bookingv1.GetBookingsRequest) (*bookingv1.GetBookingsResponse, error) {
+	if req.GetEmail() == "" {
+		return nil, status.Error(codes.InvalidArgument, "email is required")
+	}
+
+	filter := models.BookingFilter{
+		Status: models.BookingStatus(req.GetStatus()),
+		Limit:  int(req.GetLimit()),
+	}
 
-		st, _ := status.FromError(err)
+	if filter.Status != "" && !filter.Status.Valid() {
+		return nil, status.Error(codes.InvalidArgument, "invalid status")
+	}
 
-		return nil, status.Error(st.Code(), "failed to pay for the booking")
+	if req.GetFrom() != "" {
+		from, err := time.Parse(time.RFC3339, req.GetFrom())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "from must be an RFC 3339 timestamp")
+		}
+		filter.From = from
 	}
 
-	if paysuccess {
-		reserveID, success, err := b.originalServer.book.Book(ctx, req.GetEmail(), req.GetBoxName(), req.GetTimeStart(), req.GetTimeHrs(), req.GetTimeMins())
+	if req.GetTo() != "" {
+		to, err := time.Parse(time.RFC3339, req.GetTo())
 		if err != nil {
-			if err.Error() == ErrAlreadyBooked.Error() {
-				return nil, status.Error(codes.AlreadyExists, "this box is already booked for this time")
-			}
+			return nil, status.Error(codes.InvalidArgument, "to must be an RFC 3339 timestamp")
+		}
+		filter.To = to
+	}
 
-			if err.Error() == ErrBookingNotFound.Error() {
-				return nil, status.Error(codes.NotFound, "booking not found")
-			}
-			return nil, status.Error(codes.Canceled, "booking cancelled")
+	bookings, next, err := b.originalServer.book.Bookings(ctx, req.GetEmail(), filter, req.GetCursor())
+	if err != nil {
+		if errors.Is(err, book.ErrInvalidCursor) {
+			return nil, status.Error(codes.InvalidArgument, "invalid cursor")
 		}
+		return nil, status.Error(codes.Internal, "failed to get bookings")
+	}
+
+	resp := &bookingv1.GetBookingsResponse{
+		Bookings:   make([]*bookingv1.Booking, 0, len(bookings)),
+		NextCursor: next,
+	}
+
+	for _, bk := range bookings {
+		pb := toProtoBooking(bk)
+		pb.Access = b.toProtoAccess(bk)
+
+		resp.Bookings = append(resp.Bookings, pb)
+	}
+
+	return resp, nil
+}
+
+func toProtoBooking(bk models.Booking) *bookingv1.Booking {
+	pb := &bookingv1.Booking{
+		Id:           bk.ID,
+		Uid:          bk.UID,
+		Email:        bk.Email,
+		BoxName:      bk.BoxName,
+		StartsAt:     bk.StartsAt.Format(time.RFC3339),
+		ExpiresAt:    bk.ExpiresAt.Format(time.RFC3339),
+		PeopleAmount: bk.PeopleAmount,
+		PricePaid:    bk.PricePaid,
+		Status:       string(bk.Status),
+		SeriesUid:    bk.SeriesUID,
+	}
 
-		return &bookingv1.BookResponse{
-			ReserveId: reserveID,
-			Balance:   balance,
-			Success:   success,
-		}, nil
-	} else {
-		return nil, status.Error(codes.Canceled, "payment failed")
+	if !bk.CancelledAt.IsZero() {
+		pb.CancelledAt = bk.CancelledAt.Format(time.RFC3339)
 	}
+
+	if !bk.CheckedInAt.IsZero() {
+		pb.CheckedInAt = bk.CheckedInAt.Format(time.RFC3339)
+	}
+
+	return pb
 }
 
-func compilePayment(ctx context.Context, req *bookingv1.BookRequest, paymentsClient payments.Client) (int64, bool, error) {
-	const op = "book.CompilePayment"
+func (b *bookingServerAdapter) GetBoxes(ctx context.Context, req *bookingv1.GetBoxesRequest) (*bookingv1.GetBoxesResponse, error) {
+	boxes, err := b.originalServer.book.Boxes(ctx, req.GetIncludeInactive())
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get boxes")
+	}
 
-	amount := (req.GetTimeHrs()*int64(boxes.HrsAmount) + req.GetTimeMins()*int64(boxes.MinAmount)) * req.GetPeopleAmount()
+	resp := &bookingv1.GetBoxesResponse{
+		Boxes: make([]*bookingv1.Box, 0, len(boxes)),
+	}
+
+	for _, box := range boxes {
+		resp.Boxes = append(resp.Boxes, toProtoBox(box))
+	}
 
-	balance, paysuccess, err := paymentsClient.Pay(ctx, req.GetEmail(), amount)
+	return resp, nil
+}
+
+func (b *bookingServerAdapter) GetBox(ctx context.Context, req *bookingv1.GetBoxRequest) (*bookingv1.GetBoxResponse, error) {
+	if req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+
+	box, err := b.originalServer.book.Box(ctx, req.GetName())
 	if err != nil {
-		if err.Error() == ErrNotEnoughFundsToPay.Error() {
-			return emptyBalanceValue, false, fmt.Errorf("%s: %w", op, ErrNotEnoughFundsToPay)
+		if errors.Is(err, book.ErrBoxNotFound) {
+			return nil, status.Error(codes.NotFound, "box not found")
 		}
+		return nil, status.Error(codes.Internal, "failed to get box")
+	}
 
-		if err.Error() == ErrInvalidCredentials.Error() {
-			return emptyBalanceValue, false, fmt.Errorf("%s: %w", op, ErrInvalidCredentials)
-		}
+	return &bookingv1.GetBoxResponse{
+		Box: toProtoBox(box),
+	}, nil
+}
 
-		if err.Error() == ErrCardNotFound.Error() {
-			return emptyBalanceValue, false, fmt.Errorf("%s: %w", op, ErrCardNotFound)
+func (b *bookingServerAdapter) GetAvailability(ctx context.Context, req *bookingv1.GetAvailabilityRequest) (*bookingv1.GetAvailabilityResponse, error) {
+	if req.GetBoxName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "boxName is required")
+	}
+
+	slotMinutes := req.GetSlotMinutes()
+	if slotMinutes == 0 {
+		slotMinutes = defaultSlotMinutes
+	}
+	if slotMinutes < minSlotMinutes || slotMinutes > maxSlotMinutes {
+		return nil, status.Error(codes.InvalidArgument, "invalid slot length")
+	}
+
+	box, err := b.originalServer.book.Box(ctx, req.GetBoxName())
+	if err != nil {
+		if errors.Is(err, book.ErrBoxNotFound) {
+			return nil, status.Error(codes.NotFound, "box not found")
 		}
+		return nil, status.Error(codes.Internal, "failed to get availability")
+	}
 
-		return emptyBalanceValue, false, fmt.Errorf("%s: %w", op, err)
+	loc, err := box.Location()
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get availability")
 	}
 
-	return balance, paysuccess, nil
+	date, err := time.ParseInLocation(dateLayout, req.GetDate(), loc)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "date must be in YYYY-MM-DD format")
+	}
+
+	availability, err := b.originalServer.book.Availability(ctx, box.Name, date, time.Duration(slotMinutes)*time.Minute)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get availability")
+	}
 
+	return &bookingv1.GetAvailabilityResponse{
+		BoxName:  box.Name,
+		Date:     date.Format(dateLayout),
+		TimeZone: box.TimeZone,
+		OpensAt:  availability.OpensAt.Format(time.RFC3339),
+		ClosesAt: availability.ClosesAt.Format(time.RFC3339),
+		Free:     toProtoIntervals(availability.Free),
+		Busy:     toProtoIntervals(availability.Busy),
+		Slots:    toProtoIntervals(availability.Slots),
+	}, nil
 }
 
-func validate(req *bookingv1.BookRequest) error {
-	if req.GetBoxName() == "" {
-		return status.Error(codes.InvalidArgument, "boxName is required")
+func toProtoIntervals(intervals []models.Interval) []*bookingv1.TimeInterval {
+	result := make([]*bookingv1.TimeInterval, 0, len(intervals))
+
+	for _, in := range intervals {
+		result = append(result, &bookingv1.TimeInterval{
+			StartsAt:  in.StartsAt.Format(time.RFC3339),
+			ExpiresAt: in.ExpiresAt.Format(time.RFC3339),
+		})
 	}
 
-	if _, ok := boxes.Boxes[req.GetBoxName()]; !ok {
-		return status.Error(codes.NotFound, "boxName not found")
+	return result
+}
+
+func toProtoBox(box models.Box) *bookingv1.Box {
+	return &bookingv1.Box{
+		Id:             box.ID,
+		Name:           box.Name,
+		Address:        box.Address,
+		Description:    box.Description,
+		Capacity:       box.Capacity,
+		PricePerHour:   box.PricePerHour,
+		TimeZone:       box.TimeZone,
+		Active:         box.Active,
+		OpensAt:        box.OpensAt,
+		ClosesAt:       box.ClosesAt,
+		SharedSessions: box.SharedSessions,
+		VenueId:        box.VenueID,
+	}
+}
+
+func toProtoPrice(price models.Price) *bookingv1.Price {
+	pb := &bookingv1.Price{
+		PricePerHour: price.PricePerHour,
+		PeopleAmount: price.PeopleAmount,
+		Lines:        make([]*bookingv1.PriceLine, 0, len(price.Lines)),
+		Subtotal:     price.Subtotal,
+		SurgePercent: price.SurgePercent,
+		MinCharge:    price.MinCharge,
+		PromoCode:    price.PromoCode,
+		Discount:     price.Discount,
+		Total:        price.Total,
+	}
+
+	for _, line := range price.Lines {
+		pb.Lines = append(pb.Lines, &bookingv1.PriceLine{
+			Name:      line.Name,
+			StartsAt:  line.StartsAt.Format(time.RFC3339),
+			ExpiresAt: line.ExpiresAt.Format(time.RFC3339),
+			Percent:   line.Percent,
+			Amount:    line.Amount,
+		})
+	}
+
+	return pb
+}
+
+func validate(req *bookingv1.BookRequest, box models.Box) (time.Time, error) {
+	if !box.Active {
+		return time.Time{}, status.Error(codes.FailedPrecondition, "box is not available for booking")
 	}
 
 	if req.GetEmail() == "" {
-		return status.Error(codes.InvalidArgument, "email is required")
+		return time.Time{}, status.Error(codes.InvalidArgument, "email is required")
 	}
 
 	if req.GetPeopleAmount() <= 0 {
-		return status.Error(codes.InvalidArgument, "invalid amount of people")
+		return time.Time{}, status.Error(codes.InvalidArgument, "invalid amount of people")
+	}
+
+	if req.GetPeopleAmount() > box.Capacity {
+		return time.Time{}, status.Error(codes.InvalidArgument, ErrCapacityExceeded.Error())
 	}
 
-	if req.GetPeopleAmount() > 1 {
-		return status.Error(codes.InvalidArgument, "the amount of people is greater than 1")
+	if req.GetTimeHrs() < 0 || req.GetTimeMins() < 0 || (req.GetTimeHrs() == 0 && req.GetTimeMins() == 0) {
+		return time.Time{}, status.Error(codes.InvalidArgument, "invalid time")
 	}
 
-	timeStart, err := time.Parse("15:04", req.GetTimeStart())
+	// The hours and the minutes are checked on their own first so that the
+	// duration can't overflow.
+	hrs, mins := req.GetTimeHrs(), req.GetTimeMins()
+	if hrs > int64(maxBookingDuration/time.Hour) || mins > int64(maxBookingDuration/time.Minute) ||
+		time.Duration(hrs)*time.Hour+time.Duration(mins)*time.Minute > maxBookingDuration {
+		return time.Time{}, status.Error(codes.InvalidArgument, ErrBookingTooLong.Error())
+	}
+
+	loc, err := box.Location()
 	if err != nil {
-		fmt.Println(err)
-		return status.Error(codes.Internal, "internal error occured")
+		return time.Time{}, status.Error(codes.Internal, "internal error occured")
 	}
 
-	if timeStart.IsZero() {
-		return status.Error(codes.InvalidArgument, "invalid time")
+	startsAt, err := booktime.Parse(req.GetTimeStart(), loc)
+	if err != nil {
+		return time.Time{}, status.Error(codes.InvalidArgument, booktime.ErrInvalidFormat.Error())
 	}
 
-	if req.GetTimeHrs() <= 0 && req.GetTimeMins() <= 0 {
-		return status.Error(codes.InvalidArgument, "invalid time")
+	if !startsAt.After(time.Now()) {
+		return time.Time{}, status.Error(codes.InvalidArgument, ErrBookingInPast.Error())
 	}
 
-	return nil
+	return startsAt, nil
 }
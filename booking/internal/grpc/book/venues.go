@@ -0,0 +1,101 @@
+package bookgrpc
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/lib/geo"
+	"context"
+	"time"
+
+	bookingv1 "github.com/MKode312/protos/gen/go/booking"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultVenueRadiusKm = 10
+	maxVenueRadiusKm     = 100
+	defaultVenuesLimit   = 20
+	maxVenuesLimit       = 100
+)
+
+func (b *bookingServerAdapter) GetNearbyVenues(ctx context.Context, req *bookingv1.GetNearbyVenuesRequest) (*bookingv1.GetNearbyVenuesResponse, error) {
+	center := geo.Point{Latitude: req.GetLatitude(), Longitude: req.GetLongitude()}
+	if !center.Valid() {
+		return nil, status.Error(codes.InvalidArgument, "invalid coordinates")
+	}
+
+	radius := req.GetRadiusKm()
+	if radius == 0 {
+		radius = defaultVenueRadiusKm
+	}
+	if radius < 0 || radius > maxVenueRadiusKm {
+		return nil, status.Error(codes.InvalidArgument, "invalid radius")
+	}
+
+	limit := req.GetLimit()
+	if limit <= 0 {
+		limit = defaultVenuesLimit
+	}
+	if limit > maxVenuesLimit {
+		limit = maxVenuesLimit
+	}
+
+	slotMinutes := req.GetSlotMinutes()
+	if slotMinutes == 0 {
+		slotMinutes = defaultSlotMinutes
+	}
+	if slotMinutes < minSlotMinutes || slotMinutes > maxSlotMinutes {
+		return nil, status.Error(codes.InvalidArgument, "invalid slot length")
+	}
+
+	venues, err := b.originalServer.book.NearbyVenues(ctx, center, radius, int(limit), time.Duration(slotMinutes)*time.Minute)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get venues")
+	}
+
+	resp := &bookingv1.GetNearbyVenuesResponse{
+		Venues: make([]*bookingv1.NearbyVenue, 0, len(venues)),
+	}
+
+	for _, venue := range venues {
+		resp.Venues = append(resp.Venues, toProtoNearbyVenue(venue))
+	}
+
+	return resp, nil
+}
+
+func toProtoNearbyVenue(nearby models.NearbyVenue) *bookingv1.NearbyVenue {
+	venue := &bookingv1.NearbyVenue{
+		Venue: &bookingv1.Venue{
+			Id:        nearby.Venue.ID,
+			Name:      nearby.Venue.Name,
+			Address:   nearby.Venue.Address,
+			Latitude:  nearby.Venue.Latitude,
+			Longitude: nearby.Venue.Longitude,
+			TimeZone:  nearby.Venue.TimeZone,
+			Phone:     nearby.Venue.Phone,
+			Email:     nearby.Venue.Email,
+			Website:   nearby.Venue.Website,
+		},
+		DistanceKm: nearby.DistanceKm,
+		Boxes:      make([]*bookingv1.BoxDaySummary, 0, len(nearby.Boxes)),
+	}
+
+	for _, summary := range nearby.Boxes {
+		box := &bookingv1.BoxDaySummary{
+			Box:         toProtoBox(summary.Box),
+			Date:        summary.Date.Format(dateLayout),
+			OpensAt:     summary.OpensAt.Format(time.RFC3339),
+			ClosesAt:    summary.ClosesAt.Format(time.RFC3339),
+			FreeMinutes: summary.FreeMinutes,
+			FreeSlots:   summary.FreeSlots,
+		}
+		if !summary.NextFree.IsZero() {
+			box.NextFreeAt = summary.NextFree.Format(time.RFC3339)
+		}
+
+		venue.Boxes = append(venue.Boxes, box)
+	}
+
+	return venue
+}
@@ -0,0 +1,186 @@
+package bookgrpc
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/services/book"
+	"context"
+	"errors"
+	"time"
+
+	bookingv1 "github.com/MKode312/protos/gen/go/booking"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (b *bookingServerAdapter) JoinWaitlist(ctx context.Context, req *bookingv1.JoinWaitlistRequest) (*bookingv1.JoinWaitlistResponse, error) {
+	if req.GetBoxName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "boxName is required")
+	}
+
+	mode := models.WaitlistMode(req.GetMode())
+	if mode == "" {
+		mode = models.WaitlistModeOffer
+	}
+
+	if !mode.Valid() {
+		return nil, status.Error(codes.InvalidArgument, "invalid mode, expected offer or auto_book")
+	}
+
+	box, err := b.originalServer.book.Box(ctx, req.GetBoxName())
+	if err != nil {
+		if errors.Is(err, book.ErrBoxNotFound) {
+			return nil, status.Error(codes.NotFound, "boxName not found")
+		}
+		return nil, status.Error(codes.Internal, "internal error occured")
+	}
+
+	bookReq := &bookingv1.BookRequest{
+		Email:        req.GetEmail(),
+		BoxName:      req.GetBoxName(),
+		PeopleAmount: req.GetPeopleAmount(),
+		TimeStart:    req.GetTimeStart(),
+		TimeHrs:      req.GetTimeHrs(),
+		TimeMins:     req.GetTimeMins(),
+	}
+
+	startsAt, err := validate(bookReq, box)
+	if err != nil {
+		return nil, err
+	}
+
+	duration := time.Duration(req.GetTimeHrs())*time.Hour + time.Duration(req.GetTimeMins())*time.Minute
+
+	entry, err := b.originalServer.book.JoinWaitlist(ctx, req.GetEmail(), req.GetBoxName(), startsAt, duration, req.GetPeopleAmount(), mode)
+	if err != nil {
+		if err := scheduleError(err); err != nil {
+			return nil, err
+		}
+		if errors.Is(err, book.ErrSlotFree) {
+			return nil, status.Error(codes.FailedPrecondition, "the slot is free, book it instead")
+		}
+		return nil, status.Error(codes.Internal, "failed to join the waitlist")
+	}
+
+	return &bookingv1.JoinWaitlistResponse{
+		Entry: toProtoWaitlistEntry(entry),
+	}, nil
+}
+
+func (b *bookingServerAdapter) GetWaitlist(ctx context.Context, req *bookingv1.GetWaitlistRequest) (*bookingv1.GetWaitlistResponse, error) {
+	if req.GetEmail() == "" {
+		return nil, status.Error(codes.InvalidArgument, "email is required")
+	}
+
+	entries, err := b.originalServer.book.Waitlist(ctx, req.GetEmail())
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get the waitlist")
+	}
+
+	resp := &bookingv1.GetWaitlistResponse{
+		Entries: make([]*bookingv1.WaitlistEntry, 0, len(entries)),
+	}
+
+	for _, entry := range entries {
+		resp.Entries = append(resp.Entries, toProtoWaitlistEntry(entry))
+	}
+
+	return resp, nil
+}
+
+func (b *bookingServerAdapter) AcceptWaitlistOffer(ctx context.Context, req *bookingv1.AcceptWaitlistOfferRequest) (*bookingv1.BookResponse, error) {
+	if req.GetEntryUid() == "" {
+		return nil, status.Error(codes.InvalidArgument, "waitlist entry ID is required")
+	}
+
+	if req.GetEmail() == "" {
+		return nil, status.Error(codes.InvalidArgument, "email is required")
+	}
+
+	booking, price, balance, err := b.originalServer.book.AcceptOffer(ctx, req.GetEmail(), req.GetEntryUid())
+	if err != nil {
+		if err := waitlistError(err); err != nil {
+			return nil, err
+		}
+		if errors.Is(err, book.ErrNotEnoughFunds) {
+			return nil, status.Error(codes.OutOfRange, "not enough funds to pay")
+		}
+		if errors.Is(err, book.ErrCardNotFound) {
+			return nil, status.Error(codes.NotFound, "card not found")
+		}
+		if errors.Is(err, book.ErrPaymentFailed) {
+			return nil, status.Error(codes.Canceled, "failed to pay for the booking")
+		}
+		if errors.Is(err, book.ErrPaymentPending) {
+			return nil, status.Error(codes.Unavailable, "payment is being processed")
+		}
+		return nil, status.Error(codes.Internal, "failed to accept the offer")
+	}
+
+	return &bookingv1.BookResponse{
+		ReserveId:  booking.ID,
+		BookingUid: booking.UID,
+		Balance:    balance,
+		Success:    true,
+		Access:     b.bookingAccess(ctx, booking.UID),
+		Price:      toProtoPrice(price),
+	}, nil
+}
+
+func (b *bookingServerAdapter) LeaveWaitlist(ctx context.Context, req *bookingv1.LeaveWaitlistRequest) (*bookingv1.LeaveWaitlistResponse, error) {
+	if req.GetEntryUid() == "" {
+		return nil, status.Error(codes.InvalidArgument, "waitlist entry ID is required")
+	}
+
+	if req.GetEmail() == "" {
+		return nil, status.Error(codes.InvalidArgument, "email is required")
+	}
+
+	if err := b.originalServer.book.LeaveWaitlist(ctx, req.GetEmail(), req.GetEntryUid()); err != nil {
+		if err := waitlistError(err); err != nil {
+			return nil, err
+		}
+		return nil, status.Error(codes.Internal, "failed to leave the waitlist")
+	}
+
+	return &bookingv1.LeaveWaitlistResponse{
+		Success: true,
+	}, nil
+}
+
+// waitlistError maps the errors of a waitlist entry lookup, nil means err is
+// not one of them.
+func waitlistError(err error) error {
+	switch {
+	case errors.Is(err, book.ErrWaitlistEntryNotFound):
+		return status.Error(codes.NotFound, "waitlist entry not found")
+	case errors.Is(err, book.ErrNotYourWaitlistEntry):
+		return status.Error(codes.PermissionDenied, "this waitlist entry belongs to another user")
+	case errors.Is(err, book.ErrNoOffer):
+		return status.Error(codes.FailedPrecondition, "there is no offer for this waitlist entry")
+	case errors.Is(err, book.ErrOfferExpired):
+		return status.Error(codes.FailedPrecondition, "waitlist offer has expired")
+	}
+
+	return nil
+}
+
+func toProtoWaitlistEntry(entry models.WaitlistEntry) *bookingv1.WaitlistEntry {
+	pb := &bookingv1.WaitlistEntry{
+		Uid:          entry.UID,
+		BoxName:      entry.BoxName,
+		StartsAt:     entry.StartsAt.Format(time.RFC3339),
+		ExpiresAt:    entry.ExpiresAt.Format(time.RFC3339),
+		PeopleAmount: entry.PeopleAmount,
+		Mode:         string(entry.Mode),
+		Status:       string(entry.Status),
+		BookingUid:   entry.BookingUID,
+		Error:        entry.Error,
+		CreatedAt:    entry.CreatedAt.Format(time.RFC3339),
+	}
+
+	if !entry.OfferExpiresAt.IsZero() {
+		pb.OfferExpiresAt = entry.OfferExpiresAt.Format(time.RFC3339)
+	}
+
+	return pb
+}
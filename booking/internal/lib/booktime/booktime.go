@@ -0,0 +1,28 @@
+package booktime
+
+import (
+	"errors"
+	"time"
+)
+
+// LocalLayout is the wall-clock format used when the client sends a date and
+// time without an offset. Such values are resolved in the box's own time zone.
+const LocalLayout = "2006-01-02T15:04"
+
+var ErrInvalidFormat = errors.New("invalid time format, expected RFC 3339 or YYYY-MM-DDTHH:MM")
+
+// Parse parses a booking start time. RFC 3339 values keep their own offset,
+// local values ("2006-01-02T15:04" or "2006-01-02 15:04") are interpreted in loc.
+func Parse(value string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.In(loc), nil
+	}
+
+	for _, layout := range []string{LocalLayout, "2006-01-02 15:04"} {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, ErrInvalidFormat
+}
@@ -0,0 +1,84 @@
+package geo
+
+import "math"
+
+// earthRadiusKm is the mean radius of the Earth.
+const earthRadiusKm = 6371.0
+
+// Point is a position in degrees.
+type Point struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// Bounds is a latitude and longitude range in degrees. A range that crosses
+// the antimeridian has MinLongitude greater than MaxLongitude.
+type Bounds struct {
+	MinLatitude  float64
+	MaxLatitude  float64
+	MinLongitude float64
+	MaxLongitude float64
+}
+
+// Valid reports whether the point is a position on the Earth.
+func (p Point) Valid() bool {
+	return p.Latitude >= -90 && p.Latitude <= 90 && p.Longitude >= -180 && p.Longitude <= 180
+}
+
+// DistanceKm returns the great-circle distance between the points.
+func DistanceKm(a Point, b Point) float64 {
+	lat1, lat2 := radians(a.Latitude), radians(b.Latitude)
+	dLat := lat2 - lat1
+	dLon := radians(b.Longitude - a.Longitude)
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// Around returns the bounds of the points within radiusKm of the center. The
+// bounds hold a few points farther away, check them with DistanceKm.
+func Around(center Point, radiusKm float64) Bounds {
+	dLat := degrees(radiusKm / earthRadiusKm)
+
+	bounds := Bounds{
+		MinLatitude:  math.Max(center.Latitude-dLat, -90),
+		MaxLatitude:  math.Min(center.Latitude+dLat, 90),
+		MinLongitude: -180,
+		MaxLongitude: 180,
+	}
+
+	// Near a pole every longitude is in range.
+	if bounds.MinLatitude == -90 || bounds.MaxLatitude == 90 {
+		return bounds
+	}
+
+	dLon := degrees(math.Asin(math.Sin(radiusKm/earthRadiusKm) / math.Cos(radians(center.Latitude))))
+	if math.IsNaN(dLon) || dLon >= 180 {
+		return bounds
+	}
+
+	bounds.MinLongitude = wrapLongitude(center.Longitude - dLon)
+	bounds.MaxLongitude = wrapLongitude(center.Longitude + dLon)
+
+	return bounds
+}
+
+func wrapLongitude(lon float64) float64 {
+	switch {
+	case lon < -180:
+		return lon + 360
+	case lon > 180:
+		return lon - 360
+	}
+
+	return lon
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func degrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
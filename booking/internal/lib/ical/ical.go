@@ -0,0 +1,126 @@
+package ical
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// lineLimit is the longest content line in octets, longer lines are folded.
+	lineLimit = 75
+
+	utcLayout  = "20060102T150405Z"
+	dateLayout = "20060102"
+)
+
+type Status string
+
+const (
+	StatusConfirmed Status = "CONFIRMED"
+	StatusCancelled Status = "CANCELLED"
+)
+
+// Calendar is the subset of an RFC 5545 VCALENDAR used for the booking feeds.
+type Calendar struct {
+	// ProdID names the product that made the calendar.
+	ProdID string
+	Name   string
+	Events []Event
+}
+
+// Event is a VEVENT. UID has to stay the same for the life of the event, so
+// calendar apps update the event instead of adding a new one, Sequence grows
+// with every change. All-day events only use the date of Start and End.
+type Event struct {
+	UID         string
+	Sequence    int64
+	Stamp       time.Time
+	Start       time.Time
+	End         time.Time
+	AllDay      bool
+	Summary     string
+	Location    string
+	Description string
+	Status      Status
+}
+
+// Marshal renders the calendar with CRLF line breaks and folded lines.
+func (c Calendar) Marshal() []byte {
+	var buf bytes.Buffer
+
+	line(&buf, "BEGIN:VCALENDAR")
+	line(&buf, "VERSION:2.0")
+	line(&buf, "PRODID:"+c.ProdID)
+	line(&buf, "CALSCALE:GREGORIAN")
+	line(&buf, "METHOD:PUBLISH")
+	if c.Name != "" {
+		line(&buf, "X-WR-CALNAME:"+escape(c.Name))
+	}
+
+	for _, e := range c.Events {
+		line(&buf, "BEGIN:VEVENT")
+		line(&buf, "UID:"+e.UID)
+		line(&buf, "SEQUENCE:"+strconv.FormatInt(e.Sequence, 10))
+		line(&buf, "DTSTAMP:"+e.Stamp.UTC().Format(utcLayout))
+
+		if e.AllDay {
+			line(&buf, "DTSTART;VALUE=DATE:"+e.Start.Format(dateLayout))
+			line(&buf, "DTEND;VALUE=DATE:"+e.End.Format(dateLayout))
+		} else {
+			line(&buf, "DTSTART:"+e.Start.UTC().Format(utcLayout))
+			line(&buf, "DTEND:"+e.End.UTC().Format(utcLayout))
+		}
+
+		line(&buf, "SUMMARY:"+escape(e.Summary))
+		if e.Location != "" {
+			line(&buf, "LOCATION:"+escape(e.Location))
+		}
+		if e.Description != "" {
+			line(&buf, "DESCRIPTION:"+escape(e.Description))
+		}
+		if e.Status != "" {
+			line(&buf, "STATUS:"+string(e.Status))
+		}
+		line(&buf, "END:VEVENT")
+	}
+
+	line(&buf, "END:VCALENDAR")
+
+	return buf.Bytes()
+}
+
+var escaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// escape escapes a TEXT value.
+func escape(s string) string {
+	return escaper.Replace(s)
+}
+
+// line writes a content line folded after lineLimit octets, never inside a
+// UTF-8 sequence.
+func line(buf *bytes.Buffer, s string) {
+	limit := lineLimit
+
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(s[cut]) {
+			cut--
+		}
+
+		buf.WriteString(s[:cut])
+		buf.WriteString("\r\n ")
+		s = s[cut:]
+
+		// The leading space of a continuation line counts too.
+		limit = lineLimit - 1
+	}
+
+	buf.WriteString(s)
+	buf.WriteString("\r\n")
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
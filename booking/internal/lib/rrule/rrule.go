@@ -0,0 +1,217 @@
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Frequency string
+
+const (
+	Daily  Frequency = "DAILY"
+	Weekly Frequency = "WEEKLY"
+)
+
+var (
+	ErrInvalidRule   = errors.New("invalid recurrence rule, expected e.g. FREQ=WEEKLY;BYDAY=TU;COUNT=10")
+	ErrNoEnd         = errors.New("recurrence rule needs an UNTIL or COUNT")
+	ErrTooManyEvents = errors.New("recurrence rule gives too many occurrences")
+)
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// Rule is the subset of an RFC 5545 RRULE used for booking series: daily or
+// weekly repeats with an interval, the days of the week and an end.
+type Rule struct {
+	Freq     Frequency
+	Interval int
+	// ByDay is only used by weekly rules. Empty means the weekday of the start.
+	ByDay []time.Weekday
+	Count int
+	// Until is the last day of the series, inclusive.
+	Until time.Time
+}
+
+// Parse parses a rule like "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH;UNTIL=20261231".
+// UNTIL is a date (YYYYMMDD or YYYY-MM-DD) in loc. A leading "RRULE:" is allowed.
+func Parse(value string, loc *time.Location) (Rule, error) {
+	rule := Rule{Interval: 1}
+
+	value = strings.TrimPrefix(strings.TrimSpace(value), "RRULE:")
+	if value == "" {
+		return Rule{}, ErrInvalidRule
+	}
+
+	for _, part := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok {
+			return Rule{}, ErrInvalidRule
+		}
+
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			rule.Freq = Frequency(strings.ToUpper(val))
+		case "INTERVAL":
+			n, err := strconv.Atoi(val)
+			if err != nil || n <= 0 {
+				return Rule{}, ErrInvalidRule
+			}
+			rule.Interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(val)
+			if err != nil || n <= 0 {
+				return Rule{}, ErrInvalidRule
+			}
+			rule.Count = n
+		case "UNTIL":
+			until, err := parseDate(val, loc)
+			if err != nil {
+				return Rule{}, ErrInvalidRule
+			}
+			rule.Until = until
+		case "BYDAY":
+			for _, day := range strings.Split(strings.ToUpper(val), ",") {
+				weekday, ok := weekdays[day]
+				if !ok {
+					return Rule{}, ErrInvalidRule
+				}
+				rule.ByDay = append(rule.ByDay, weekday)
+			}
+		default:
+			return Rule{}, ErrInvalidRule
+		}
+	}
+
+	if rule.Freq != Daily && rule.Freq != Weekly {
+		return Rule{}, ErrInvalidRule
+	}
+
+	if rule.Freq == Daily && len(rule.ByDay) > 0 {
+		return Rule{}, ErrInvalidRule
+	}
+
+	if rule.Count == 0 && rule.Until.IsZero() {
+		return Rule{}, ErrNoEnd
+	}
+
+	return rule, nil
+}
+
+// Occurrences returns the starts of the series beginning at start, which is
+// always the first one. Every occurrence keeps the wall-clock time of start in
+// its time zone. More than limit occurrences give ErrTooManyEvents.
+func (r Rule) Occurrences(start time.Time, limit int) ([]time.Time, error) {
+	byDay := make(map[time.Weekday]bool, len(r.ByDay))
+	for _, day := range r.ByDay {
+		byDay[day] = true
+	}
+	if len(byDay) == 0 {
+		byDay[start.Weekday()] = true
+	}
+
+	firstWeek := weekStart(start)
+
+	var starts []time.Time
+
+	for day := 0; ; day++ {
+		t := time.Date(start.Year(), start.Month(), start.Day()+day, start.Hour(), start.Minute(), start.Second(), 0, start.Location())
+
+		if !r.Until.IsZero() && t.After(endOfDay(r.Until, start.Location())) {
+			break
+		}
+
+		if r.matches(t, day, firstWeek, byDay) {
+			if len(starts) == limit {
+				return nil, ErrTooManyEvents
+			}
+
+			starts = append(starts, t)
+
+			if len(starts) == r.Count {
+				break
+			}
+		}
+	}
+
+	return starts, nil
+}
+
+func (r Rule) matches(t time.Time, day int, firstWeek time.Time, byDay map[time.Weekday]bool) bool {
+	if day == 0 {
+		return true
+	}
+
+	switch r.Freq {
+	case Daily:
+		return day%r.Interval == 0
+	case Weekly:
+		weeks := int(weekStart(t).Sub(firstWeek).Hours()+12) / (7 * 24)
+		return byDay[t.Weekday()] && weeks%r.Interval == 0
+	}
+
+	return false
+}
+
+// String formats the rule back to its RRULE form.
+func (r Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+
+	if r.Interval > 1 {
+		parts = append(parts, fmt.Sprintf("INTERVAL=%d", r.Interval))
+	}
+
+	if len(r.ByDay) > 0 {
+		days := make([]string, 0, len(r.ByDay))
+		for _, weekday := range r.ByDay {
+			days = append(days, strings.ToUpper(weekday.String()[:2]))
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+
+	if r.Count > 0 {
+		parts = append(parts, fmt.Sprintf("COUNT=%d", r.Count))
+	}
+
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.Format("20060102"))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+// weekStart returns the midnight of the Monday of the week of t.
+func weekStart(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, t.Location())
+}
+
+func endOfDay(date time.Time, loc *time.Location) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day()+1, 0, 0, 0, 0, loc).Add(-time.Nanosecond)
+}
+
+func parseDate(value string, loc *time.Location) (time.Time, error) {
+	for _, layout := range []string{"20060102", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+
+	// RFC 5545 also allows a UTC date-time.
+	t, err := time.Parse("20060102T150405Z", value)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return t.In(loc), nil
+}
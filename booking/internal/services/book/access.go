@@ -0,0 +1,149 @@
+package book
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/lib/logger/sl"
+	"booking/internal/storage"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+var (
+	ErrInvalidAccessCode = errors.New("invalid access code")
+	ErrAccessNotYetValid = errors.New("access code is not valid yet")
+	ErrAccessExpired     = errors.New("access code has expired")
+	ErrAccessLocked      = errors.New("too many wrong PINs, try again later")
+)
+
+type AccessSigner interface {
+	Credentials(booking models.Booking) models.AccessCredentials
+	BookingUID(token string) (string, bool)
+}
+
+type CheckInStore interface {
+	BookingByPIN(ctx context.Context, boxName string, pin string, at time.Time) (models.Booking, error)
+	CheckIn(ctx context.Context, checkIn models.CheckIn) (models.CheckIn, error)
+	PINLockedUntil(ctx context.Context, boxName string, door string) (time.Time, error)
+	RecordPINFailure(ctx context.Context, boxName string, door string, at time.Time, maxFailures int64, lockout time.Duration) (time.Time, error)
+}
+
+// Credentials returns the door credentials of the booking.
+func (b *Book) Credentials(booking models.Booking) models.AccessCredentials {
+	return b.access.Credentials(booking)
+}
+
+// VerifyAccess checks a PIN or a signed token entered at a door of the box
+// and records the check-in when the door may open. A PIN opens the door once,
+// at the first check-in with it. PINs are short, so too many wrong ones lock
+// PIN entry at that door for a while. Tokens can't be guessed and keep
+// working for the whole booking.
+func (b *Book) VerifyAccess(ctx context.Context, boxName string, code string, door string) (models.CheckIn, models.Booking, error) {
+	const op = "book.VerifyAccess"
+
+	log := b.log.With(slog.String("op", op), slog.String("box", boxName), slog.String("door", door))
+
+	now := time.Now()
+
+	var (
+		booking models.Booking
+		method  models.CheckInMethod
+		err     error
+	)
+
+	if bookingUID, ok := b.access.BookingUID(code); ok {
+		method = models.CheckInMethodToken
+		booking, err = b.booker.Booking(ctx, bookingUID)
+	} else {
+		method = models.CheckInMethodPIN
+
+		lockedUntil, lockErr := b.checkIns.PINLockedUntil(ctx, boxName, door)
+		if lockErr != nil {
+			log.Error("failed to check the PIN lock", sl.Err(lockErr))
+			return models.CheckIn{}, models.Booking{}, fmt.Errorf("%s: %w", op, lockErr)
+		}
+		if now.Before(lockedUntil) {
+			log.Warn("access denied, PIN entry is locked", slog.Time("locked_until", lockedUntil))
+			return models.CheckIn{}, models.Booking{}, fmt.Errorf("%s: %w", op, ErrAccessLocked)
+		}
+
+		booking, err = b.checkIns.BookingByPIN(ctx, boxName, code, now)
+	}
+	if err != nil {
+		if errors.Is(err, storage.ErrBookingNotFound) {
+			log.Warn("access denied, unknown code", slog.String("method", string(method)))
+
+			if method == models.CheckInMethodPIN {
+				b.recordPINFailure(ctx, log, boxName, door, now)
+			}
+
+			return models.CheckIn{}, models.Booking{}, fmt.Errorf("%s: %w", op, ErrInvalidAccessCode)
+		}
+		log.Error("failed to find the booking", sl.Err(err))
+		return models.CheckIn{}, models.Booking{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log = log.With(slog.String("booking_id", booking.UID))
+
+	if booking.BoxName != boxName {
+		log.Warn("access denied, booking of another box")
+		return models.CheckIn{}, models.Booking{}, fmt.Errorf("%s: %w", op, ErrInvalidAccessCode)
+	}
+
+	if booking.Status != models.BookingStatusActive {
+		log.Warn("access denied, booking is not active", slog.String("status", string(booking.Status)))
+		return models.CheckIn{}, models.Booking{}, fmt.Errorf("%s: %w", op, ErrBookingNotActive)
+	}
+
+	credentials := b.access.Credentials(booking)
+
+	if now.Before(credentials.ValidFrom) {
+		log.Warn("access denied, too early")
+		return models.CheckIn{}, models.Booking{}, fmt.Errorf("%s: %w", op, ErrAccessNotYetValid)
+	}
+
+	if !now.Before(credentials.ValidUntil) {
+		log.Warn("access denied, booking has ended")
+		return models.CheckIn{}, models.Booking{}, fmt.Errorf("%s: %w", op, ErrAccessExpired)
+	}
+
+	checkIn, err := b.checkIns.CheckIn(ctx, models.CheckIn{
+		BookingUID: booking.UID,
+		BoxName:    boxName,
+		Door:       door,
+		Method:     method,
+		CreatedAt:  now,
+	})
+	if err != nil {
+		if errors.Is(err, storage.ErrBookingNotFound) {
+			log.Warn("access denied, PIN already used")
+			return models.CheckIn{}, models.Booking{}, fmt.Errorf("%s: %w", op, ErrInvalidAccessCode)
+		}
+		log.Error("failed to record the check-in", sl.Err(err))
+		return models.CheckIn{}, models.Booking{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if booking.CheckedInAt.IsZero() {
+		booking.CheckedInAt = now
+	}
+
+	log.Info("access granted", slog.String("method", string(method)))
+
+	return checkIn, booking, nil
+}
+
+// recordPINFailure counts a wrong PIN. The code is refused either way, a
+// failure to count it only gets logged.
+func (b *Book) recordPINFailure(ctx context.Context, log *slog.Logger, boxName string, door string, at time.Time) {
+	lockedUntil, err := b.checkIns.RecordPINFailure(ctx, boxName, door, at, b.maxPINAttempts, b.pinLockout)
+	if err != nil {
+		log.Error("failed to count the wrong PIN", sl.Err(err))
+		return
+	}
+
+	if !lockedUntil.IsZero() {
+		log.Warn("too many wrong PINs, PIN entry locked", slog.Time("locked_until", lockedUntil))
+	}
+}
@@ -0,0 +1,202 @@
+package book
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/lib/logger/sl"
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+)
+
+// Availability returns the free and busy intervals of the box within its
+// opening hours on the given day, plus the free slots of the given length.
+func (b *Book) Availability(ctx context.Context, boxName string, date time.Time, slot time.Duration) (models.Availability, error) {
+	const op = "book.Availability"
+
+	log := b.log.With(slog.String("op", op), slog.String("box", boxName))
+
+	box, err := b.Box(ctx, boxName)
+	if err != nil {
+		return models.Availability{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	opens, closes, err := b.openingHours(ctx, box, date)
+	if err != nil {
+		log.Error("invalid box schedule", sl.Err(err))
+		return models.Availability{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	availability := models.Availability{
+		Box:      box,
+		OpensAt:  opens,
+		ClosesAt: closes,
+	}
+
+	if !closes.After(opens) {
+		return availability, nil
+	}
+
+	busy, err := b.booker.BusyIntervals(ctx, boxName, opens, closes)
+	if err != nil {
+		log.Error("failed to get busy intervals", sl.Err(err))
+		return models.Availability{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if box.SharedSessions {
+		busy = fullIntervals(busy, box.Capacity)
+	}
+
+	blackouts, err := b.schedule.Blackouts(ctx, boxName, opens, closes)
+	if err != nil {
+		log.Error("failed to get blackouts", sl.Err(err))
+		return models.Availability{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, blackout := range blackouts {
+		busy = append(busy, models.Interval{StartsAt: blackout.StartsAt, ExpiresAt: blackout.EndsAt})
+	}
+
+	sort.Slice(busy, func(i, j int) bool {
+		return busy[i].StartsAt.Before(busy[j].StartsAt)
+	})
+
+	availability.Busy = mergeIntervals(clipIntervals(busy, opens, closes, opens.Location()))
+
+	from := opens
+	if now := time.Now().In(opens.Location()); now.After(from) {
+		from = now.Truncate(time.Minute)
+	}
+
+	availability.Free = freeIntervals(availability.Busy, from, closes)
+	availability.Slots = slots(availability.Free, opens, closes, slot)
+
+	return availability, nil
+}
+
+// clipIntervals cuts the intervals to [from, to) and converts them into loc.
+func clipIntervals(intervals []models.Interval, from time.Time, to time.Time, loc *time.Location) []models.Interval {
+	clipped := make([]models.Interval, 0, len(intervals))
+
+	for _, in := range intervals {
+		if in.StartsAt.Before(from) {
+			in.StartsAt = from
+		}
+		if in.ExpiresAt.After(to) {
+			in.ExpiresAt = to
+		}
+		if !in.ExpiresAt.After(in.StartsAt) {
+			continue
+		}
+
+		clipped = append(clipped, models.Interval{
+			StartsAt:  in.StartsAt.In(loc),
+			ExpiresAt: in.ExpiresAt.In(loc),
+		})
+	}
+
+	return clipped
+}
+
+// fullIntervals returns the parts of the timeline where the bookings of a
+// shared box take its whole capacity, ordered by start time.
+func fullIntervals(bookings []models.Interval, capacity int64) []models.Interval {
+	type event struct {
+		at     time.Time
+		people int64
+	}
+
+	events := make([]event, 0, 2*len(bookings))
+	for _, in := range bookings {
+		events = append(events, event{at: in.StartsAt, people: in.PeopleAmount}, event{at: in.ExpiresAt, people: -in.PeopleAmount})
+	}
+
+	// Ends go before starts at the same moment, adjacent bookings don't add up.
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].at.Equal(events[j].at) {
+			return events[i].people < events[j].people
+		}
+		return events[i].at.Before(events[j].at)
+	})
+
+	var (
+		full     []models.Interval
+		occupied int64
+		start    time.Time
+	)
+
+	for _, e := range events {
+		wasFull := occupied >= capacity
+		occupied += e.people
+
+		switch {
+		case !wasFull && occupied >= capacity:
+			start = e.at
+		case wasFull && occupied < capacity && e.at.After(start):
+			full = append(full, models.Interval{StartsAt: start, ExpiresAt: e.at})
+		}
+	}
+
+	return full
+}
+
+// mergeIntervals joins overlapping and adjacent intervals. The input must be
+// sorted by start time.
+func mergeIntervals(intervals []models.Interval) []models.Interval {
+	var merged []models.Interval
+
+	for _, in := range intervals {
+		if n := len(merged); n > 0 && !in.StartsAt.After(merged[n-1].ExpiresAt) {
+			if in.ExpiresAt.After(merged[n-1].ExpiresAt) {
+				merged[n-1].ExpiresAt = in.ExpiresAt
+			}
+			continue
+		}
+
+		merged = append(merged, in)
+	}
+
+	return merged
+}
+
+// freeIntervals returns the gaps between the merged busy intervals within [from, to).
+func freeIntervals(busy []models.Interval, from time.Time, to time.Time) []models.Interval {
+	var free []models.Interval
+
+	cursor := from
+
+	for _, in := range busy {
+		if in.StartsAt.After(cursor) {
+			free = append(free, models.Interval{StartsAt: cursor, ExpiresAt: in.StartsAt})
+		}
+		if in.ExpiresAt.After(cursor) {
+			cursor = in.ExpiresAt
+		}
+	}
+
+	if to.After(cursor) {
+		free = append(free, models.Interval{StartsAt: cursor, ExpiresAt: to})
+	}
+
+	return free
+}
+
+// slots returns the windows of the given length, aligned to opens, that fit
+// completely into one of the free intervals.
+func slots(free []models.Interval, opens time.Time, closes time.Time, length time.Duration) []models.Interval {
+	var result []models.Interval
+
+	for start := opens; !start.Add(length).After(closes); start = start.Add(length) {
+		end := start.Add(length)
+
+		for _, in := range free {
+			if !start.Before(in.StartsAt) && !end.After(in.ExpiresAt) {
+				result = append(result, models.Interval{StartsAt: start, ExpiresAt: end})
+				break
+			}
+		}
+	}
+
+	return result
+}
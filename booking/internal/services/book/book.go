@@ -1,83 +1,494 @@
 package book
 
 import (
+	"booking/internal/domain/models"
 	"booking/internal/lib/logger/sl"
+	"booking/internal/storage"
 	"context"
 	"errors"
 	"fmt"
 	"log/slog"
+	"sync"
+	"time"
 )
 
 var (
-	ErrAlreadyBooked = errors.New("this box is already booked for this time")
-	ErrBookingNotFound = errors.New("booking not found")
-	ErrNotYourBooking = errors.New("this booking belongs to another user")
+	ErrAlreadyBooked    = errors.New("this box is already booked for this time")
+	ErrBookingNotFound  = errors.New("booking not found")
+	ErrNotYourBooking   = errors.New("this booking belongs to another user")
+	ErrBookingNotActive = errors.New("booking is not active")
+	ErrInvalidCursor    = errors.New("invalid cursor")
+	ErrBoxNotFound      = errors.New("box not found")
+	ErrNotEnoughFunds   = errors.New("not enough funds to pay")
+	ErrCardNotFound     = errors.New("card not found")
+	ErrPaymentFailed    = errors.New("failed to pay for the booking")
+	ErrPaymentPending   = errors.New("payment is being processed")
+	ErrRefundFailed     = errors.New("failed to process refund")
+	ErrRefundPending    = errors.New("refund is being processed")
+)
+
+const (
+	defaultBookingsLimit = 20
+	maxBookingsLimit     = 100
 )
 
 type Book struct {
-	log    *slog.Logger
-	booker Booker
+	log          *slog.Logger
+	booker       Booker
+	boxProvider  BoxProvider
+	sagas        SagaStore
+	payments     Payments
+	pricer       Pricer
+	refunds      RefundPolicy
+	series       SeriesStore
+	waitlist     WaitlistStore
+	rescheduler  Rescheduler
+	schedule     ScheduleStore
+	access       AccessSigner
+	checkIns     CheckInStore
+	calendar     CalendarStore
+	notifier     Notifier
+	idempotency  IdempotencyStore
+	holds        HoldStore
+	promos       PromoStore
+	venues       VenueStore
+	participants ParticipantStore
+	// offerTTL is how long a waitlist offer holds the slot.
+	offerTTL time.Duration
+	// holdTTL is how long a checkout hold keeps the slot, a user has at most
+	// maxHolds of them at a time.
+	holdTTL  time.Duration
+	maxHolds int64
+	// claimTimeout is how long an idempotency key stays claimed by a request
+	// that has not stored its result.
+	claimTimeout time.Duration
+	// maxPINAttempts wrong PINs within pinLockout lock PIN entry at the
+	// door for pinLockout.
+	maxPINAttempts int64
+	pinLockout     time.Duration
+	// waitlistMu keeps two promotions from handing out the same entry.
+	waitlistMu sync.Mutex
 }
 
 type Booker interface {
-	BookABox(ctx context.Context, email string, boxName string, startTime string, timeMins int64, timeHrs int64) (resID int64, success bool, err error)
-	CancelBooking(ctx context.Context, email string, bookingID int64) (refundedAmount int64, success bool, err error)
+	BookABox(ctx context.Context, email string, boxName string, startsAt time.Time, expiresAt time.Time, peopleAmount int64, pricePaid int64) (models.Saga, error)
+	Booking(ctx context.Context, bookingID string) (models.Booking, error)
+	CancelBooking(ctx context.Context, booking models.Booking, refundAmount int64) (models.Saga, error)
+	Bookings(ctx context.Context, email string, filter models.BookingFilter) ([]models.Booking, error)
+	BusyIntervals(ctx context.Context, boxName string, from time.Time, to time.Time) ([]models.Interval, error)
+	IsSlotFree(ctx context.Context, boxName string, startsAt time.Time, expiresAt time.Time, peopleAmount int64) (bool, error)
+}
+
+type Pricer interface {
+	Quote(ctx context.Context, box models.Box, startsAt time.Time, duration time.Duration, peopleAmount int64) (models.Price, error)
+}
+
+type RefundPolicy interface {
+	Refund(booking models.Booking, initiator models.CancelInitiator, now time.Time) models.Refund
+}
+
+type BoxProvider interface {
+	Box(ctx context.Context, name string) (models.Box, error)
+	Boxes(ctx context.Context, includeInactive bool) ([]models.Box, error)
 }
 
-func NewBooker(log *slog.Logger, booker Booker) *Book {
+// Deps are the stores and services the booking service works with. The
+// sqlite storage implements all of the stores.
+type Deps struct {
+	Booker       Booker
+	Boxes        BoxProvider
+	Sagas        SagaStore
+	Payments     Payments
+	Pricer       Pricer
+	Refunds      RefundPolicy
+	Series       SeriesStore
+	Waitlist     WaitlistStore
+	Rescheduler  Rescheduler
+	Schedule     ScheduleStore
+	Access       AccessSigner
+	CheckIns     CheckInStore
+	Calendar     CalendarStore
+	Notifier     Notifier
+	Idempotency  IdempotencyStore
+	Holds        HoldStore
+	Promos       PromoStore
+	Venues       VenueStore
+	Participants ParticipantStore
+}
+
+// Config tunes the booking service.
+type Config struct {
+	// OfferTTL is how long a waitlist offer holds the slot.
+	OfferTTL time.Duration
+	// HoldTTL is how long a checkout hold keeps the slot, a user has at most
+	// MaxHolds of them at a time.
+	HoldTTL  time.Duration
+	MaxHolds int64
+	// IdempotencyClaimTimeout is how long an idempotency key stays claimed by
+	// a request that has not stored its result.
+	IdempotencyClaimTimeout time.Duration
+	// MaxPINAttempts wrong PINs within PINLockout lock PIN entry at the
+	// door for PINLockout.
+	MaxPINAttempts int64
+	PINLockout     time.Duration
+}
+
+func NewBooker(log *slog.Logger, deps Deps, cfg Config) *Book {
 	return &Book{
-		log:    log,
-		booker: booker,
+		log:            log,
+		booker:         deps.Booker,
+		boxProvider:    deps.Boxes,
+		sagas:          deps.Sagas,
+		payments:       deps.Payments,
+		pricer:         deps.Pricer,
+		refunds:        deps.Refunds,
+		series:         deps.Series,
+		waitlist:       deps.Waitlist,
+		rescheduler:    deps.Rescheduler,
+		schedule:       deps.Schedule,
+		access:         deps.Access,
+		checkIns:       deps.CheckIns,
+		calendar:       deps.Calendar,
+		notifier:       deps.Notifier,
+		idempotency:    deps.Idempotency,
+		holds:          deps.Holds,
+		promos:         deps.Promos,
+		venues:         deps.Venues,
+		participants:   deps.Participants,
+		offerTTL:       cfg.OfferTTL,
+		holdTTL:        cfg.HoldTTL,
+		maxHolds:       cfg.MaxHolds,
+		claimTimeout:   cfg.IdempotencyClaimTimeout,
+		maxPINAttempts: cfg.MaxPINAttempts,
+		pinLockout:     cfg.PINLockout,
+	}
+}
+
+// Book runs the booking saga: the slot is reserved first, then the wallet is
+// charged and the booking confirmed. A failed payment releases the slot, a
+// failed confirmation refunds the charge. The discount of an optional promo
+// code is taken off the price before the charge. A request repeated with the
+// same idempotency key gets the booking made by the first one. When the outcome
+// of the payment is unknown the booking is returned with ErrPaymentPending, it
+// stands once the saga recovery has charged it.
+func (b *Book) Book(ctx context.Context, email string, boxName string, startsAt time.Time, duration time.Duration, peopleAmount int64, promoCode string, idempotencyKey string) (models.Booking, models.Price, int64, error) {
+	var result bookResult
+
+	err := b.idempotent(ctx, idempotencyKey, email, models.IdempotentBook, bookRequest(boxName, startsAt, duration, peopleAmount, promoCode), &result, func() error {
+		var err error
+		result.Booking, result.Price, result.Balance, err = b.book(ctx, email, boxName, startsAt, duration, peopleAmount, promoCode)
+		return err
+	})
+	if err != nil {
+		return models.Booking{}, models.Price{}, 0, err
 	}
+
+	return result.Booking, result.Price, result.Balance, nil
 }
 
-func (b *Book) Book(ctx context.Context, email string, boxName string, timeStart string, timeHrs int64, timeMins int64) (reserveID int64, success bool, err error) {
+func (b *Book) book(ctx context.Context, email string, boxName string, startsAt time.Time, duration time.Duration, peopleAmount int64, promoCode string) (booking models.Booking, price models.Price, balance int64, err error) {
 	const op = "book.BookBox"
 
 	log := b.log.With(slog.String("op", op))
 
-	log.Info("booking a box")
+	log.Info("booking a box",
+		slog.String("box", boxName),
+		slog.Time("starts_at", startsAt),
+		slog.Duration("duration", duration))
+
+	box, err := b.Box(ctx, boxName)
+	if err != nil {
+		return models.Booking{}, models.Price{}, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := b.checkSchedule(ctx, box, startsAt, startsAt.Add(duration)); err != nil {
+		log.Error("the box is closed", sl.Err(err))
+		return models.Booking{}, models.Price{}, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	price, err = b.pricer.Quote(ctx, box, startsAt, duration, peopleAmount)
+	if err != nil {
+		log.Error("failed to calculate the price", sl.Err(err))
+		return models.Booking{}, models.Price{}, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var promo models.PromoCode
+
+	if promoCode != "" {
+		promo, price, err = b.applyPromo(ctx, email, box, startsAt, startsAt.Add(duration), promoCode, price)
+		if err != nil {
+			return models.Booking{}, models.Price{}, 0, fmt.Errorf("%s: %w", op, err)
+		}
+	}
 
-	resID, success, err := b.booker.BookABox(ctx, email, boxName, timeStart, timeHrs, timeMins)
+	saga, err := b.booker.BookABox(ctx, email, boxName, startsAt, startsAt.Add(duration), peopleAmount, price.Total)
 	if err != nil {
-		if err.Error() == ErrAlreadyBooked.Error() {
+		if errors.Is(err, storage.ErrAlreadyBooked) {
 			log.Error("this box is already booked")
-			return 0, false, fmt.Errorf("%s: %w", op, ErrAlreadyBooked)
+			return models.Booking{}, models.Price{}, 0, fmt.Errorf("%s: %w", op, ErrAlreadyBooked)
 		}
 		log.Error("failed to book a box", sl.Err(err))
-		return 0, false, fmt.Errorf("%s: %w", op, err)
+		return models.Booking{}, models.Price{}, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if promoCode != "" {
+		if err := b.redeemPromo(ctx, saga, promo, price.Discount); err != nil {
+			return models.Booking{}, models.Price{}, 0, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	booking = models.Booking{
+		UID:          saga.BookingID,
+		ID:           saga.LegacyBookingID,
+		Email:        email,
+		BoxName:      boxName,
+		StartsAt:     startsAt,
+		ExpiresAt:    startsAt.Add(duration),
+		PeopleAmount: peopleAmount,
+		PricePaid:    price.Total,
+		Status:       models.BookingStatusActive,
+	}
+
+	balance, err = b.charge(ctx, saga)
+	if err != nil {
+		if errors.Is(err, ErrPaymentPending) {
+			// The booking stands once the recovery has charged it.
+			return booking, price, emptyBalanceValue, fmt.Errorf("%s: %w", op, err)
+		}
+		return models.Booking{}, models.Price{}, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := b.confirm(ctx, saga); err != nil {
+		return models.Booking{}, models.Price{}, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("successfully booked a box",
+		slog.String("booking_id", saga.BookingID),
+		slog.Int64("price", price.Total))
+
+	return booking, price, balance, nil
+}
+
+// Quote prices a booking, with the discount of an optional promo code, and
+// checks the slot and the wallet without reserving or charging anything. A
+// wallet that can't be read gives an empty balance instead of an error.
+func (b *Book) Quote(ctx context.Context, email string, boxName string, startsAt time.Time, duration time.Duration, peopleAmount int64, promoCode string) (models.Quote, error) {
+	const op = "book.Quote"
+
+	log := b.log.With(slog.String("op", op), slog.String("box", boxName))
+
+	box, err := b.Box(ctx, boxName)
+	if err != nil {
+		return models.Quote{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	price, err := b.pricer.Quote(ctx, box, startsAt, duration, peopleAmount)
+	if err != nil {
+		log.Error("failed to calculate the price", sl.Err(err))
+		return models.Quote{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if promoCode != "" {
+		_, price, err = b.applyPromo(ctx, email, box, startsAt, startsAt.Add(duration), promoCode, price)
+		if err != nil {
+			return models.Quote{}, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	free, err := b.booker.IsSlotFree(ctx, boxName, startsAt, startsAt.Add(duration), peopleAmount)
+	if err != nil {
+		log.Error("failed to check the slot", sl.Err(err))
+		return models.Quote{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	balance, err := b.payments.Balance(ctx, email)
+	if err != nil {
+		log.Warn("failed to get the wallet balance", sl.Err(err))
+		balance = emptyBalanceValue
 	}
 
-	log.Info("successfully booked a box")
+	return models.Quote{
+		Price:    price,
+		Balance:  balance,
+		SlotFree: free,
+	}, nil
+}
+
+// CancelBooking runs the cancellation saga: the booking is held as cancelling
+// until the refund goes through. When the refund fails the booking stays active.
+// The refund is the part of the paid price allowed by the refund policy. Users
+// may only cancel their own bookings, operators may cancel any booking. A
+// request repeated with the same idempotency key gets the refund of the first
+// one. When the outcome of the refund is unknown the refund is returned with
+// ErrRefundPending, the saga recovery pays it.
+func (b *Book) CancelBooking(ctx context.Context, email string, bookingID string, initiator models.CancelInitiator, idempotencyKey string) (models.Refund, int64, error) {
+	var result cancelResult
+
+	err := b.idempotent(ctx, idempotencyKey, email, models.IdempotentCancel, cancelRequest(bookingID, initiator), &result, func() error {
+		var err error
+		result.Refund, result.Balance, err = b.cancelBooking(ctx, email, bookingID, initiator)
+		return err
+	})
+	if err != nil {
+		return models.Refund{}, 0, err
+	}
 
-	return resID, success, nil
+	return result.Refund, result.Balance, nil
 }
 
-func (b *Book) CancelBooking(ctx context.Context, email string, bookingID int64) (refundedAmount int64, success bool, err error) {
+func (b *Book) cancelBooking(ctx context.Context, email string, bookingID string, initiator models.CancelInitiator) (refund models.Refund, balance int64, err error) {
 	const op = "book.CancelBooking"
 
 	log := b.log.With(slog.String("op", op))
 
-	log.Info("canceling booking", 
-		slog.Int64("booking_id", bookingID),
-		slog.String("email", email))
+	log.Info("canceling booking",
+		slog.String("booking_id", bookingID),
+		slog.String("email", email),
+		slog.String("initiator", string(initiator)))
 
-	refundedAmount, success, err = b.booker.CancelBooking(ctx, email, bookingID)
+	booking, err := b.booker.Booking(ctx, bookingID)
 	if err != nil {
-		if errors.Is(err, ErrBookingNotFound) {
+		if errors.Is(err, storage.ErrBookingNotFound) {
 			log.Error("booking not found")
-			return 0, false, fmt.Errorf("%s: %w", op, ErrBookingNotFound)
+			return models.Refund{}, 0, fmt.Errorf("%s: %w", op, ErrBookingNotFound)
 		}
-		if errors.Is(err, ErrNotYourBooking) {
-			log.Error("booking belongs to another user")
-			return 0, false, fmt.Errorf("%s: %w", op, ErrNotYourBooking)
+		log.Error("failed to get booking", sl.Err(err))
+		return models.Refund{}, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if initiator != models.CancelByOperator && !ownedBy(booking, email) {
+		log.Error("booking belongs to another user")
+		return models.Refund{}, 0, fmt.Errorf("%s: %w", op, ErrNotYourBooking)
+	}
+
+	now := time.Now()
+
+	if booking.Status != models.BookingStatusActive || !now.Before(booking.ExpiresAt) {
+		log.Error("booking is not active")
+		return models.Refund{}, 0, fmt.Errorf("%s: %w", op, ErrBookingNotActive)
+	}
+
+	refund = b.refunds.Refund(booking, initiator, now)
+
+	saga, err := b.booker.CancelBooking(ctx, booking, refund.Amount)
+	if err != nil {
+		if errors.Is(err, storage.ErrBookingNotActive) {
+			log.Error("booking is not active")
+			return models.Refund{}, 0, fmt.Errorf("%s: %w", op, ErrBookingNotActive)
 		}
 		log.Error("failed to cancel booking", sl.Err(err))
-		return 0, false, fmt.Errorf("%s: %w", op, err)
+		return models.Refund{}, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	balance, err = b.refundCancel(ctx, saga)
+	if err != nil {
+		if errors.Is(err, ErrRefundPending) {
+			return refund, emptyBalanceValue, fmt.Errorf("%s: %w", op, err)
+		}
+		return models.Refund{}, 0, fmt.Errorf("%s: %w", op, err)
 	}
 
 	log.Info("successfully cancelled booking",
-		slog.Int64("refunded_amount", refundedAmount))
+		slog.String("refund_policy", string(refund.Policy)),
+		slog.Int64("refunded_amount", refund.Amount))
+
+	b.promoteSlot(ctx, booking.BoxName, booking.StartsAt, booking.ExpiresAt)
+
+	return refund, balance, nil
+}
+
+// Bookings returns one page of the user's bookings and the cursor of the next
+// page, which is empty on the last page.
+func (b *Book) Bookings(ctx context.Context, email string, filter models.BookingFilter, cursor string) ([]models.Booking, string, error) {
+	const op = "book.Bookings"
+
+	log := b.log.With(slog.String("op", op))
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultBookingsLimit
+	}
+	if filter.Limit > maxBookingsLimit {
+		filter.Limit = maxBookingsLimit
+	}
+
+	if cursor != "" {
+		after, err := decodeCursor(cursor)
+		if err != nil {
+			return nil, "", fmt.Errorf("%s: %w", op, ErrInvalidCursor)
+		}
+		filter.After = &after
+	}
+
+	limit := filter.Limit
+	// One extra row tells whether there is a next page.
+	filter.Limit++
+
+	bookings, err := b.booker.Bookings(ctx, email, filter)
+	if err != nil {
+		log.Error("failed to list bookings", sl.Err(err))
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	b.localize(ctx, bookings)
+
+	var next string
+	if len(bookings) > limit {
+		bookings = bookings[:limit]
+		last := bookings[limit-1]
+		next = encodeCursor(models.BookingCursor{StartsAt: last.StartsAt.Unix(), ID: last.ID})
+	}
+
+	return bookings, next, nil
+}
+
+func (b *Book) Box(ctx context.Context, name string) (models.Box, error) {
+	const op = "book.Box"
+
+	box, err := b.boxProvider.Box(ctx, name)
+	if err != nil {
+		if errors.Is(err, storage.ErrBoxNotFound) {
+			return models.Box{}, fmt.Errorf("%s: %w", op, ErrBoxNotFound)
+		}
+		b.log.Error("failed to get box", slog.String("op", op), sl.Err(err))
+		return models.Box{}, fmt.Errorf("%s: %w", op, err)
+	}
 
-	return refundedAmount, success, nil
+	return box, nil
+}
+
+func (b *Book) Boxes(ctx context.Context, includeInactive bool) ([]models.Box, error) {
+	const op = "book.Boxes"
+
+	boxes, err := b.boxProvider.Boxes(ctx, includeInactive)
+	if err != nil {
+		b.log.Error("failed to list boxes", slog.String("op", op), sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return boxes, nil
+}
+
+// localize converts booking times into the time zone of their box.
+func (b *Book) localize(ctx context.Context, bookings []models.Booking) {
+	locations := make(map[string]*time.Location)
+
+	for i := range bookings {
+		loc, ok := locations[bookings[i].BoxName]
+		if !ok {
+			if box, err := b.boxProvider.Box(ctx, bookings[i].BoxName); err == nil {
+				loc, _ = box.Location()
+			}
+			locations[bookings[i].BoxName] = loc
+		}
+
+		if loc == nil {
+			continue
+		}
+
+		bookings[i].StartsAt = bookings[i].StartsAt.In(loc)
+		bookings[i].ExpiresAt = bookings[i].ExpiresAt.In(loc)
+		if !bookings[i].CancelledAt.IsZero() {
+			bookings[i].CancelledAt = bookings[i].CancelledAt.In(loc)
+		}
+	}
 }
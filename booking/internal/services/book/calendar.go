@@ -0,0 +1,211 @@
+package book
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/lib/ical"
+	"booking/internal/lib/logger/sl"
+	"booking/internal/storage"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+const (
+	calendarProdID = "-//SportBox//Booking//EN"
+	// calendarUIDDomain makes event UIDs globally unique.
+	calendarUIDDomain = "@sport-box"
+
+	// Calendar feeds cover the bookings starting within this window.
+	calendarPast  = 90 * 24 * time.Hour
+	calendarAhead = 365 * 24 * time.Hour
+)
+
+var ErrCalendarFeedNotFound = errors.New("calendar feed not found")
+
+type CalendarStore interface {
+	CalendarFeed(ctx context.Context, email string) (models.CalendarFeed, error)
+	RotateCalendarFeed(ctx context.Context, email string) (models.CalendarFeed, error)
+	CalendarFeedByToken(ctx context.Context, token string) (models.CalendarFeed, error)
+	UserCalendarBookings(ctx context.Context, email string, from time.Time, to time.Time) ([]models.Booking, error)
+	BoxCalendarBookings(ctx context.Context, boxName string, from time.Time, to time.Time) ([]models.Booking, error)
+}
+
+// CalendarFeed returns the private calendar feed of the user. Rotating it
+// gives the feed a new token, the old URL stops working.
+func (b *Book) CalendarFeed(ctx context.Context, email string, rotate bool) (models.CalendarFeed, error) {
+	const op = "book.CalendarFeed"
+
+	feed, err := b.calendar.CalendarFeed(ctx, email)
+	if rotate {
+		feed, err = b.calendar.RotateCalendarFeed(ctx, email)
+	}
+	if err != nil {
+		b.log.Error("failed to get the calendar feed", slog.String("op", op), sl.Err(err))
+		return models.CalendarFeed{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return feed, nil
+}
+
+// UserCalendar renders the bookings of the owner of the feed token, the ones
+// they made and the ones they joined, as an iCalendar. Events keep the booking ID as UID, so moved and cancelled
+// bookings update their event.
+func (b *Book) UserCalendar(ctx context.Context, token string) ([]byte, error) {
+	const op = "book.UserCalendar"
+
+	log := b.log.With(slog.String("op", op))
+
+	feed, err := b.calendar.CalendarFeedByToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, storage.ErrCalendarFeedNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, ErrCalendarFeedNotFound)
+		}
+		log.Error("failed to get the calendar feed", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	now := time.Now()
+
+	bookings, err := b.calendar.UserCalendarBookings(ctx, feed.Email, now.Add(-calendarPast), now.Add(calendarAhead))
+	if err != nil {
+		log.Error("failed to get bookings", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	boxes, err := b.Boxes(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	addresses := make(map[string]string, len(boxes))
+	for _, box := range boxes {
+		addresses[box.Name] = box.Address
+	}
+
+	calendar := ical.Calendar{
+		ProdID: calendarProdID,
+		Name:   "Sport box bookings",
+		Events: make([]ical.Event, 0, len(bookings)),
+	}
+
+	for _, booking := range bookings {
+		event := bookingEvent(booking, "booking-", now)
+		event.Summary = "Sport box " + booking.BoxName
+		event.Location = addresses[booking.BoxName]
+		event.Description = fmt.Sprintf("People: %d\nBooking: %s", booking.PeopleAmount, booking.UID)
+
+		calendar.Events = append(calendar.Events, event)
+	}
+
+	return calendar.Marshal(), nil
+}
+
+// BoxCalendar renders the schedule of the box as an iCalendar: booked slots
+// without personal data, closures and maintenance blackouts.
+func (b *Book) BoxCalendar(ctx context.Context, boxName string) ([]byte, error) {
+	const op = "book.BoxCalendar"
+
+	log := b.log.With(slog.String("op", op), slog.String("box", boxName))
+
+	box, err := b.Box(ctx, boxName)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	loc, err := box.Location()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	now := time.Now()
+	from, to := now.Add(-calendarPast), now.Add(calendarAhead)
+
+	bookings, err := b.calendar.BoxCalendarBookings(ctx, boxName, from, to)
+	if err != nil {
+		log.Error("failed to get bookings", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	schedule, err := b.Schedule(ctx, boxName, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	calendar := ical.Calendar{
+		ProdID: calendarProdID,
+		Name:   box.Name + " schedule",
+		Events: make([]ical.Event, 0, len(bookings)+len(schedule.Closures)+len(schedule.Blackouts)),
+	}
+
+	for _, booking := range bookings {
+		event := bookingEvent(booking, "slot-", now)
+		event.Summary = "Booked"
+		if box.SharedSessions {
+			event.Summary = fmt.Sprintf("Booked, %d people", booking.PeopleAmount)
+		}
+		event.Location = box.Address
+
+		calendar.Events = append(calendar.Events, event)
+	}
+
+	for _, closure := range schedule.Closures {
+		day, err := time.ParseInLocation(dateLayout, closure.Date, loc)
+		if err != nil {
+			log.Warn("invalid closure date", slog.String("closure_id", closure.UID), sl.Err(err))
+			continue
+		}
+
+		summary := "Closed"
+		if closure.Name != "" {
+			summary += ": " + closure.Name
+		}
+
+		calendar.Events = append(calendar.Events, ical.Event{
+			UID:     "closure-" + closure.UID + calendarUIDDomain,
+			Stamp:   now,
+			Start:   day,
+			End:     day.AddDate(0, 0, 1),
+			AllDay:  true,
+			Summary: summary,
+			Status:  ical.StatusConfirmed,
+		})
+	}
+
+	for _, blackout := range schedule.Blackouts {
+		summary := "Maintenance"
+		if blackout.Reason != "" {
+			summary += ": " + blackout.Reason
+		}
+
+		calendar.Events = append(calendar.Events, ical.Event{
+			UID:     "blackout-" + blackout.UID + calendarUIDDomain,
+			Stamp:   now,
+			Start:   blackout.StartsAt,
+			End:     blackout.EndsAt,
+			Summary: summary,
+			Status:  ical.StatusConfirmed,
+		})
+	}
+
+	return calendar.Marshal(), nil
+}
+
+// bookingEvent returns the event of a booking with a UID made of prefix and
+// the booking ID.
+func bookingEvent(booking models.Booking, prefix string, now time.Time) ical.Event {
+	status := ical.StatusConfirmed
+	if booking.Status == models.BookingStatusCancelled {
+		status = ical.StatusCancelled
+	}
+
+	return ical.Event{
+		UID:      prefix + booking.UID + calendarUIDDomain,
+		Sequence: booking.Revision,
+		Stamp:    now,
+		Start:    booking.StartsAt,
+		End:      booking.ExpiresAt,
+		Status:   status,
+	}
+}
@@ -0,0 +1,41 @@
+package book
+
+import (
+	"booking/internal/domain/models"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Cursors are opaque to clients: base64 of "<startsAt>:<id>" of the last
+// booking on the previous page.
+
+func encodeCursor(c models.BookingCursor) string {
+	raw := strconv.FormatInt(c.StartsAt, 10) + ":" + strconv.FormatInt(c.ID, 10)
+
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeCursor(cursor string) (models.BookingCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return models.BookingCursor{}, err
+	}
+
+	startsAt, id, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return models.BookingCursor{}, fmt.Errorf("malformed cursor %q", raw)
+	}
+
+	var c models.BookingCursor
+
+	if c.StartsAt, err = strconv.ParseInt(startsAt, 10, 64); err != nil {
+		return models.BookingCursor{}, err
+	}
+	if c.ID, err = strconv.ParseInt(id, 10, 64); err != nil {
+		return models.BookingCursor{}, err
+	}
+
+	return c, nil
+}
@@ -0,0 +1,240 @@
+package book
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/lib/logger/sl"
+	"booking/internal/storage"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+var (
+	ErrHoldNotFound = errors.New("hold not found")
+	ErrNotYourHold  = errors.New("this hold belongs to another user")
+	ErrHoldExpired  = errors.New("hold has expired")
+	ErrTooManyHolds = errors.New("too many active holds")
+)
+
+type HoldStore interface {
+	HoldSlot(ctx context.Context, hold models.Hold, maxPerUser int64) (models.Hold, error)
+	Hold(ctx context.Context, holdUID string) (models.Hold, error)
+	ConvertHold(ctx context.Context, hold models.Hold, pricePaid int64) (models.Saga, error)
+	ExpireHolds(ctx context.Context, now time.Time) ([]models.Hold, error)
+	ReleaseHold(ctx context.Context, holdUID string) error
+}
+
+// HoldSlot reserves the slot for the user for holdTTL while they check out.
+// Nothing is charged until the hold is booked.
+func (b *Book) HoldSlot(ctx context.Context, email string, boxName string, startsAt time.Time, duration time.Duration, peopleAmount int64) (models.Hold, models.Price, error) {
+	const op = "book.HoldSlot"
+
+	log := b.log.With(slog.String("op", op), slog.String("box", boxName))
+
+	box, err := b.Box(ctx, boxName)
+	if err != nil {
+		return models.Hold{}, models.Price{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := b.checkSchedule(ctx, box, startsAt, startsAt.Add(duration)); err != nil {
+		return models.Hold{}, models.Price{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	price, err := b.pricer.Quote(ctx, box, startsAt, duration, peopleAmount)
+	if err != nil {
+		log.Error("failed to calculate the price", sl.Err(err))
+		return models.Hold{}, models.Price{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	hold, err := b.holds.HoldSlot(ctx, models.Hold{
+		Email:        email,
+		BoxName:      boxName,
+		StartsAt:     startsAt,
+		ExpiresAt:    startsAt.Add(duration),
+		PeopleAmount: peopleAmount,
+		HeldUntil:    time.Now().Add(b.holdTTL),
+	}, b.maxHolds)
+	if err != nil {
+		if errors.Is(err, storage.ErrAlreadyBooked) {
+			return models.Hold{}, models.Price{}, fmt.Errorf("%s: %w", op, ErrAlreadyBooked)
+		}
+		if errors.Is(err, storage.ErrTooManyHolds) {
+			return models.Hold{}, models.Price{}, fmt.Errorf("%s: %w", op, ErrTooManyHolds)
+		}
+		log.Error("failed to hold the slot", sl.Err(err))
+		return models.Hold{}, models.Price{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("slot held", slog.String("hold_id", hold.UID), slog.Time("held_until", hold.HeldUntil))
+
+	return hold, price, nil
+}
+
+// BookHold runs the booking saga for the slot of a hold: the hold becomes the
+// booking, then the wallet is charged and the booking confirmed. The price is
+// quoted again, so it is the one at the time of booking, less the discount of
+// an optional promo code. A request repeated with the same idempotency key
+// gets the booking made by the first one. As with Book, a booking whose
+// payment outcome is unknown is returned with ErrPaymentPending. The opening
+// hours and maintenance of the box are checked again, a hold whose slot has
+// been closed since is released and fails with the reason.
+func (b *Book) BookHold(ctx context.Context, email string, holdID string, promoCode string, idempotencyKey string) (models.Booking, models.Price, int64, error) {
+	var result bookResult
+
+	err := b.idempotent(ctx, idempotencyKey, email, models.IdempotentBook, holdRequest(holdID, promoCode), &result, func() error {
+		var err error
+		result.Booking, result.Price, result.Balance, err = b.bookHold(ctx, email, holdID, promoCode)
+		return err
+	})
+	if err != nil {
+		return models.Booking{}, models.Price{}, 0, err
+	}
+
+	return result.Booking, result.Price, result.Balance, nil
+}
+
+func (b *Book) bookHold(ctx context.Context, email string, holdID string, promoCode string) (booking models.Booking, price models.Price, balance int64, err error) {
+	const op = "book.BookHold"
+
+	log := b.log.With(slog.String("op", op), slog.String("hold_id", holdID))
+
+	hold, err := b.holds.Hold(ctx, holdID)
+	if err != nil {
+		if errors.Is(err, storage.ErrHoldNotFound) {
+			return models.Booking{}, models.Price{}, 0, fmt.Errorf("%s: %w", op, ErrHoldNotFound)
+		}
+		log.Error("failed to get the hold", sl.Err(err))
+		return models.Booking{}, models.Price{}, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if hold.Email != email {
+		return models.Booking{}, models.Price{}, 0, fmt.Errorf("%s: %w", op, ErrNotYourHold)
+	}
+
+	if !time.Now().Before(hold.HeldUntil) {
+		return models.Booking{}, models.Price{}, 0, fmt.Errorf("%s: %w", op, ErrHoldExpired)
+	}
+
+	box, err := b.Box(ctx, hold.BoxName)
+	if err != nil {
+		return models.Booking{}, models.Price{}, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// The box may have been closed while the user was checking out.
+	if err := b.checkSchedule(ctx, box, hold.StartsAt, hold.ExpiresAt); err != nil {
+		if errors.Is(err, ErrOutsideOpeningHours) || errors.Is(err, ErrMaintenance) {
+			log.Info("the slot of the hold was closed, releasing it", slog.String("reason", err.Error()))
+
+			if releaseErr := b.holds.ReleaseHold(ctx, hold.UID); releaseErr != nil {
+				log.Error("failed to release the hold", sl.Err(releaseErr))
+			}
+		}
+		return models.Booking{}, models.Price{}, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	duration := hold.ExpiresAt.Sub(hold.StartsAt)
+
+	price, err = b.pricer.Quote(ctx, box, hold.StartsAt, duration, hold.PeopleAmount)
+	if err != nil {
+		log.Error("failed to calculate the price", sl.Err(err))
+		return models.Booking{}, models.Price{}, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var promo models.PromoCode
+
+	if promoCode != "" {
+		promo, price, err = b.applyPromo(ctx, email, box, hold.StartsAt, hold.ExpiresAt, promoCode, price)
+		if err != nil {
+			return models.Booking{}, models.Price{}, 0, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	saga, err := b.holds.ConvertHold(ctx, hold, price.Total)
+	if err != nil {
+		if errors.Is(err, storage.ErrHoldExpired) {
+			return models.Booking{}, models.Price{}, 0, fmt.Errorf("%s: %w", op, ErrHoldExpired)
+		}
+		log.Error("failed to book the hold", sl.Err(err))
+		return models.Booking{}, models.Price{}, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if promoCode != "" {
+		if err := b.redeemPromo(ctx, saga, promo, price.Discount); err != nil {
+			return models.Booking{}, models.Price{}, 0, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	booking = models.Booking{
+		UID:          saga.BookingID,
+		ID:           saga.LegacyBookingID,
+		Email:        hold.Email,
+		BoxName:      hold.BoxName,
+		StartsAt:     hold.StartsAt,
+		ExpiresAt:    hold.ExpiresAt,
+		PeopleAmount: hold.PeopleAmount,
+		PricePaid:    price.Total,
+		Status:       models.BookingStatusActive,
+	}
+
+	balance, err = b.charge(ctx, saga)
+	if err != nil {
+		if errors.Is(err, ErrPaymentPending) {
+			// The booking stands once the recovery has charged it.
+			return booking, price, emptyBalanceValue, fmt.Errorf("%s: %w", op, err)
+		}
+		return models.Booking{}, models.Price{}, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := b.confirm(ctx, saga); err != nil {
+		return models.Booking{}, models.Price{}, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("hold booked", slog.String("booking_id", saga.BookingID), slog.Int64("price", price.Total))
+
+	return booking, price, balance, nil
+}
+
+// SweepHolds releases the holds that ran out and offers their slots to the
+// waitlist.
+func (b *Book) SweepHolds(ctx context.Context) error {
+	const op = "book.SweepHolds"
+
+	holds, err := b.holds.ExpireHolds(ctx, time.Now())
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, hold := range holds {
+		b.log.Info("hold expired", slog.String("op", op), slog.String("hold_id", hold.UID))
+
+		b.promoteSlot(ctx, hold.BoxName, hold.StartsAt, hold.ExpiresAt)
+	}
+
+	return nil
+}
+
+func (b *Book) StartHoldSweeper(ctx context.Context, interval time.Duration) <-chan error {
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(errCh)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := b.SweepHolds(ctx); err != nil {
+					errCh <- err
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return errCh
+}
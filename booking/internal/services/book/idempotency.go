@@ -0,0 +1,163 @@
+package book
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/lib/logger/sl"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+var (
+	ErrIdempotencyKeyReused = errors.New("idempotency key was used for another request")
+	ErrRequestInProgress    = errors.New("a request with this idempotency key is in progress")
+)
+
+type IdempotencyStore interface {
+	ClaimIdempotencyKey(ctx context.Context, record models.IdempotencyRecord, staleBefore time.Time) (stored models.IdempotencyRecord, claimed bool, err error)
+	SaveIdempotencyResult(ctx context.Context, email string, key string, result []byte) error
+	SavePendingIdempotencyResult(ctx context.Context, email string, key string, sagaID int64, result []byte) error
+	ReleaseIdempotencyKey(ctx context.Context, email string, key string) error
+}
+
+// bookResult and cancelResult are the results remembered for a repeated
+// idempotency key.
+type bookResult struct {
+	Booking models.Booking `json:"booking"`
+	Price   models.Price   `json:"price"`
+	Balance int64          `json:"balance"`
+}
+
+type cancelResult struct {
+	Refund  models.Refund `json:"refund"`
+	Balance int64         `json:"balance"`
+}
+
+// idempotent runs the request once per idempotency key of the user. The
+// result of a repeated key is read into result instead, run fills it
+// otherwise. A failed request releases the key so that it can be retried,
+// without a key the request simply runs. A request that died before storing
+// its result can't release the key, the same request takes it over once the
+// claim is older than the claim timeout.
+//
+// A request whose payment outcome is unknown has not failed: its saga is
+// settled by the recovery. The key stays claimed with the result the request
+// has once the saga completes, a rolled back saga releases it.
+func (b *Book) idempotent(ctx context.Context, key string, email string, operation models.IdempotentOperation, request string, result any, run func() error) error {
+	const op = "book.idempotent"
+
+	if key == "" {
+		return run()
+	}
+
+	log := b.log.With(slog.String("op", op), slog.String("operation", string(operation)))
+
+	stored, claimed, err := b.idempotency.ClaimIdempotencyKey(ctx, models.IdempotencyRecord{
+		Email:     email,
+		Key:       key,
+		Operation: operation,
+		Request:   request,
+	}, time.Now().Add(-b.claimTimeout))
+	if err != nil {
+		log.Error("failed to claim the idempotency key", sl.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !claimed {
+		if stored.Operation != operation || stored.Request != request {
+			return fmt.Errorf("%s: %w", op, ErrIdempotencyKeyReused)
+		}
+
+		if stored.SagaID != 0 {
+			switch stored.SagaState {
+			case models.SagaStateCompleted:
+				// The result stands, it is replayed below.
+			case models.SagaStateCompensated:
+				log.Info("the saga of the idempotency key was rolled back, running the request again")
+
+				if err := b.idempotency.ReleaseIdempotencyKey(ctx, email, key); err != nil {
+					return fmt.Errorf("%s: %w", op, err)
+				}
+
+				return b.idempotent(ctx, key, email, operation, request, result, run)
+			default:
+				return fmt.Errorf("%s: %w", op, ErrRequestInProgress)
+			}
+		}
+
+		if stored.Result == nil {
+			return fmt.Errorf("%s: %w", op, ErrRequestInProgress)
+		}
+
+		log.Info("replaying the result of the idempotency key")
+
+		if err := json.Unmarshal(stored.Result, result); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		return nil
+	}
+
+	// The caller may have gone away, the key is settled anyway.
+	settleCtx := context.WithoutCancel(ctx)
+
+	if err := run(); err != nil {
+		var pending *pendingSaga
+		if errors.As(err, &pending) {
+			data, saveErr := json.Marshal(result)
+			if saveErr == nil {
+				saveErr = b.idempotency.SavePendingIdempotencyResult(settleCtx, email, key, pending.sagaID, data)
+			}
+			if saveErr != nil {
+				log.Error("failed to save the pending result of the idempotency key", sl.Err(saveErr))
+			}
+
+			return err
+		}
+
+		if releaseErr := b.idempotency.ReleaseIdempotencyKey(settleCtx, email, key); releaseErr != nil {
+			log.Error("failed to release the idempotency key", sl.Err(releaseErr))
+		}
+
+		return err
+	}
+
+	data, err := json.Marshal(result)
+	if err == nil {
+		err = b.idempotency.SaveIdempotencyResult(settleCtx, email, key, data)
+	}
+	if err != nil {
+		// The request went through, a retry gets ErrRequestInProgress until
+		// the claim times out.
+		log.Error("failed to save the result of the idempotency key", sl.Err(err))
+	}
+
+	return nil
+}
+
+// bookRequest, holdRequest and cancelRequest describe the parameters a
+// repeated key must come with. The promo code is only added when there is
+// one, so requests without it keep their old description.
+func bookRequest(boxName string, startsAt time.Time, duration time.Duration, peopleAmount int64, promoCode string) string {
+	return withPromo(fmt.Sprintf("%s|%d|%d|%d", boxName, startsAt.Unix(), int64(duration/time.Second), peopleAmount), promoCode)
+}
+
+func holdRequest(holdID string, promoCode string) string {
+	return withPromo("hold|"+holdID, promoCode)
+}
+
+func withPromo(request string, promoCode string) string {
+	if promoCode == "" {
+		return request
+	}
+
+	return request + "|promo:" + strings.ToUpper(promoCode)
+}
+
+func cancelRequest(bookingID string, initiator models.CancelInitiator) string {
+	return fmt.Sprintf("%s|%s", bookingID, initiator)
+}
@@ -0,0 +1,56 @@
+package book
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/lib/logger/sl"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+var ErrInvalidLocale = errors.New("unsupported locale")
+
+type Notifier interface {
+	Notify(ctx context.Context, notice models.Notice) error
+	SetLocale(ctx context.Context, email string, locale models.Locale) error
+}
+
+// SetNotificationLocale sets the language of the notifications of the user.
+func (b *Book) SetNotificationLocale(ctx context.Context, email string, locale models.Locale) error {
+	const op = "book.SetNotificationLocale"
+
+	if !locale.Valid() {
+		return fmt.Errorf("%s: %w", op, ErrInvalidLocale)
+	}
+
+	if err := b.notifier.SetLocale(ctx, email, locale); err != nil {
+		b.log.Error("failed to save the locale", slog.String("op", op), sl.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// notify tells the user about a change of the booking. The change is done
+// already, so failing to queue the notification only gets logged.
+func (b *Book) notify(ctx context.Context, kind models.NotificationKind, bookingID string, amount int64, ref string) {
+	const op = "book.notify"
+
+	log := b.log.With(slog.String("op", op), slog.String("booking_id", bookingID), slog.String("kind", string(kind)))
+
+	booking, err := b.booker.Booking(ctx, bookingID)
+	if err != nil {
+		log.Error("failed to get the booking", sl.Err(err))
+		return
+	}
+
+	if err := b.notifier.Notify(ctx, models.Notice{
+		Kind:    kind,
+		Booking: booking,
+		Amount:  amount,
+		Ref:     ref,
+	}); err != nil {
+		log.Error("failed to queue the notification", sl.Err(err))
+	}
+}
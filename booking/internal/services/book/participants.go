@@ -0,0 +1,216 @@
+package book
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/lib/logger/sl"
+	"booking/internal/storage"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvitationNotFound  = errors.New("invitation not found")
+	ErrAlreadyInvited      = errors.New("this user is already invited to the booking")
+	ErrInvitationDeclined  = errors.New("invitation was declined")
+	ErrTooManyParticipants = errors.New("the booking has no room for more participants")
+	ErrInviteOwner         = errors.New("the owner of the booking can't be invited")
+)
+
+type ParticipantStore interface {
+	InviteParticipant(ctx context.Context, bookingUID string, email string, maxParticipants int64) (models.Participant, error)
+	RespondToInvitation(ctx context.Context, bookingUID string, email string, status models.ParticipantStatus) (models.Participant, error)
+	Participants(ctx context.Context, bookingUID string) ([]models.Participant, error)
+	Invitations(ctx context.Context, email string, now time.Time) ([]models.Participant, error)
+}
+
+// InviteParticipant lets the owner of an upcoming booking invite someone to
+// come along. The booking takes one participant less than its people amount,
+// the owner being one of them. The invitee is notified.
+func (b *Book) InviteParticipant(ctx context.Context, email string, bookingID string, participantEmail string) (models.Participant, error) {
+	const op = "book.InviteParticipant"
+
+	log := b.log.With(slog.String("op", op), slog.String("booking_id", bookingID))
+
+	booking, err := b.booker.Booking(ctx, bookingID)
+	if err != nil {
+		if errors.Is(err, storage.ErrBookingNotFound) {
+			return models.Participant{}, fmt.Errorf("%s: %w", op, ErrBookingNotFound)
+		}
+		return models.Participant{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !ownedBy(booking, email) {
+		return models.Participant{}, fmt.Errorf("%s: %w", op, ErrNotYourBooking)
+	}
+
+	if err := upcoming(booking); err != nil {
+		return models.Participant{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	participantEmail = normalizeEmail(participantEmail)
+	if strings.EqualFold(participantEmail, booking.Email) {
+		return models.Participant{}, fmt.Errorf("%s: %w", op, ErrInviteOwner)
+	}
+
+	participant, err := b.participants.InviteParticipant(ctx, booking.UID, participantEmail, booking.PeopleAmount-1)
+	if err != nil {
+		if errors.Is(err, storage.ErrAlreadyInvited) {
+			return models.Participant{}, fmt.Errorf("%s: %w", op, ErrAlreadyInvited)
+		}
+		if errors.Is(err, storage.ErrTooManyParticipants) {
+			return models.Participant{}, fmt.Errorf("%s: %w", op, ErrTooManyParticipants)
+		}
+		log.Error("failed to invite the participant", sl.Err(err))
+		return models.Participant{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// The invitation is made already, failing to queue the notification only
+	// gets logged.
+	if err := b.notifier.Notify(ctx, models.Notice{
+		Kind:    models.NotificationInvited,
+		Booking: booking,
+		Ref:     participant.Email + ":" + strconv.FormatInt(participant.InvitedAt.Unix(), 10),
+		To:      participant.Email,
+	}); err != nil {
+		log.Error("failed to queue the notification", sl.Err(err))
+	}
+
+	log.Info("participant invited")
+
+	return participant, nil
+}
+
+// RespondToInvitation accepts or declines the invitation of the user to an
+// upcoming booking. An accepted invitation can still be declined later.
+func (b *Book) RespondToInvitation(ctx context.Context, email string, bookingID string, accept bool) (models.Participant, error) {
+	const op = "book.RespondToInvitation"
+
+	log := b.log.With(slog.String("op", op), slog.String("booking_id", bookingID))
+
+	booking, err := b.booker.Booking(ctx, bookingID)
+	if err != nil {
+		if errors.Is(err, storage.ErrBookingNotFound) {
+			return models.Participant{}, fmt.Errorf("%s: %w", op, ErrInvitationNotFound)
+		}
+		return models.Participant{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := upcoming(booking); err != nil {
+		return models.Participant{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	status := models.ParticipantStatusDeclined
+	if accept {
+		status = models.ParticipantStatusAccepted
+	}
+
+	participant, err := b.participants.RespondToInvitation(ctx, booking.UID, normalizeEmail(email), status)
+	if err != nil {
+		if errors.Is(err, storage.ErrInvitationNotFound) {
+			return models.Participant{}, fmt.Errorf("%s: %w", op, ErrInvitationNotFound)
+		}
+		if errors.Is(err, storage.ErrInvitationDeclined) {
+			return models.Participant{}, fmt.Errorf("%s: %w", op, ErrInvitationDeclined)
+		}
+		log.Error("failed to answer the invitation", sl.Err(err))
+		return models.Participant{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("invitation answered", slog.String("status", string(participant.Status)))
+
+	return participant, nil
+}
+
+// Participants returns the people invited to the booking. The owner and the
+// participants can see them.
+func (b *Book) Participants(ctx context.Context, email string, bookingID string) ([]models.Participant, error) {
+	const op = "book.Participants"
+
+	booking, err := b.booker.Booking(ctx, bookingID)
+	if err != nil {
+		if errors.Is(err, storage.ErrBookingNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, ErrBookingNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	participants, err := b.participants.Participants(ctx, booking.UID)
+	if err != nil {
+		b.log.Error("failed to get participants", slog.String("op", op), sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if ownedBy(booking, email) {
+		return participants, nil
+	}
+
+	for _, participant := range participants {
+		if strings.EqualFold(participant.Email, email) && participant.Status != models.ParticipantStatusDeclined {
+			return participants, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s: %w", op, ErrNotYourBooking)
+}
+
+// Invitations returns the upcoming bookings the user is invited to and has not
+// answered yet.
+func (b *Book) Invitations(ctx context.Context, email string) ([]models.Invitation, error) {
+	const op = "book.Invitations"
+
+	log := b.log.With(slog.String("op", op))
+
+	pending, err := b.participants.Invitations(ctx, normalizeEmail(email), time.Now())
+	if err != nil {
+		log.Error("failed to get invitations", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	invitations := make([]models.Invitation, 0, len(pending))
+
+	for _, participant := range pending {
+		booking, err := b.booker.Booking(ctx, participant.BookingUID)
+		if err != nil {
+			log.Error("failed to get the booking", slog.String("booking_id", participant.BookingUID), sl.Err(err))
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		invitations = append(invitations, models.Invitation{
+			Booking:   booking,
+			InvitedAt: participant.InvitedAt,
+		})
+	}
+
+	return invitations, nil
+}
+
+// normalizeEmail spells a participant email the way it is stored, so that an
+// invitee finds the invitation whatever the case of their account email.
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// ownedBy tells whether the user owns the booking. The email is matched
+// case-insensitively, as when bookings are listed.
+func ownedBy(booking models.Booking, email string) bool {
+	return strings.EqualFold(booking.Email, email)
+}
+
+// upcoming tells whether participants can still join the booking: it has to
+// be active and not started yet.
+func upcoming(booking models.Booking) error {
+	if booking.Status != models.BookingStatusActive {
+		return ErrBookingNotActive
+	}
+
+	if !time.Now().Before(booking.StartsAt) {
+		return ErrBookingStarted
+	}
+
+	return nil
+}
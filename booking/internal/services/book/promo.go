@@ -0,0 +1,127 @@
+package book
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/lib/logger/sl"
+	"booking/internal/storage"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+var (
+	ErrPromoCodeNotFound      = errors.New("promo code not found")
+	ErrPromoCodeExpired       = errors.New("promo code is not valid at this time")
+	ErrPromoCodeNotApplicable = errors.New("promo code does not apply to this booking")
+	ErrPromoCodeExhausted     = errors.New("promo code has been used up")
+)
+
+type PromoStore interface {
+	PromoCode(ctx context.Context, code string) (models.PromoCode, error)
+	PromoUsage(ctx context.Context, code string, email string) (models.PromoUsage, error)
+	RedeemPromoCode(ctx context.Context, promo models.PromoCode, email string, bookingUID string, discount int64) error
+	PromoDiscount(ctx context.Context, bookingUID string) (code string, discount int64, err error)
+}
+
+// applyPromo takes the discount of the promo code off the price of a booking
+// of the box. The code has to be valid now, cover the box and the time of the
+// booking and have redemptions left for the user.
+func (b *Book) applyPromo(ctx context.Context, email string, box models.Box, startsAt time.Time, expiresAt time.Time, code string, price models.Price) (models.PromoCode, models.Price, error) {
+	const op = "book.applyPromo"
+
+	promo, err := b.promos.PromoCode(ctx, strings.TrimSpace(code))
+	if err != nil {
+		if errors.Is(err, storage.ErrPromoCodeNotFound) {
+			return models.PromoCode{}, models.Price{}, fmt.Errorf("%s: %w", op, ErrPromoCodeNotFound)
+		}
+		return models.PromoCode{}, models.Price{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	now := time.Now()
+
+	if (!promo.ValidFrom.IsZero() && now.Before(promo.ValidFrom)) || (!promo.ValidUntil.IsZero() && !now.Before(promo.ValidUntil)) {
+		return models.PromoCode{}, models.Price{}, fmt.Errorf("%s: %w", op, ErrPromoCodeExpired)
+	}
+
+	if promo.BoxName != "" && promo.BoxName != box.Name {
+		return models.PromoCode{}, models.Price{}, fmt.Errorf("%s: %w", op, ErrPromoCodeNotApplicable)
+	}
+
+	covered, err := promoCovers(promo, box, startsAt, expiresAt)
+	if err != nil {
+		return models.PromoCode{}, models.Price{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !covered {
+		return models.PromoCode{}, models.Price{}, fmt.Errorf("%s: %w", op, ErrPromoCodeNotApplicable)
+	}
+
+	if promo.MaxRedemptions > 0 || promo.MaxPerUser > 0 {
+		usage, err := b.promos.PromoUsage(ctx, promo.Code, email)
+		if err != nil {
+			return models.PromoCode{}, models.Price{}, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if (promo.MaxRedemptions > 0 && usage.Total >= promo.MaxRedemptions) || (promo.MaxPerUser > 0 && usage.ByUser >= promo.MaxPerUser) {
+			return models.PromoCode{}, models.Price{}, fmt.Errorf("%s: %w", op, ErrPromoCodeExhausted)
+		}
+	}
+
+	price.PromoCode = promo.Code
+	price.Discount = promo.Discount(price.Total)
+	price.Total -= price.Discount
+
+	return promo, price, nil
+}
+
+// promoCovers tells whether the booking fits between the clock times of the
+// code on the day it starts, local to the box.
+func promoCovers(promo models.PromoCode, box models.Box, startsAt time.Time, expiresAt time.Time) (bool, error) {
+	loc, err := box.Location()
+	if err != nil {
+		return false, err
+	}
+
+	y, m, d := startsAt.In(loc).Date()
+
+	from, err := models.ClockTime(y, m, d, promo.StartsAt, loc)
+	if err != nil {
+		return false, fmt.Errorf("invalid promo code start %q: %w", promo.StartsAt, err)
+	}
+
+	to, err := models.ClockTime(y, m, d, promo.EndsAt, loc)
+	if err != nil {
+		return false, fmt.Errorf("invalid promo code end %q: %w", promo.EndsAt, err)
+	}
+
+	return !startsAt.Before(from) && !expiresAt.After(to), nil
+}
+
+// redeemPromo records the use of the promo code by the reserved booking of
+// the saga. When the code has been used up in the meantime the slot is
+// released again.
+func (b *Book) redeemPromo(ctx context.Context, saga models.Saga, promo models.PromoCode, discount int64) error {
+	const op = "book.redeemPromo"
+
+	log := b.log.With(slog.String("op", op), slog.Int64("saga_id", saga.ID))
+
+	redeemErr := b.promos.RedeemPromoCode(ctx, promo, saga.Email, saga.BookingID, discount)
+	if redeemErr == nil {
+		return nil
+	}
+
+	if err := b.sagas.ReleaseBooking(ctx, saga.ID, models.SagaStateCompensated, redeemErr.Error()); err != nil {
+		log.Error("failed to release the reservation", sl.Err(err))
+	}
+
+	if errors.Is(redeemErr, storage.ErrPromoCodeExhausted) {
+		return fmt.Errorf("%s: %w", op, ErrPromoCodeExhausted)
+	}
+
+	log.Error("failed to redeem the promo code", sl.Err(redeemErr))
+
+	return fmt.Errorf("%s: %w", op, redeemErr)
+}
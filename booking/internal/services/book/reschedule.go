@@ -0,0 +1,290 @@
+package book
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/lib/logger/sl"
+	"booking/internal/storage"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+)
+
+var ErrBookingStarted = errors.New("booking has already started")
+
+type Rescheduler interface {
+	ReserveReschedule(ctx context.Context, booking models.Booking, amount int64) (models.Saga, error)
+	RescheduleBooking(ctx context.Context, booking models.Booking, moved models.Booking, chargeSagaID int64) (models.Saga, error)
+}
+
+// Booking returns the booking with the given opaque or legacy numeric ID.
+func (b *Book) Booking(ctx context.Context, bookingID string) (models.Booking, error) {
+	const op = "book.Booking"
+
+	booking, err := b.booker.Booking(ctx, bookingID)
+	if err != nil {
+		if errors.Is(err, storage.ErrBookingNotFound) {
+			return models.Booking{}, fmt.Errorf("%s: %w", op, ErrBookingNotFound)
+		}
+		b.log.Error("failed to get booking", slog.String("op", op), sl.Err(err))
+		return models.Booking{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return booking, nil
+}
+
+// RescheduleBooking moves a booking that has not started yet to another time
+// or box and settles the price difference: a higher price is charged before
+// the booking is moved and refunded when the move fails, a lower one is
+// refunded after the move. The booking keeps its ID.
+func (b *Book) RescheduleBooking(ctx context.Context, email string, bookingID string, boxName string, startsAt time.Time, duration time.Duration) (booking models.Booking, price models.Price, balance int64, err error) {
+	const op = "book.RescheduleBooking"
+
+	log := b.log.With(slog.String("op", op), slog.String("booking_id", bookingID))
+
+	log.Info("rescheduling booking",
+		slog.String("box", boxName),
+		slog.Time("starts_at", startsAt),
+		slog.Duration("duration", duration))
+
+	booking, err = b.Booking(ctx, bookingID)
+	if err != nil {
+		return models.Booking{}, models.Price{}, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !ownedBy(booking, email) {
+		log.Error("booking belongs to another user")
+		return models.Booking{}, models.Price{}, 0, fmt.Errorf("%s: %w", op, ErrNotYourBooking)
+	}
+
+	if booking.Status != models.BookingStatusActive {
+		log.Error("booking is not active")
+		return models.Booking{}, models.Price{}, 0, fmt.Errorf("%s: %w", op, ErrBookingNotActive)
+	}
+
+	if !time.Now().Before(booking.StartsAt) {
+		log.Error("booking has already started")
+		return models.Booking{}, models.Price{}, 0, fmt.Errorf("%s: %w", op, ErrBookingStarted)
+	}
+
+	box, err := b.Box(ctx, boxName)
+	if err != nil {
+		return models.Booking{}, models.Price{}, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := b.checkSchedule(ctx, box, startsAt, startsAt.Add(duration)); err != nil {
+		log.Error("the box is closed", sl.Err(err))
+		return models.Booking{}, models.Price{}, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	price, err = b.pricer.Quote(ctx, box, startsAt, duration, booking.PeopleAmount)
+	if err != nil {
+		log.Error("failed to calculate the price", sl.Err(err))
+		return models.Booking{}, models.Price{}, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// The booking keeps the discount of its promo code, up to the new price.
+	promoCode, discount, err := b.promos.PromoDiscount(ctx, booking.UID)
+	if err != nil {
+		log.Error("failed to get the promo code discount", sl.Err(err))
+		return models.Booking{}, models.Price{}, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if discount > 0 {
+		price.PromoCode = promoCode
+		price.Discount = min(discount, price.Total)
+		price.Total -= price.Discount
+	}
+
+	moved := booking
+	moved.BoxName = boxName
+	moved.StartsAt = startsAt
+	moved.ExpiresAt = startsAt.Add(duration)
+	moved.PricePaid = price.Total
+
+	difference := moved.PricePaid - booking.PricePaid
+
+	if difference > 0 {
+		balance, err = b.chargeReschedule(ctx, booking, moved, difference)
+	} else {
+		balance, err = b.refundReschedule(ctx, booking, moved)
+	}
+	if err != nil {
+		return models.Booking{}, models.Price{}, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("successfully rescheduled booking", slog.Int64("price_difference", difference))
+
+	b.notify(ctx, models.NotificationRescheduled, booking.UID, difference, strconv.FormatInt(booking.Revision+1, 10))
+
+	b.promoteSlot(ctx, booking.BoxName, booking.StartsAt, booking.ExpiresAt)
+
+	return moved, price, balance, nil
+}
+
+// chargeReschedule charges the price difference and moves the booking. When
+// the booking can't be moved the difference is refunded. A payment with an
+// unknown outcome leaves the saga reserved for the recovery.
+func (b *Book) chargeReschedule(ctx context.Context, booking models.Booking, moved models.Booking, difference int64) (int64, error) {
+	const op = "book.chargeReschedule"
+
+	log := b.log.With(slog.String("op", op))
+
+	saga, err := b.rescheduler.ReserveReschedule(ctx, booking, difference)
+	if err != nil {
+		log.Error("failed to start the reschedule saga", sl.Err(err))
+		return emptyBalanceValue, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log = log.With(slog.Int64("saga_id", saga.ID))
+
+	balance, success, err := b.payments.Pay(ctx, saga.Email, saga.Amount, paymentKey(saga, paymentActionPay))
+	if err != nil && !paymentDeclined(err) {
+		log.Error("payment outcome unknown, leaving the reschedule to the recovery", sl.Err(err))
+		return emptyBalanceValue, fmt.Errorf("%s: %w", op, ErrPaymentPending)
+	}
+	if err != nil || !success {
+		reason := "payment declined"
+		if err != nil {
+			reason = err.Error()
+		}
+
+		log.Error("failed to pay the price difference", slog.String("reason", reason))
+
+		if err := b.sagas.SetSagaState(ctx, saga.ID, models.SagaStateCompensated, reason); err != nil {
+			log.Error("failed to save the saga state", sl.Err(err))
+		}
+
+		return emptyBalanceValue, fmt.Errorf("%s: %w", op, paymentError(err))
+	}
+
+	if err := b.sagas.SetSagaState(ctx, saga.ID, models.SagaStateCharged, ""); err != nil {
+		// The move below closes the saga anyway.
+		log.Error("failed to save the saga state", sl.Err(err))
+	}
+
+	if _, moveErr := b.rescheduler.RescheduleBooking(ctx, booking, moved, saga.ID); moveErr != nil {
+		log.Error("failed to move the booking, refunding", sl.Err(moveErr))
+
+		if err := b.sagas.SetSagaState(ctx, saga.ID, models.SagaStateRefunding, moveErr.Error()); err != nil {
+			log.Error("failed to save the saga state", sl.Err(err))
+		}
+
+		saga.Error = moveErr.Error()
+
+		if _, err := b.finishRescheduleRefund(ctx, saga); err != nil {
+			log.Error("failed to refund, the saga will be retried", sl.Err(err))
+		}
+
+		return emptyBalanceValue, fmt.Errorf("%s: %w", op, rescheduleError(moveErr))
+	}
+
+	return balance, nil
+}
+
+// recoverRescheduleCharge settles the charge of a reschedule saga that was
+// interrupted before payments answered.
+func (b *Book) recoverRescheduleCharge(ctx context.Context, saga models.Saga) error {
+	const op = "book.recoverRescheduleCharge"
+
+	_, success, err := b.payments.Pay(ctx, saga.Email, saga.Amount, paymentKey(saga, paymentActionPay))
+	if err != nil && !paymentDeclined(err) {
+		return fmt.Errorf("%s: %w", op, ErrPaymentPending)
+	}
+
+	if err != nil || !success {
+		reason := "payment declined"
+		if err != nil {
+			reason = err.Error()
+		}
+
+		if err := b.sagas.SetSagaState(ctx, saga.ID, models.SagaStateCompensated, reason); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		return nil
+	}
+
+	saga.Error = "booking was not moved"
+
+	if err := b.sagas.SetSagaState(ctx, saga.ID, models.SagaStateRefunding, saga.Error); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err := b.finishRescheduleRefund(ctx, saga); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// refundReschedule moves the booking and refunds the price difference, if
+// any. A failed refund is retried by the saga recovery.
+func (b *Book) refundReschedule(ctx context.Context, booking models.Booking, moved models.Booking) (int64, error) {
+	const op = "book.refundReschedule"
+
+	log := b.log.With(slog.String("op", op))
+
+	saga, err := b.rescheduler.RescheduleBooking(ctx, booking, moved, 0)
+	if err != nil {
+		log.Error("failed to move the booking", sl.Err(err))
+		return emptyBalanceValue, fmt.Errorf("%s: %w", op, rescheduleError(err))
+	}
+
+	if saga.ID == 0 {
+		balance, err := b.payments.Balance(ctx, booking.Email)
+		if err != nil {
+			log.Warn("failed to get the wallet balance", sl.Err(err))
+			return emptyBalanceValue, nil
+		}
+
+		return balance, nil
+	}
+
+	balance, err := b.finishRescheduleRefund(ctx, saga)
+	if err != nil {
+		// The booking is moved, the refund is retried by the saga recovery.
+		log.Error("failed to refund the price difference", slog.Int64("saga_id", saga.ID), sl.Err(err))
+		return emptyBalanceValue, nil
+	}
+
+	return balance, nil
+}
+
+// finishRescheduleRefund pays the refund of a reschedule saga. A saga with an
+// error is compensating a booking that could not be moved.
+func (b *Book) finishRescheduleRefund(ctx context.Context, saga models.Saga) (int64, error) {
+	const op = "book.finishRescheduleRefund"
+
+	balance, _, err := b.payments.AddFunds(ctx, saga.Email, saga.Amount, paymentKey(saga, paymentActionRefund))
+	if err != nil {
+		return emptyBalanceValue, fmt.Errorf("%s: %w", op, err)
+	}
+
+	state := models.SagaStateCompleted
+	if saga.Error != "" {
+		state = models.SagaStateCompensated
+	}
+
+	if err := b.sagas.RefundSaga(ctx, saga.ID, state, saga.Error); err != nil {
+		return emptyBalanceValue, fmt.Errorf("%s: %w", op, err)
+	}
+
+	b.notify(ctx, models.NotificationRefunded, saga.BookingID, saga.Amount, strconv.FormatInt(saga.ID, 10))
+
+	return balance, nil
+}
+
+// rescheduleError maps the storage errors of a move to service errors.
+func rescheduleError(err error) error {
+	switch {
+	case errors.Is(err, storage.ErrAlreadyBooked):
+		return ErrAlreadyBooked
+	case errors.Is(err, storage.ErrBookingNotActive):
+		return ErrBookingNotActive
+	}
+
+	return err
+}
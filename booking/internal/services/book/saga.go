@@ -0,0 +1,402 @@
+package book
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/lib/logger/sl"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+)
+
+const emptyBalanceValue = -1
+
+type SagaStore interface {
+	SetSagaState(ctx context.Context, sagaID int64, state models.SagaState, reason string) error
+	SetSagaPayment(ctx context.Context, sagaIDs []int64, paymentKey string, amount int64) error
+	ConfirmBooking(ctx context.Context, sagaID int64) error
+	ReleaseBooking(ctx context.Context, sagaID int64, state models.SagaState, reason string) error
+	RefundBooking(ctx context.Context, sagaID int64, reason string) error
+	RefundSaga(ctx context.Context, sagaID int64, state models.SagaState, reason string) error
+	FinishCancel(ctx context.Context, sagaID int64) error
+	AbortCancel(ctx context.Context, sagaID int64, reason string) error
+	StaleSagas(ctx context.Context, before time.Time) ([]models.Saga, error)
+}
+
+// Payments charge and refund once per idempotency key, so a retried call
+// doesn't move the money twice.
+type Payments interface {
+	Pay(ctx context.Context, email string, amount int64, idempotencyKey string) (balance int64, success bool, err error)
+	AddFunds(ctx context.Context, email string, amount int64, idempotencyKey string) (balance int64, success bool, err error)
+	Balance(ctx context.Context, email string) (balance int64, err error)
+}
+
+// Payment actions of a saga, each made once.
+const (
+	paymentActionPay    = "pay"
+	paymentActionRefund = "refund"
+)
+
+// paymentKey is the idempotency key of a payment action of the saga. The
+// booking UID keeps the keys unique when saga IDs start over in a new database.
+func paymentKey(saga models.Saga, action string) string {
+	return fmt.Sprintf("booking:%s:saga:%d:%s", saga.BookingID, saga.ID, action)
+}
+
+// sagaPayment returns the idempotency key and the amount the saga is charged
+// with: its own or the ones of the payment it shares with other sagas.
+func sagaPayment(saga models.Saga) (string, int64) {
+	if saga.PaymentKey != "" {
+		return saga.PaymentKey, saga.PaymentAmount
+	}
+
+	return paymentKey(saga, paymentActionPay), saga.Amount
+}
+
+// pendingSaga is the error of a saga left to the recovery because the outcome
+// of its payment is unknown. It wraps ErrPaymentPending or ErrRefundPending.
+type pendingSaga struct {
+	sagaID int64
+	err    error
+}
+
+func (e *pendingSaga) Error() string {
+	return e.err.Error()
+}
+
+func (e *pendingSaga) Unwrap() error {
+	return e.err
+}
+
+// declinedPayments are the errors payments answers with when it did not move
+// the money.
+var declinedPayments = []string{
+	ErrNotEnoughFunds.Error(),
+	ErrCardNotFound.Error(),
+	"invalid amount",
+	"email is required",
+}
+
+// paymentDeclined tells whether a payments error is a definite answer that
+// the money was not moved. Any other error, like a timeout or an unreachable
+// service, leaves the outcome unknown: the call may have gone through.
+func paymentDeclined(err error) bool {
+	for _, declined := range declinedPayments {
+		if err.Error() == declined {
+			return true
+		}
+	}
+
+	return false
+}
+
+// charge takes the price of a reserved booking from the wallet. When the
+// payment is declined the slot is released again. When its outcome is unknown
+// the saga stays reserved for the recovery to settle. A charge repeated by the
+// recovery gets the outcome of the first one by its idempotency key.
+func (b *Book) charge(ctx context.Context, saga models.Saga) (int64, error) {
+	const op = "book.charge"
+
+	log := b.log.With(slog.String("op", op), slog.Int64("saga_id", saga.ID))
+
+	key, amount := sagaPayment(saga)
+
+	balance, success, err := b.payments.Pay(ctx, saga.Email, amount, key)
+	if err != nil && !paymentDeclined(err) {
+		log.Error("payment outcome unknown, leaving the reservation to the recovery", sl.Err(err))
+		return emptyBalanceValue, fmt.Errorf("%s: %w", op, &pendingSaga{sagaID: saga.ID, err: ErrPaymentPending})
+	}
+	if err != nil || !success {
+		reason := "payment declined"
+		if err != nil {
+			reason = err.Error()
+		}
+
+		log.Error("failed to pay for the booking", slog.String("reason", reason))
+
+		if err := b.sagas.ReleaseBooking(ctx, saga.ID, models.SagaStateCompensated, reason); err != nil {
+			log.Error("failed to release the reservation", sl.Err(err))
+		}
+
+		return emptyBalanceValue, fmt.Errorf("%s: %w", op, paymentError(err))
+	}
+
+	if err := b.sagas.SetSagaState(ctx, saga.ID, models.SagaStateCharged, ""); err != nil {
+		// The confirmation below closes the saga anyway.
+		log.Error("failed to save the saga state", sl.Err(err))
+	}
+
+	return balance, nil
+}
+
+// chargeAll takes the price of several reserved bookings from the wallet in
+// one payment. When the payment is declined all the slots are released again,
+// when its outcome is unknown the sagas stay reserved.
+func (b *Book) chargeAll(ctx context.Context, sagas []models.Saga) (int64, error) {
+	const op = "book.chargeAll"
+
+	log := b.log.With(slog.String("op", op))
+
+	var total int64
+	ids := make([]int64, 0, len(sagas))
+	for _, saga := range sagas {
+		total += saga.Amount
+		ids = append(ids, saga.ID)
+	}
+
+	key := paymentKey(sagas[0], paymentActionPay)
+
+	// The recovery repeats the shared payment for any of the sagas left reserved.
+	if err := b.sagas.SetSagaPayment(ctx, ids, key, total); err != nil {
+		log.Error("failed to save the payment of the sagas", sl.Err(err))
+
+		for _, saga := range sagas {
+			if err := b.sagas.ReleaseBooking(ctx, saga.ID, models.SagaStateCompensated, err.Error()); err != nil {
+				log.Error("failed to release the reservation", slog.Int64("saga_id", saga.ID), sl.Err(err))
+			}
+		}
+
+		return emptyBalanceValue, fmt.Errorf("%s: %w", op, ErrPaymentFailed)
+	}
+
+	balance, success, err := b.payments.Pay(ctx, sagas[0].Email, total, key)
+	if err != nil && !paymentDeclined(err) {
+		log.Error("payment outcome unknown, leaving the reservations to the recovery", sl.Err(err))
+		return emptyBalanceValue, fmt.Errorf("%s: %w", op, ErrPaymentPending)
+	}
+	if err != nil || !success {
+		reason := "payment declined"
+		if err != nil {
+			reason = err.Error()
+		}
+
+		log.Error("failed to pay for the bookings", slog.String("reason", reason))
+
+		for _, saga := range sagas {
+			if err := b.sagas.ReleaseBooking(ctx, saga.ID, models.SagaStateCompensated, reason); err != nil {
+				log.Error("failed to release the reservation", slog.Int64("saga_id", saga.ID), sl.Err(err))
+			}
+		}
+
+		return emptyBalanceValue, fmt.Errorf("%s: %w", op, paymentError(err))
+	}
+
+	for _, saga := range sagas {
+		if err := b.sagas.SetSagaState(ctx, saga.ID, models.SagaStateCharged, ""); err != nil {
+			log.Error("failed to save the saga state", slog.Int64("saga_id", saga.ID), sl.Err(err))
+		}
+	}
+
+	return balance, nil
+}
+
+// confirm activates a charged booking. When that fails the charge is refunded.
+func (b *Book) confirm(ctx context.Context, saga models.Saga) error {
+	const op = "book.confirm"
+
+	log := b.log.With(slog.String("op", op), slog.Int64("saga_id", saga.ID))
+
+	confirmErr := b.sagas.ConfirmBooking(ctx, saga.ID)
+	if confirmErr == nil {
+		b.notify(ctx, models.NotificationBooked, saga.BookingID, saga.Amount, "")
+		return nil
+	}
+
+	log.Error("failed to confirm the booking, refunding", sl.Err(confirmErr))
+
+	if err := b.sagas.SetSagaState(ctx, saga.ID, models.SagaStateRefunding, confirmErr.Error()); err != nil {
+		log.Error("failed to save the saga state", sl.Err(err))
+	}
+
+	if err := b.refundBooking(ctx, saga, confirmErr.Error()); err != nil {
+		log.Error("failed to refund, the saga will be retried", sl.Err(err))
+	}
+
+	return fmt.Errorf("%s: %w", op, confirmErr)
+}
+
+// refundBooking gives the money of a booking that could not be confirmed back
+// and releases its slot.
+func (b *Book) refundBooking(ctx context.Context, saga models.Saga, reason string) error {
+	const op = "book.refundBooking"
+
+	if _, _, err := b.payments.AddFunds(ctx, saga.Email, saga.Amount, paymentKey(saga, paymentActionRefund)); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := b.sagas.RefundBooking(ctx, saga.ID, reason); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	b.notify(ctx, models.NotificationRefunded, saga.BookingID, saga.Amount, strconv.FormatInt(saga.ID, 10))
+
+	return nil
+}
+
+// refundCancel pays the refund of a cancellation and marks the booking as
+// cancelled. When payments rejects the refund the booking becomes active
+// again, when its outcome is unknown the saga stays refunding and the
+// recovery retries it.
+func (b *Book) refundCancel(ctx context.Context, saga models.Saga) (int64, error) {
+	const op = "book.refundCancel"
+
+	log := b.log.With(slog.String("op", op), slog.Int64("saga_id", saga.ID))
+
+	balance := int64(emptyBalanceValue)
+
+	if saga.Amount > 0 {
+		var (
+			success   bool
+			refundErr error
+		)
+
+		balance, success, refundErr = b.payments.AddFunds(ctx, saga.Email, saga.Amount, paymentKey(saga, paymentActionRefund))
+		if refundErr != nil && !paymentDeclined(refundErr) {
+			log.Error("refund outcome unknown, leaving it to the recovery", sl.Err(refundErr))
+			return emptyBalanceValue, fmt.Errorf("%s: %w", op, &pendingSaga{sagaID: saga.ID, err: ErrRefundPending})
+		}
+		if refundErr == nil && !success {
+			refundErr = errors.New("refund declined")
+		}
+		if refundErr != nil {
+			log.Error("failed to refund, keeping the booking", sl.Err(refundErr))
+
+			if err := b.sagas.AbortCancel(ctx, saga.ID, refundErr.Error()); err != nil {
+				log.Error("failed to restore the booking", sl.Err(err))
+			}
+
+			return emptyBalanceValue, fmt.Errorf("%s: %w", op, ErrRefundFailed)
+		}
+	}
+
+	if err := b.sagas.RefundSaga(ctx, saga.ID, models.SagaStateRefunded, ""); err != nil {
+		log.Error("failed to save the saga state", sl.Err(err))
+	}
+
+	if err := b.finishCancel(ctx, saga); err != nil {
+		// The money is back, the saga is finished by the recovery worker.
+		log.Error("failed to finish the cancellation", sl.Err(err))
+	}
+
+	return balance, nil
+}
+
+// finishCancel marks the booking of a refunded cancellation as cancelled.
+func (b *Book) finishCancel(ctx context.Context, saga models.Saga) error {
+	const op = "book.finishCancel"
+
+	if err := b.sagas.FinishCancel(ctx, saga.ID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	b.notify(ctx, models.NotificationCancelled, saga.BookingID, saga.Amount, "")
+
+	return nil
+}
+
+// RecoverSagas resumes or rolls back the sagas that stopped moving, e.g.
+// because the service was restarted in the middle of a booking.
+func (b *Book) RecoverSagas(ctx context.Context, staleAfter time.Duration) error {
+	const op = "book.RecoverSagas"
+
+	log := b.log.With(slog.String("op", op))
+
+	sagas, err := b.sagas.StaleSagas(ctx, time.Now().Add(-staleAfter))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, saga := range sagas {
+		log := log.With(
+			slog.Int64("saga_id", saga.ID),
+			slog.String("kind", string(saga.Kind)),
+			slog.String("state", string(saga.State)))
+
+		var err error
+
+		switch {
+		case saga.Kind == models.SagaKindBook && saga.State == models.SagaStateReserved:
+			// Payments may or may not have charged the wallet. The charge is
+			// repeated with the same idempotency key, which returns the outcome
+			// of the first one, and the booking is confirmed or released by it.
+			if _, err = b.charge(ctx, saga); err == nil {
+				err = b.confirm(ctx, saga)
+			}
+		case saga.Kind == models.SagaKindBook && saga.State == models.SagaStateCharged:
+			err = b.confirm(ctx, saga)
+		case saga.Kind == models.SagaKindBook && saga.State == models.SagaStateRefunding:
+			err = b.refundBooking(ctx, saga, saga.Error)
+		case saga.Kind == models.SagaKindCancel && saga.State == models.SagaStateRefunding:
+			_, err = b.refundCancel(ctx, saga)
+		case saga.Kind == models.SagaKindCancel && saga.State == models.SagaStateRefunded:
+			err = b.finishCancel(ctx, saga)
+		case saga.Kind == models.SagaKindReschedule && saga.State == models.SagaStateReserved:
+			// As with a booking, the charge of the price difference is repeated
+			// with the same key. The booking has not been moved, so a charge that
+			// went through is refunded.
+			err = b.recoverRescheduleCharge(ctx, saga)
+		case saga.Kind == models.SagaKindReschedule && saga.State == models.SagaStateCharged:
+			// The move completes the saga, so a charged one was never moved.
+			saga.Error = "booking was not moved"
+			if err = b.sagas.SetSagaState(ctx, saga.ID, models.SagaStateRefunding, saga.Error); err == nil {
+				_, err = b.finishRescheduleRefund(ctx, saga)
+			}
+		case saga.Kind == models.SagaKindReschedule && saga.State == models.SagaStateRefunding:
+			_, err = b.finishRescheduleRefund(ctx, saga)
+		default:
+			log.Warn("unexpected saga state")
+			continue
+		}
+
+		if err != nil {
+			log.Error("failed to recover saga", sl.Err(err))
+			continue
+		}
+
+		log.Info("saga recovered")
+	}
+
+	return nil
+}
+
+func (b *Book) StartSagaRecovery(ctx context.Context, interval time.Duration, staleAfter time.Duration) <-chan error {
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(errCh)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := b.RecoverSagas(ctx, staleAfter); err != nil {
+					errCh <- err
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return errCh
+}
+
+// paymentError maps the error messages of the payments client to service errors.
+func paymentError(err error) error {
+	if err == nil {
+		return ErrPaymentFailed
+	}
+
+	switch err.Error() {
+	case ErrNotEnoughFunds.Error():
+		return ErrNotEnoughFunds
+	case ErrCardNotFound.Error():
+		return ErrCardNotFound
+	}
+
+	return ErrPaymentFailed
+}
@@ -0,0 +1,268 @@
+package book
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/lib/logger/sl"
+	"booking/internal/storage"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+var (
+	ErrOutsideOpeningHours = errors.New("the box is closed at this time")
+	ErrMaintenance         = errors.New("the box is closed for maintenance at this time")
+	ErrInvalidSchedule     = errors.New("invalid schedule")
+	ErrClosureNotFound     = errors.New("closure not found")
+	ErrBlackoutNotFound    = errors.New("blackout not found")
+)
+
+type ScheduleStore interface {
+	WeeklyHours(ctx context.Context, boxName string) ([]models.WeeklyHours, error)
+	SetWeeklyHours(ctx context.Context, boxName string, hours []models.WeeklyHours) error
+	Closure(ctx context.Context, boxName string, date string) (models.Closure, error)
+	Closures(ctx context.Context, boxName string, from string, to string) ([]models.Closure, error)
+	AddClosure(ctx context.Context, closure models.Closure) (models.Closure, error)
+	RemoveClosure(ctx context.Context, closureUID string) error
+	Blackouts(ctx context.Context, boxName string, from time.Time, to time.Time) ([]models.Blackout, error)
+	AddBlackout(ctx context.Context, blackout models.Blackout) (models.Blackout, error)
+	RemoveBlackout(ctx context.Context, blackoutUID string) error
+}
+
+// openingHours returns the opening and closing moments of the box on the
+// calendar day of date. A closure shuts the box for the day, weekly hours win
+// over the daily hours of the box. A closed day opens and closes at midnight.
+func (b *Book) openingHours(ctx context.Context, box models.Box, date time.Time) (time.Time, time.Time, error) {
+	loc, err := box.Location()
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	local := date.In(loc)
+	y, m, d := local.Date()
+	midnight := time.Date(y, m, d, 0, 0, 0, 0, loc)
+
+	_, err = b.schedule.Closure(ctx, box.Name, local.Format(dateLayout))
+	if err == nil {
+		return midnight, midnight, nil
+	}
+	if !errors.Is(err, storage.ErrClosureNotFound) {
+		return time.Time{}, time.Time{}, err
+	}
+
+	weekly, err := b.schedule.WeeklyHours(ctx, box.Name)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	for _, hours := range weekly {
+		if hours.Weekday != local.Weekday() {
+			continue
+		}
+
+		if hours.Closed {
+			return midnight, midnight, nil
+		}
+
+		opens, err := models.ClockTime(y, m, d, hours.OpensAt, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid opening time %q: %w", hours.OpensAt, err)
+		}
+
+		closes, err := models.ClockTime(y, m, d, hours.ClosesAt, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid closing time %q: %w", hours.ClosesAt, err)
+		}
+
+		return opens, closes, nil
+	}
+
+	return box.OpeningHours(date)
+}
+
+// checkSchedule tells whether the box is open for the whole slot. A slot may
+// run past midnight if the box is open through it.
+func (b *Book) checkSchedule(ctx context.Context, box models.Box, startsAt time.Time, expiresAt time.Time) error {
+	const op = "book.checkSchedule"
+
+	for cursor := startsAt; cursor.Before(expiresAt); {
+		opens, closes, err := b.openingHours(ctx, box, cursor)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		if cursor.Before(opens) || !cursor.Before(closes) {
+			return fmt.Errorf("%s: %w", op, ErrOutsideOpeningHours)
+		}
+
+		cursor = closes
+	}
+
+	blackouts, err := b.schedule.Blackouts(ctx, box.Name, startsAt, expiresAt)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(blackouts) > 0 {
+		return fmt.Errorf("%s: %w", op, ErrMaintenance)
+	}
+
+	return nil
+}
+
+// Schedule returns the weekly hours of the box and its closures and
+// blackouts within [from, to).
+func (b *Book) Schedule(ctx context.Context, boxName string, from time.Time, to time.Time) (models.Schedule, error) {
+	const op = "book.Schedule"
+
+	log := b.log.With(slog.String("op", op), slog.String("box", boxName))
+
+	box, err := b.Box(ctx, boxName)
+	if err != nil {
+		return models.Schedule{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	loc, err := box.Location()
+	if err != nil {
+		return models.Schedule{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	schedule := models.Schedule{Box: box}
+
+	schedule.Weekly, err = b.schedule.WeeklyHours(ctx, boxName)
+	if err != nil {
+		log.Error("failed to get the weekly hours", sl.Err(err))
+		return models.Schedule{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	schedule.Closures, err = b.schedule.Closures(ctx, boxName, from.In(loc).Format(dateLayout), to.Add(-time.Nanosecond).In(loc).Format(dateLayout))
+	if err != nil {
+		log.Error("failed to get the closures", sl.Err(err))
+		return models.Schedule{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	schedule.Blackouts, err = b.schedule.Blackouts(ctx, boxName, from, to)
+	if err != nil {
+		log.Error("failed to get the blackouts", sl.Err(err))
+		return models.Schedule{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return schedule, nil
+}
+
+// SetOpeningHours replaces the weekly hours of the box. Bookings made before
+// are kept.
+func (b *Book) SetOpeningHours(ctx context.Context, boxName string, hours []models.WeeklyHours) error {
+	const op = "book.SetOpeningHours"
+
+	if _, err := b.Box(ctx, boxName); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	seen := make(map[time.Weekday]bool, len(hours))
+
+	for _, h := range hours {
+		if h.Weekday < time.Sunday || h.Weekday > time.Saturday || seen[h.Weekday] {
+			return fmt.Errorf("%s: %w", op, ErrInvalidSchedule)
+		}
+		seen[h.Weekday] = true
+
+		if h.Closed {
+			continue
+		}
+
+		opens, err := models.ClockTime(2000, time.January, 1, h.OpensAt, time.UTC)
+		if err != nil || h.OpensAt == models.EndOfDay {
+			return fmt.Errorf("%s: %w", op, ErrInvalidSchedule)
+		}
+
+		closes, err := models.ClockTime(2000, time.January, 1, h.ClosesAt, time.UTC)
+		if err != nil || !closes.After(opens) {
+			return fmt.Errorf("%s: %w", op, ErrInvalidSchedule)
+		}
+	}
+
+	if err := b.schedule.SetWeeklyHours(ctx, boxName, hours); err != nil {
+		b.log.Error("failed to save the opening hours", slog.String("op", op), sl.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// AddClosure closes a box, or every box when the box name is empty, for a day.
+func (b *Book) AddClosure(ctx context.Context, closure models.Closure) (models.Closure, error) {
+	const op = "book.AddClosure"
+
+	if closure.BoxName != "" {
+		if _, err := b.Box(ctx, closure.BoxName); err != nil {
+			return models.Closure{}, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if _, err := time.Parse(dateLayout, closure.Date); err != nil || !closure.Kind.Valid() {
+		return models.Closure{}, fmt.Errorf("%s: %w", op, ErrInvalidSchedule)
+	}
+
+	closure, err := b.schedule.AddClosure(ctx, closure)
+	if err != nil {
+		b.log.Error("failed to save the closure", slog.String("op", op), sl.Err(err))
+		return models.Closure{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return closure, nil
+}
+
+func (b *Book) RemoveClosure(ctx context.Context, closureUID string) error {
+	const op = "book.RemoveClosure"
+
+	if err := b.schedule.RemoveClosure(ctx, closureUID); err != nil {
+		if errors.Is(err, storage.ErrClosureNotFound) {
+			return fmt.Errorf("%s: %w", op, ErrClosureNotFound)
+		}
+		b.log.Error("failed to remove the closure", slog.String("op", op), sl.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// AddBlackout takes a box out of service for maintenance. Bookings already
+// made for that time are kept, operators cancel them if needed.
+func (b *Book) AddBlackout(ctx context.Context, blackout models.Blackout) (models.Blackout, error) {
+	const op = "book.AddBlackout"
+
+	if _, err := b.Box(ctx, blackout.BoxName); err != nil {
+		return models.Blackout{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !blackout.EndsAt.After(blackout.StartsAt) {
+		return models.Blackout{}, fmt.Errorf("%s: %w", op, ErrInvalidSchedule)
+	}
+
+	blackout, err := b.schedule.AddBlackout(ctx, blackout)
+	if err != nil {
+		b.log.Error("failed to save the blackout", slog.String("op", op), sl.Err(err))
+		return models.Blackout{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return blackout, nil
+}
+
+func (b *Book) RemoveBlackout(ctx context.Context, blackoutUID string) error {
+	const op = "book.RemoveBlackout"
+
+	if err := b.schedule.RemoveBlackout(ctx, blackoutUID); err != nil {
+		if errors.Is(err, storage.ErrBlackoutNotFound) {
+			return fmt.Errorf("%s: %w", op, ErrBlackoutNotFound)
+		}
+		b.log.Error("failed to remove the blackout", slog.String("op", op), sl.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
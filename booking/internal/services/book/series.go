@@ -0,0 +1,270 @@
+package book
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/lib/logger/sl"
+	"booking/internal/lib/rrule"
+	"booking/internal/storage"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// maxSeriesOccurrences caps a series at about a year of weekly bookings.
+const maxSeriesOccurrences = 52
+
+var ErrSeriesNotFound = errors.New("series not found")
+
+type SeriesStore interface {
+	CreateSeries(ctx context.Context, series models.Series) (models.Series, error)
+	Series(ctx context.Context, seriesUID string) (models.Series, error)
+	BookOccurrence(ctx context.Context, seriesUID string, email string, boxName string, startsAt time.Time, expiresAt time.Time, peopleAmount int64, pricePaid int64) (models.Saga, error)
+	SeriesBookings(ctx context.Context, seriesUID string) ([]models.Booking, error)
+}
+
+// BookSeries books every occurrence of a recurring series starting at startsAt.
+// Occurrences that clash with other bookings are skipped and reported, the
+// rest are paid for either one by one or together, depending on payment.
+func (b *Book) BookSeries(ctx context.Context, email string, boxName string, startsAt time.Time, duration time.Duration, peopleAmount int64, rule string, payment models.SeriesPayment) (series models.Series, occurrences []models.Occurrence, balance int64, err error) {
+	const op = "book.BookSeries"
+
+	log := b.log.With(slog.String("op", op))
+
+	log.Info("booking a series",
+		slog.String("box", boxName),
+		slog.Time("starts_at", startsAt),
+		slog.String("rule", rule),
+		slog.String("payment", string(payment)))
+
+	box, err := b.Box(ctx, boxName)
+	if err != nil {
+		return models.Series{}, nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	loc, err := box.Location()
+	if err != nil {
+		return models.Series{}, nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	recurrence, err := rrule.Parse(rule, loc)
+	if err != nil {
+		return models.Series{}, nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	starts, err := recurrence.Occurrences(startsAt.In(loc), maxSeriesOccurrences)
+	if err != nil {
+		return models.Series{}, nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(starts) == 0 {
+		return models.Series{}, nil, 0, fmt.Errorf("%s: %w", op, rrule.ErrInvalidRule)
+	}
+
+	series, err = b.series.CreateSeries(ctx, models.Series{
+		Email:        email,
+		BoxName:      boxName,
+		Rule:         recurrence.String(),
+		Payment:      payment,
+		StartsAt:     startsAt,
+		Duration:     duration,
+		PeopleAmount: peopleAmount,
+	})
+	if err != nil {
+		log.Error("failed to create the series", sl.Err(err))
+		return models.Series{}, nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	occurrences = make([]models.Occurrence, len(starts))
+	sagas := make(map[int]models.Saga, len(starts))
+
+	for i, start := range starts {
+		occurrences[i] = models.Occurrence{StartsAt: start, ExpiresAt: start.Add(duration)}
+
+		if err := b.checkSchedule(ctx, box, start, start.Add(duration)); err != nil {
+			for _, closedErr := range []error{ErrOutsideOpeningHours, ErrMaintenance} {
+				if errors.Is(err, closedErr) {
+					occurrences[i].Status, occurrences[i].Error = models.OccurrenceConflict, closedErr.Error()
+				}
+			}
+			if occurrences[i].Status == "" {
+				log.Error("failed to check the schedule", sl.Err(err))
+				occurrences[i].Status, occurrences[i].Error = models.OccurrenceFailed, "failed to book a box"
+			}
+			continue
+		}
+
+		price, err := b.pricer.Quote(ctx, box, start, duration, peopleAmount)
+		if err != nil {
+			log.Error("failed to calculate the price", sl.Err(err))
+			occurrences[i].Status, occurrences[i].Error = models.OccurrenceFailed, "failed to calculate the price"
+			continue
+		}
+		occurrences[i].Price = price
+
+		saga, err := b.series.BookOccurrence(ctx, series.UID, email, boxName, start, start.Add(duration), peopleAmount, price.Total)
+		if err != nil {
+			if errors.Is(err, storage.ErrAlreadyBooked) {
+				occurrences[i].Status, occurrences[i].Error = models.OccurrenceConflict, ErrAlreadyBooked.Error()
+				continue
+			}
+			log.Error("failed to book an occurrence", sl.Err(err))
+			occurrences[i].Status, occurrences[i].Error = models.OccurrenceFailed, "failed to book a box"
+			continue
+		}
+
+		sagas[i] = saga
+	}
+
+	balance = emptyBalanceValue
+
+	if payment == models.SeriesPaymentUpfront {
+		reserved := make([]models.Saga, 0, len(sagas))
+		for i := range starts {
+			if saga, ok := sagas[i]; ok {
+				reserved = append(reserved, saga)
+			}
+		}
+
+		if len(reserved) > 0 {
+			balance, err = b.chargeAll(ctx, reserved)
+			if err != nil {
+				for i, saga := range sagas {
+					occurrences[i].Status, occurrences[i].Error = paymentOutcome(err), errors.Unwrap(err).Error()
+					if errors.Is(err, ErrPaymentPending) {
+						occurrences[i].BookingUID = saga.BookingID
+					}
+				}
+				clear(sagas)
+			}
+		}
+	}
+
+	for i := range starts {
+		saga, ok := sagas[i]
+		if !ok {
+			continue
+		}
+
+		if payment == models.SeriesPaymentPerOccurrence {
+			paid, err := b.charge(ctx, saga)
+			if err != nil {
+				occurrences[i].Status, occurrences[i].Error = paymentOutcome(err), errors.Unwrap(err).Error()
+				if errors.Is(err, ErrPaymentPending) {
+					occurrences[i].BookingUID = saga.BookingID
+				}
+				continue
+			}
+			balance = paid
+		}
+
+		if err := b.confirm(ctx, saga); err != nil {
+			occurrences[i].Status, occurrences[i].Error = models.OccurrenceFailed, "failed to book a box"
+			continue
+		}
+
+		occurrences[i].Status = models.OccurrenceBooked
+		occurrences[i].BookingUID = saga.BookingID
+	}
+
+	log.Info("series booked",
+		slog.String("series_id", series.UID),
+		slog.Int("occurrences", len(occurrences)),
+		slog.Int("booked", countBooked(occurrences)))
+
+	return series, occurrences, balance, nil
+}
+
+// CancelSeries cancels every booking of the series that has not ended yet.
+// Each booking is refunded by the refund policy on its own.
+func (b *Book) CancelSeries(ctx context.Context, email string, seriesUID string, initiator models.CancelInitiator) (cancellations []models.SeriesCancellation, balance int64, err error) {
+	const op = "book.CancelSeries"
+
+	log := b.log.With(slog.String("op", op), slog.String("series_id", seriesUID))
+
+	series, err := b.series.Series(ctx, seriesUID)
+	if err != nil {
+		if errors.Is(err, storage.ErrSeriesNotFound) {
+			log.Error("series not found")
+			return nil, 0, fmt.Errorf("%s: %w", op, ErrSeriesNotFound)
+		}
+		log.Error("failed to get the series", sl.Err(err))
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if initiator != models.CancelByOperator && series.Email != email {
+		log.Error("series belongs to another user")
+		return nil, 0, fmt.Errorf("%s: %w", op, ErrNotYourBooking)
+	}
+
+	bookings, err := b.series.SeriesBookings(ctx, seriesUID)
+	if err != nil {
+		log.Error("failed to get the series bookings", sl.Err(err))
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	balance = emptyBalanceValue
+	now := time.Now()
+
+	for _, booking := range bookings {
+		if booking.Status != models.BookingStatusActive || !now.Before(booking.ExpiresAt) {
+			continue
+		}
+
+		cancellation := models.SeriesCancellation{
+			BookingUID: booking.UID,
+			StartsAt:   booking.StartsAt,
+		}
+
+		refund, paid, err := b.cancelBooking(ctx, series.Email, booking.UID, initiator)
+		if err != nil {
+			cancellation.Error = cancelError(err).Error()
+		} else {
+			cancellation.Refund = refund
+			if paid != emptyBalanceValue {
+				balance = paid
+			}
+		}
+
+		cancellations = append(cancellations, cancellation)
+	}
+
+	if len(cancellations) == 0 {
+		return nil, 0, fmt.Errorf("%s: %w", op, ErrBookingNotActive)
+	}
+
+	return cancellations, balance, nil
+}
+
+// cancelError hides the internal errors of a failed cancellation.
+func cancelError(err error) error {
+	for _, known := range []error{ErrBookingNotActive, ErrRefundFailed, ErrRefundPending} {
+		if errors.Is(err, known) {
+			return known
+		}
+	}
+
+	return errors.New("failed to cancel booking")
+}
+
+// paymentOutcome is the status of an occurrence whose charge did not go
+// through: a pending one may still be booked by the saga recovery.
+func paymentOutcome(err error) models.OccurrenceStatus {
+	if errors.Is(err, ErrPaymentPending) {
+		return models.OccurrencePaymentPending
+	}
+
+	return models.OccurrencePaymentFailed
+}
+
+func countBooked(occurrences []models.Occurrence) int {
+	booked := 0
+	for _, occurrence := range occurrences {
+		if occurrence.Status == models.OccurrenceBooked {
+			booked++
+		}
+	}
+
+	return booked
+}
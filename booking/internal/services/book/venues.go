@@ -0,0 +1,113 @@
+package book
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/lib/geo"
+	"booking/internal/lib/logger/sl"
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+)
+
+type VenueStore interface {
+	Venues(ctx context.Context, bounds geo.Bounds) ([]models.Venue, error)
+}
+
+// NearbyVenues returns up to limit venues within radiusKm of the center, the
+// nearest first, with the availability of their open boxes today, local to
+// each box. Slots of the summary are slot long.
+func (b *Book) NearbyVenues(ctx context.Context, center geo.Point, radiusKm float64, limit int, slot time.Duration) ([]models.NearbyVenue, error) {
+	const op = "book.NearbyVenues"
+
+	log := b.log.With(slog.String("op", op))
+
+	venues, err := b.venues.Venues(ctx, geo.Around(center, radiusKm))
+	if err != nil {
+		log.Error("failed to get venues", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var nearby []models.NearbyVenue
+
+	for _, venue := range venues {
+		distance := geo.DistanceKm(center, geo.Point{Latitude: venue.Latitude, Longitude: venue.Longitude})
+		if distance > radiusKm {
+			continue
+		}
+
+		nearby = append(nearby, models.NearbyVenue{Venue: venue, DistanceKm: distance})
+	}
+
+	sort.SliceStable(nearby, func(i, j int) bool {
+		return nearby[i].DistanceKm < nearby[j].DistanceKm
+	})
+
+	if len(nearby) > limit {
+		nearby = nearby[:limit]
+	}
+
+	if len(nearby) == 0 {
+		return nearby, nil
+	}
+
+	boxes, err := b.boxProvider.Boxes(ctx, false)
+	if err != nil {
+		log.Error("failed to get boxes", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	byVenue := make(map[int64][]models.Box)
+	for _, box := range boxes {
+		byVenue[box.VenueID] = append(byVenue[box.VenueID], box)
+	}
+
+	for i := range nearby {
+		nearby[i].Boxes = make([]models.BoxDaySummary, 0, len(byVenue[nearby[i].Venue.ID]))
+
+		for _, box := range byVenue[nearby[i].Venue.ID] {
+			summary, err := b.daySummary(ctx, box, slot)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+
+			nearby[i].Boxes = append(nearby[i].Boxes, summary)
+		}
+	}
+
+	return nearby, nil
+}
+
+// daySummary sums up the availability of the box today, from now on.
+func (b *Book) daySummary(ctx context.Context, box models.Box, slot time.Duration) (models.BoxDaySummary, error) {
+	loc, err := box.Location()
+	if err != nil {
+		return models.BoxDaySummary{}, err
+	}
+
+	now := time.Now().In(loc)
+
+	availability, err := b.Availability(ctx, box.Name, now, slot)
+	if err != nil {
+		return models.BoxDaySummary{}, err
+	}
+
+	summary := models.BoxDaySummary{
+		Box:       box,
+		Date:      time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc),
+		OpensAt:   availability.OpensAt,
+		ClosesAt:  availability.ClosesAt,
+		FreeSlots: int64(len(availability.Slots)),
+	}
+
+	for _, in := range availability.Free {
+		summary.FreeMinutes += int64(in.ExpiresAt.Sub(in.StartsAt) / time.Minute)
+	}
+
+	if len(availability.Slots) > 0 {
+		summary.NextFree = availability.Slots[0].StartsAt
+	}
+
+	return summary, nil
+}
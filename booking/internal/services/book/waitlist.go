@@ -0,0 +1,324 @@
+package book
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/lib/logger/sl"
+	"booking/internal/storage"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+var (
+	ErrWaitlistEntryNotFound = errors.New("waitlist entry not found")
+	ErrNotYourWaitlistEntry  = errors.New("this waitlist entry belongs to another user")
+	ErrSlotFree              = errors.New("the slot is free, book it instead")
+	ErrNoOffer               = errors.New("there is no offer for this waitlist entry")
+	ErrOfferExpired          = errors.New("waitlist offer has expired")
+)
+
+type WaitlistStore interface {
+	JoinWaitlist(ctx context.Context, entry models.WaitlistEntry) (models.WaitlistEntry, error)
+	WaitlistEntry(ctx context.Context, entryUID string) (models.WaitlistEntry, error)
+	Waitlist(ctx context.Context, email string) ([]models.WaitlistEntry, error)
+	WaitingEntries(ctx context.Context) ([]models.WaitlistEntry, error)
+	OfferSlot(ctx context.Context, entry models.WaitlistEntry, heldUntil time.Time) (string, error)
+	AcceptOffer(ctx context.Context, entry models.WaitlistEntry, pricePaid int64) (models.Saga, error)
+	SetWaitlistStatus(ctx context.Context, entryID int64, status models.WaitlistStatus, bookingUID string, reason string) error
+	LeaveWaitlist(ctx context.Context, entry models.WaitlistEntry) error
+	ExpireWaitlist(ctx context.Context, now time.Time) (int64, error)
+}
+
+// JoinWaitlist puts the user in the queue for a slot that is taken now.
+func (b *Book) JoinWaitlist(ctx context.Context, email string, boxName string, startsAt time.Time, duration time.Duration, peopleAmount int64, mode models.WaitlistMode) (models.WaitlistEntry, error) {
+	const op = "book.JoinWaitlist"
+
+	log := b.log.With(slog.String("op", op), slog.String("box", boxName))
+
+	box, err := b.Box(ctx, boxName)
+	if err != nil {
+		return models.WaitlistEntry{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := b.checkSchedule(ctx, box, startsAt, startsAt.Add(duration)); err != nil {
+		return models.WaitlistEntry{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	free, err := b.booker.IsSlotFree(ctx, boxName, startsAt, startsAt.Add(duration), peopleAmount)
+	if err != nil {
+		log.Error("failed to check the slot", sl.Err(err))
+		return models.WaitlistEntry{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if free {
+		return models.WaitlistEntry{}, fmt.Errorf("%s: %w", op, ErrSlotFree)
+	}
+
+	entry, err := b.waitlist.JoinWaitlist(ctx, models.WaitlistEntry{
+		Email:        email,
+		BoxName:      boxName,
+		StartsAt:     startsAt,
+		ExpiresAt:    startsAt.Add(duration),
+		PeopleAmount: peopleAmount,
+		Mode:         mode,
+	})
+	if err != nil {
+		log.Error("failed to join the waitlist", sl.Err(err))
+		return models.WaitlistEntry{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("joined the waitlist", slog.String("entry_id", entry.UID))
+
+	return entry, nil
+}
+
+// Waitlist returns the waitlist entries of the user, newest first.
+func (b *Book) Waitlist(ctx context.Context, email string) ([]models.WaitlistEntry, error) {
+	const op = "book.Waitlist"
+
+	entries, err := b.waitlist.Waitlist(ctx, email)
+	if err != nil {
+		b.log.Error("failed to get the waitlist", slog.String("op", op), sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return entries, nil
+}
+
+// AcceptOffer books and pays for the slot held by a waitlist offer.
+func (b *Book) AcceptOffer(ctx context.Context, email string, entryUID string) (booking models.Booking, price models.Price, balance int64, err error) {
+	const op = "book.AcceptOffer"
+
+	log := b.log.With(slog.String("op", op), slog.String("entry_id", entryUID))
+
+	entry, err := b.waitlistEntry(ctx, email, entryUID)
+	if err != nil {
+		return models.Booking{}, models.Price{}, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if entry.Status != models.WaitlistStatusOffered {
+		return models.Booking{}, models.Price{}, 0, fmt.Errorf("%s: %w", op, ErrNoOffer)
+	}
+
+	box, err := b.Box(ctx, entry.BoxName)
+	if err != nil {
+		return models.Booking{}, models.Price{}, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	duration := entry.ExpiresAt.Sub(entry.StartsAt)
+
+	price, err = b.pricer.Quote(ctx, box, entry.StartsAt, duration, entry.PeopleAmount)
+	if err != nil {
+		log.Error("failed to calculate the price", sl.Err(err))
+		return models.Booking{}, models.Price{}, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	saga, err := b.waitlist.AcceptOffer(ctx, entry, price.Total)
+	if err != nil {
+		if errors.Is(err, storage.ErrOfferExpired) {
+			return models.Booking{}, models.Price{}, 0, fmt.Errorf("%s: %w", op, ErrOfferExpired)
+		}
+		log.Error("failed to accept the offer", sl.Err(err))
+		return models.Booking{}, models.Price{}, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	balance, err = b.charge(ctx, saga)
+	if err != nil {
+		if errors.Is(err, ErrPaymentPending) {
+			// The recovery books the slot or releases it once the payment is settled.
+			return models.Booking{}, models.Price{}, 0, fmt.Errorf("%s: %w", op, err)
+		}
+		if err := b.waitlist.SetWaitlistStatus(ctx, entry.ID, models.WaitlistStatusFailed, "", errors.Unwrap(err).Error()); err != nil {
+			log.Error("failed to save the waitlist entry", sl.Err(err))
+		}
+		return models.Booking{}, models.Price{}, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := b.confirm(ctx, saga); err != nil {
+		return models.Booking{}, models.Price{}, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("waitlist offer accepted", slog.String("booking_id", saga.BookingID))
+
+	return models.Booking{
+		UID:          saga.BookingID,
+		ID:           saga.LegacyBookingID,
+		Email:        entry.Email,
+		BoxName:      entry.BoxName,
+		StartsAt:     entry.StartsAt,
+		ExpiresAt:    entry.ExpiresAt,
+		PeopleAmount: entry.PeopleAmount,
+		PricePaid:    price.Total,
+		Status:       models.BookingStatusActive,
+	}, price, balance, nil
+}
+
+// LeaveWaitlist takes the user off the waitlist. A held slot is given up and
+// offered to the next user.
+func (b *Book) LeaveWaitlist(ctx context.Context, email string, entryUID string) error {
+	const op = "book.LeaveWaitlist"
+
+	entry, err := b.waitlistEntry(ctx, email, entryUID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := b.waitlist.LeaveWaitlist(ctx, entry); err != nil {
+		if errors.Is(err, storage.ErrWaitlistEntryNotFound) {
+			return fmt.Errorf("%s: %w", op, ErrWaitlistEntryNotFound)
+		}
+		b.log.Error("failed to leave the waitlist", slog.String("op", op), sl.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if entry.Status == models.WaitlistStatusOffered {
+		b.promoteSlot(ctx, entry.BoxName, entry.StartsAt, entry.ExpiresAt)
+	}
+
+	return nil
+}
+
+func (b *Book) waitlistEntry(ctx context.Context, email string, entryUID string) (models.WaitlistEntry, error) {
+	entry, err := b.waitlist.WaitlistEntry(ctx, entryUID)
+	if err != nil {
+		if errors.Is(err, storage.ErrWaitlistEntryNotFound) {
+			return models.WaitlistEntry{}, ErrWaitlistEntryNotFound
+		}
+		return models.WaitlistEntry{}, err
+	}
+
+	if entry.Email != email {
+		return models.WaitlistEntry{}, ErrNotYourWaitlistEntry
+	}
+
+	return entry, nil
+}
+
+// PromoteWaitlist expires the offers that ran out and gives every free slot to
+// the first user waiting for it.
+func (b *Book) PromoteWaitlist(ctx context.Context) error {
+	const op = "book.PromoteWaitlist"
+
+	b.waitlistMu.Lock()
+	defer b.waitlistMu.Unlock()
+
+	if _, err := b.waitlist.ExpireWaitlist(ctx, time.Now()); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	entries, err := b.waitlist.WaitingEntries(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, entry := range entries {
+		b.promote(ctx, entry)
+	}
+
+	return nil
+}
+
+// promoteSlot gives a slot that has just been freed to the users waiting for it.
+func (b *Book) promoteSlot(ctx context.Context, boxName string, startsAt time.Time, expiresAt time.Time) {
+	const op = "book.promoteSlot"
+
+	b.waitlistMu.Lock()
+	defer b.waitlistMu.Unlock()
+
+	entries, err := b.waitlist.WaitingEntries(ctx)
+	if err != nil {
+		b.log.Error("failed to get the waitlist", slog.String("op", op), sl.Err(err))
+		return
+	}
+
+	for _, entry := range entries {
+		if entry.BoxName == boxName && entry.StartsAt.Before(expiresAt) && entry.ExpiresAt.After(startsAt) {
+			b.promote(ctx, entry)
+		}
+	}
+}
+
+// promote offers or books the slot of a waiting entry if it is free. A taken
+// slot leaves the entry waiting. A booking whose payment is still being settled
+// by the recovery books the entry already, so it is not booked twice; the
+// entry fails with the booking if the recovery rolls it back. Any other failure
+// of the booking, e.g. a closed box or a declined payment, won't go away by
+// trying again and fails the entry.
+func (b *Book) promote(ctx context.Context, entry models.WaitlistEntry) {
+	const op = "book.promote"
+
+	log := b.log.With(slog.String("op", op), slog.String("entry_id", entry.UID))
+
+	if entry.Mode == models.WaitlistModeOffer {
+		bookingUID, err := b.waitlist.OfferSlot(ctx, entry, time.Now().Add(b.offerTTL))
+		if err != nil {
+			if !errors.Is(err, storage.ErrAlreadyBooked) {
+				log.Error("failed to offer the slot", sl.Err(err))
+			}
+			return
+		}
+
+		log.Info("slot offered", slog.String("booking_id", bookingUID))
+
+		return
+	}
+
+	booking, _, _, err := b.book(ctx, entry.Email, entry.BoxName, entry.StartsAt, entry.ExpiresAt.Sub(entry.StartsAt), entry.PeopleAmount, "")
+	if err != nil && !errors.Is(err, ErrPaymentPending) {
+		if errors.Is(err, ErrAlreadyBooked) {
+			return
+		}
+
+		log.Error("failed to book the slot", sl.Err(err))
+
+		if err := b.waitlist.SetWaitlistStatus(ctx, entry.ID, models.WaitlistStatusFailed, "", failureReason(err)); err != nil {
+			log.Error("failed to save the waitlist entry", sl.Err(err))
+		}
+
+		return
+	}
+
+	if err := b.waitlist.SetWaitlistStatus(ctx, entry.ID, models.WaitlistStatusBooked, booking.UID, ""); err != nil {
+		log.Error("failed to save the waitlist entry", sl.Err(err))
+	}
+
+	log.Info("slot booked from the waitlist", slog.String("booking_id", booking.UID))
+}
+
+// failureReason returns the innermost error of the chain, the one without the
+// operation names.
+func failureReason(err error) string {
+	for {
+		inner := errors.Unwrap(err)
+		if inner == nil {
+			return err.Error()
+		}
+		err = inner
+	}
+}
+
+func (b *Book) StartWaitlistWorker(ctx context.Context, interval time.Duration) <-chan error {
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(errCh)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := b.PromoteWaitlist(ctx); err != nil {
+					errCh <- err
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return errCh
+}
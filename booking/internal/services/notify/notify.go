@@ -0,0 +1,294 @@
+package notify
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/lib/logger/sl"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+const (
+	// dispatchBatch is how many notifications one dispatch sends at most.
+	dispatchBatch = 100
+	// maxRetryDelay caps the growing delay between attempts.
+	maxRetryDelay = time.Hour
+)
+
+var errChannelNotConfigured = errors.New("channel is not configured")
+
+// Notifier queues notifications about bookings and sends them over every
+// configured channel. Each channel keeps its own delivery state, so one
+// failing channel doesn't hold up or repeat the others.
+type Notifier struct {
+	log          *slog.Logger
+	store        Store
+	channels     map[string]Channel
+	reminders    []time.Duration
+	locale       models.Locale
+	maxAttempts  int
+	retryBackoff time.Duration
+}
+
+// Channel sends rendered notifications, e.g. by email.
+type Channel interface {
+	Name() string
+	Send(ctx context.Context, msg models.NotificationMessage) error
+}
+
+type Store interface {
+	EnqueueNotification(ctx context.Context, notification models.Notification) (bool, error)
+	DueNotifications(ctx context.Context, now time.Time, limit int) ([]models.Notification, error)
+	UpdateNotification(ctx context.Context, notification models.Notification) error
+	NotificationLocale(ctx context.Context, email string) (models.Locale, error)
+	SetNotificationLocale(ctx context.Context, email string, locale models.Locale) error
+	Booking(ctx context.Context, bookingID string) (models.Booking, error)
+	Box(ctx context.Context, name string) (models.Box, error)
+}
+
+// New returns a notifier sending reminders the given time before bookings
+// start. Users who haven't chosen a locale get messages in locale. A failed
+// delivery is retried after retryBackoff, doubled with every attempt, until
+// maxAttempts attempts have failed.
+func New(log *slog.Logger, store Store, channels []Channel, reminders []time.Duration, locale models.Locale, maxAttempts int, retryBackoff time.Duration) *Notifier {
+	byName := make(map[string]Channel, len(channels))
+	for _, channel := range channels {
+		byName[channel.Name()] = channel
+	}
+
+	if !locale.Valid() {
+		locale = models.LocaleRU
+	}
+
+	return &Notifier{
+		log:          log,
+		store:        store,
+		channels:     byName,
+		reminders:    reminders,
+		locale:       locale,
+		maxAttempts:  max(maxAttempts, 1),
+		retryBackoff: retryBackoff,
+	}
+}
+
+// Notify queues the notice for every channel. New and moved bookings also
+// get their reminders queued. Queueing the same notice again does nothing.
+func (n *Notifier) Notify(ctx context.Context, notice models.Notice) error {
+	const op = "notify.Notify"
+
+	to := notice.Booking.Email
+	if notice.To != "" {
+		to = notice.To
+	}
+
+	locale, err := n.store.NotificationLocale(ctx, to)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if locale == "" {
+		locale = n.locale
+	}
+
+	booking := notice.Booking
+	now := time.Now()
+
+	base := models.Notification{
+		Email:         to,
+		Kind:          notice.Kind,
+		Locale:        locale,
+		BookingUID:    booking.UID,
+		BoxName:       booking.BoxName,
+		StartsAt:      booking.StartsAt,
+		ExpiresAt:     booking.ExpiresAt,
+		PeopleAmount:  booking.PeopleAmount,
+		Amount:        notice.Amount,
+		NextAttemptAt: now,
+	}
+
+	notifications := []models.Notification{base}
+	notifications[0].Key = string(notice.Kind) + ":" + booking.UID + ":" + notice.Ref
+
+	if notice.Kind == models.NotificationBooked || notice.Kind == models.NotificationRescheduled {
+		for _, before := range n.reminders {
+			sendAt := booking.StartsAt.Add(-before)
+			if sendAt.Before(now) {
+				continue
+			}
+
+			reminder := base
+			reminder.Kind = models.NotificationReminder
+			reminder.Amount = 0
+			reminder.RemindBefore = before
+			reminder.NextAttemptAt = sendAt
+			// A moved booking gets new reminders, the old ones are skipped.
+			reminder.Key = fmt.Sprintf("%s:%s:%d:%d", models.NotificationReminder, booking.UID,
+				booking.StartsAt.Unix(), int64(before/time.Second))
+
+			notifications = append(notifications, reminder)
+		}
+	}
+
+	for _, notification := range notifications {
+		key := notification.Key
+
+		for name := range n.channels {
+			notification.Channel = name
+			notification.Key = key + ":" + name
+
+			if _, err := n.store.EnqueueNotification(ctx, notification); err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetLocale sets the language of the notifications of the user.
+func (n *Notifier) SetLocale(ctx context.Context, email string, locale models.Locale) error {
+	const op = "notify.SetLocale"
+
+	if err := n.store.SetNotificationLocale(ctx, email, locale); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Dispatch sends the notifications that are due.
+func (n *Notifier) Dispatch(ctx context.Context) error {
+	const op = "notify.Dispatch"
+
+	due, err := n.store.DueNotifications(ctx, time.Now(), dispatchBatch)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, notification := range due {
+		if err := ctx.Err(); err != nil {
+			return nil
+		}
+
+		n.deliver(ctx, notification)
+	}
+
+	return nil
+}
+
+func (n *Notifier) StartDispatcher(ctx context.Context, interval time.Duration) <-chan error {
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(errCh)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := n.Dispatch(ctx); err != nil {
+					errCh <- err
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return errCh
+}
+
+// deliver sends one notification and stores the outcome.
+func (n *Notifier) deliver(ctx context.Context, notification models.Notification) {
+	const op = "notify.deliver"
+
+	log := n.log.With(
+		slog.String("op", op),
+		slog.String("notification_id", notification.UID),
+		slog.String("kind", string(notification.Kind)),
+		slog.String("channel", notification.Channel))
+
+	now := time.Now()
+
+	skip, err := n.stale(ctx, notification, now)
+	if err != nil {
+		log.Error("failed to check the booking", sl.Err(err))
+		return
+	}
+
+	if skip {
+		notification.Status = models.NotificationStatusSkipped
+	} else {
+		err = n.send(ctx, notification)
+		notification.Attempts++
+
+		switch {
+		case err == nil:
+			notification.Status = models.NotificationStatusSent
+			notification.SentAt = now
+			notification.Error = ""
+		case notification.Attempts >= n.maxAttempts || errors.Is(err, errChannelNotConfigured):
+			log.Error("failed to send the notification, giving up", slog.Int("attempts", notification.Attempts), sl.Err(err))
+			notification.Status = models.NotificationStatusFailed
+			notification.Error = err.Error()
+		default:
+			log.Warn("failed to send the notification, will retry", slog.Int("attempts", notification.Attempts), sl.Err(err))
+			notification.NextAttemptAt = now.Add(n.retryDelay(notification.Attempts))
+			notification.Error = err.Error()
+		}
+	}
+
+	if err := n.store.UpdateNotification(ctx, notification); err != nil {
+		// The notification stays due and is sent again.
+		log.Error("failed to save the notification", sl.Err(err))
+	}
+}
+
+// stale reports whether a reminder is no longer true: the booking was
+// cancelled, moved or has started already.
+func (n *Notifier) stale(ctx context.Context, notification models.Notification, now time.Time) (bool, error) {
+	if notification.Kind != models.NotificationReminder {
+		return false, nil
+	}
+
+	booking, err := n.store.Booking(ctx, notification.BookingUID)
+	if err != nil {
+		return false, err
+	}
+
+	return booking.Status != models.BookingStatusActive ||
+		!booking.StartsAt.Equal(notification.StartsAt) ||
+		!now.Before(booking.StartsAt), nil
+}
+
+func (n *Notifier) send(ctx context.Context, notification models.Notification) error {
+	channel, ok := n.channels[notification.Channel]
+	if !ok {
+		return errChannelNotConfigured
+	}
+
+	box, err := n.store.Box(ctx, notification.BoxName)
+	if err != nil {
+		return err
+	}
+
+	msg, err := render(notification, box)
+	if err != nil {
+		return err
+	}
+
+	return channel.Send(ctx, msg)
+}
+
+// retryDelay is the wait after the given number of failed attempts.
+func (n *Notifier) retryDelay(attempts int) time.Duration {
+	delay := n.retryBackoff
+	for i := 1; i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, maxRetryDelay)
+}
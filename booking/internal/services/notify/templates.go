@@ -0,0 +1,204 @@
+package notify
+
+import (
+	"booking/internal/domain/models"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// message is the subject and body template of one kind of notification.
+type message struct {
+	subject *template.Template
+	body    *template.Template
+}
+
+// language holds the templates and formats of one locale.
+type language struct {
+	dateLayout string
+	hours      string
+	minutes    string
+	messages   map[models.NotificationKind]message
+}
+
+// messageData is what the templates can use. Times are in the time zone of
+// the box.
+type messageData struct {
+	Box       string
+	Address   string
+	Date      string
+	Start     string
+	End       string
+	People    int64
+	Amount    int64
+	Before    string
+	BookingID string
+}
+
+var languages = map[models.Locale]language{
+	models.LocaleEN: {
+		dateLayout: "Mon, 2 Jan 2006",
+		hours:      "%d h",
+		minutes:    "%d min",
+		messages: map[models.NotificationKind]message{
+			models.NotificationBooked: parse(
+				"Booking confirmed: {{.Box}}, {{.Date}} {{.Start}}",
+				"Your booking of {{.Box}} is confirmed.\n\n"+
+					"When: {{.Date}}, {{.Start}}–{{.End}}\n"+
+					"{{if .Address}}Where: {{.Address}}\n{{end}}"+
+					"People: {{.People}}\n"+
+					"Paid: {{.Amount}} ₽\n\n"+
+					"Booking: {{.BookingID}}"),
+			models.NotificationRescheduled: parse(
+				"Booking moved: {{.Box}}, {{.Date}} {{.Start}}",
+				"Your booking is moved.\n\n"+
+					"Box: {{.Box}}\n"+
+					"When: {{.Date}}, {{.Start}}–{{.End}}\n"+
+					"{{if .Address}}Where: {{.Address}}\n{{end}}"+
+					"People: {{.People}}\n"+
+					"{{if gt .Amount 0}}Extra charge: {{.Amount}} ₽\n{{end}}\n"+
+					"Booking: {{.BookingID}}"),
+			models.NotificationCancelled: parse(
+				"Booking cancelled: {{.Box}}, {{.Date}} {{.Start}}",
+				"Your booking of {{.Box}} on {{.Date}}, {{.Start}}–{{.End}} is cancelled.\n"+
+					"{{if .Amount}}Refund: {{.Amount}} ₽, it is back in your wallet.\n{{else}}There is no refund for this booking.\n{{end}}\n"+
+					"Booking: {{.BookingID}}"),
+			models.NotificationRefunded: parse(
+				"Refund: {{.Amount}} ₽",
+				"{{.Amount}} ₽ for the booking of {{.Box}} on {{.Date}}, {{.Start}}–{{.End}} is back in your wallet.\n\n"+
+					"Booking: {{.BookingID}}"),
+			models.NotificationReminder: parse(
+				"Reminder: {{.Box}} in {{.Before}}",
+				"Your session in {{.Box}} starts in {{.Before}}.\n\n"+
+					"When: {{.Date}}, {{.Start}}–{{.End}}\n"+
+					"{{if .Address}}Where: {{.Address}}\n{{end}}"+
+					"People: {{.People}}\n\n"+
+					"Booking: {{.BookingID}}"),
+			models.NotificationInvited: parse(
+				"Invitation: {{.Box}}, {{.Date}} {{.Start}}",
+				"You are invited to a session in {{.Box}}.\n\n"+
+					"When: {{.Date}}, {{.Start}}–{{.End}}\n"+
+					"{{if .Address}}Where: {{.Address}}\n{{end}}"+
+					"People: {{.People}}\n\n"+
+					"Accept or decline the invitation in the app.\n"+
+					"Booking: {{.BookingID}}"),
+		},
+	},
+	models.LocaleRU: {
+		dateLayout: "02.01.2006",
+		hours:      "%d ч",
+		minutes:    "%d мин",
+		messages: map[models.NotificationKind]message{
+			models.NotificationBooked: parse(
+				"Бронирование подтверждено: {{.Box}}, {{.Date}} {{.Start}}",
+				"Ваше бронирование {{.Box}} подтверждено.\n\n"+
+					"Когда: {{.Date}}, {{.Start}}–{{.End}}\n"+
+					"{{if .Address}}Где: {{.Address}}\n{{end}}"+
+					"Человек: {{.People}}\n"+
+					"Оплачено: {{.Amount}} ₽\n\n"+
+					"Бронирование: {{.BookingID}}"),
+			models.NotificationRescheduled: parse(
+				"Бронирование перенесено: {{.Box}}, {{.Date}} {{.Start}}",
+				"Ваше бронирование перенесено.\n\n"+
+					"Коробка: {{.Box}}\n"+
+					"Когда: {{.Date}}, {{.Start}}–{{.End}}\n"+
+					"{{if .Address}}Где: {{.Address}}\n{{end}}"+
+					"Человек: {{.People}}\n"+
+					"{{if gt .Amount 0}}Доплата: {{.Amount}} ₽\n{{end}}\n"+
+					"Бронирование: {{.BookingID}}"),
+			models.NotificationCancelled: parse(
+				"Бронирование отменено: {{.Box}}, {{.Date}} {{.Start}}",
+				"Ваше бронирование {{.Box}} на {{.Date}}, {{.Start}}–{{.End}} отменено.\n"+
+					"{{if .Amount}}Возврат: {{.Amount}} ₽, деньги вернулись на ваш счёт.\n{{else}}Возврат за это бронирование не положен.\n{{end}}\n"+
+					"Бронирование: {{.BookingID}}"),
+			models.NotificationRefunded: parse(
+				"Возврат: {{.Amount}} ₽",
+				"{{.Amount}} ₽ за бронирование {{.Box}} на {{.Date}}, {{.Start}}–{{.End}} вернулись на ваш счёт.\n\n"+
+					"Бронирование: {{.BookingID}}"),
+			models.NotificationReminder: parse(
+				"Напоминание: {{.Box}} через {{.Before}}",
+				"Ваше занятие в {{.Box}} начнётся через {{.Before}}.\n\n"+
+					"Когда: {{.Date}}, {{.Start}}–{{.End}}\n"+
+					"{{if .Address}}Где: {{.Address}}\n{{end}}"+
+					"Человек: {{.People}}\n\n"+
+					"Бронирование: {{.BookingID}}"),
+			models.NotificationInvited: parse(
+				"Приглашение: {{.Box}}, {{.Date}} {{.Start}}",
+				"Вас пригласили на занятие в {{.Box}}.\n\n"+
+					"Когда: {{.Date}}, {{.Start}}–{{.End}}\n"+
+					"{{if .Address}}Где: {{.Address}}\n{{end}}"+
+					"Человек: {{.People}}\n\n"+
+					"Примите или отклоните приглашение в приложении.\n"+
+					"Бронирование: {{.BookingID}}"),
+		},
+	},
+}
+
+func parse(subject string, body string) message {
+	return message{
+		subject: template.Must(template.New("subject").Parse(subject)),
+		body:    template.Must(template.New("body").Parse(body)),
+	}
+}
+
+// render makes the message of a notification in its locale.
+func render(notification models.Notification, box models.Box) (models.NotificationMessage, error) {
+	lang, ok := languages[notification.Locale]
+	if !ok {
+		lang = languages[models.LocaleRU]
+	}
+
+	tmpl, ok := lang.messages[notification.Kind]
+	if !ok {
+		return models.NotificationMessage{}, fmt.Errorf("no template for %q notifications", notification.Kind)
+	}
+
+	loc, err := box.Location()
+	if err != nil {
+		return models.NotificationMessage{}, err
+	}
+
+	startsAt := notification.StartsAt.In(loc)
+
+	data := messageData{
+		Box:       notification.BoxName,
+		Address:   box.Address,
+		Date:      startsAt.Format(lang.dateLayout),
+		Start:     startsAt.Format("15:04"),
+		End:       notification.ExpiresAt.In(loc).Format("15:04"),
+		People:    notification.PeopleAmount,
+		Amount:    notification.Amount,
+		Before:    lang.duration(notification.RemindBefore),
+		BookingID: notification.BookingUID,
+	}
+
+	var subject, body strings.Builder
+
+	if err := tmpl.subject.Execute(&subject, data); err != nil {
+		return models.NotificationMessage{}, err
+	}
+
+	if err := tmpl.body.Execute(&body, data); err != nil {
+		return models.NotificationMessage{}, err
+	}
+
+	return models.NotificationMessage{
+		ID:         notification.UID,
+		Kind:       notification.Kind,
+		To:         notification.Email,
+		Locale:     notification.Locale,
+		Subject:    subject.String(),
+		Body:       body.String(),
+		BookingUID: notification.BookingUID,
+	}, nil
+}
+
+// duration formats d in whole hours, or in minutes when it isn't.
+func (l language) duration(d time.Duration) string {
+	if d%time.Hour == 0 {
+		return fmt.Sprintf(l.hours, int64(d/time.Hour))
+	}
+
+	return fmt.Sprintf(l.minutes, int64(d/time.Minute))
+}
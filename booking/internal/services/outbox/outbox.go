@@ -0,0 +1,123 @@
+package outbox
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/lib/logger/sl"
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+const (
+	// relayBatch is how many events one relay run publishes at most.
+	relayBatch = 100
+	// maxRetryDelay caps the growing delay between attempts.
+	maxRetryDelay = 10 * time.Minute
+)
+
+// Relay publishes the events of the outbox in the order they were written.
+// An event is marked published only after the publisher accepted it, so it
+// may be published more than once but never lost. A failed event holds up
+// the ones after it until it goes through.
+type Relay struct {
+	log          *slog.Logger
+	store        Store
+	publisher    Publisher
+	retryBackoff time.Duration
+}
+
+// Publisher hands events over to their consumers.
+type Publisher interface {
+	Publish(ctx context.Context, event models.Event) error
+}
+
+type Store interface {
+	PendingEvents(ctx context.Context, now time.Time, limit int) ([]models.Event, error)
+	EventPublished(ctx context.Context, eventID int64) error
+	EventFailed(ctx context.Context, eventID int64, nextAttemptAt time.Time, reason string) error
+}
+
+// New returns a relay retrying a failed event after retryBackoff, doubled
+// with every attempt.
+func New(log *slog.Logger, store Store, publisher Publisher, retryBackoff time.Duration) *Relay {
+	return &Relay{
+		log:          log,
+		store:        store,
+		publisher:    publisher,
+		retryBackoff: retryBackoff,
+	}
+}
+
+// Relay publishes the pending events. It stops at the first event that
+// can't be published.
+func (r *Relay) Relay(ctx context.Context) error {
+	const op = "outbox.Relay"
+
+	log := r.log.With(slog.String("op", op))
+
+	events, err := r.store.PendingEvents(ctx, time.Now(), relayBatch)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, event := range events {
+		log := log.With(
+			slog.Int64("event_id", event.ID),
+			slog.String("type", string(event.Type)),
+			slog.String("booking_id", event.BookingUID))
+
+		if err := r.publisher.Publish(ctx, event); err != nil {
+			attempts := event.Attempts + 1
+
+			log.Warn("failed to publish the event", slog.Int("attempts", attempts), sl.Err(err))
+
+			if err := r.store.EventFailed(ctx, event.ID, time.Now().Add(r.retryDelay(attempts)), err.Error()); err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+
+			return nil
+		}
+
+		if err := r.store.EventPublished(ctx, event.ID); err != nil {
+			// The event is published again by the next run.
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Relay) StartRelay(ctx context.Context, interval time.Duration) <-chan error {
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(errCh)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := r.Relay(ctx); err != nil {
+					errCh <- err
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return errCh
+}
+
+// retryDelay is the wait after the given number of failed attempts.
+func (r *Relay) retryDelay(attempts int) time.Duration {
+	delay := r.retryBackoff
+	for i := 1; i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, maxRetryDelay)
+}
@@ -0,0 +1,318 @@
+package pricing
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/lib/logger/sl"
+	"booking/internal/storage"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+const (
+	basePercent = 100
+	baseName    = "base"
+	dateLayout  = "2006-01-02"
+	minutesADay = 24 * 60
+)
+
+// Pricing calculates booking prices from the base rate of the box and the
+// price rules, holiday rates and surge settings stored next to it.
+type Pricing struct {
+	log       *slog.Logger
+	rules     RuleProvider
+	occupancy OccupancyProvider
+}
+
+type RuleProvider interface {
+	PriceRules(ctx context.Context, boxName string) ([]models.PriceRule, error)
+	HolidayRate(ctx context.Context, boxName string, date string) (models.HolidayRate, error)
+}
+
+type OccupancyProvider interface {
+	BusyIntervals(ctx context.Context, boxName string, from time.Time, to time.Time) ([]models.Interval, error)
+}
+
+func New(log *slog.Logger, rules RuleProvider, occupancy OccupancyProvider) *Pricing {
+	return &Pricing{
+		log:       log,
+		rules:     rules,
+		occupancy: occupancy,
+	}
+}
+
+// Quote returns the price of booking the box for duration from startsAt.
+// Every minute is charged at the base rate times the rules covering it, or
+// times the holiday rate on holidays. The rate only changes at midnight and at
+// the edges of the rules, so the booking is priced a segment between two such
+// changes at a time. Segments charged at the same rate make up one line of the
+// breakdown.
+func (p *Pricing) Quote(ctx context.Context, box models.Box, startsAt time.Time, duration time.Duration, peopleAmount int64) (models.Price, error) {
+	const op = "pricing.Quote"
+
+	log := p.log.With(slog.String("op", op), slog.String("box", box.Name))
+
+	loc, err := box.Location()
+	if err != nil {
+		return models.Price{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rules, err := p.rules.PriceRules(ctx, box.Name)
+	if err != nil {
+		log.Error("failed to get price rules", sl.Err(err))
+		return models.Price{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	compiled, err := compileRules(rules)
+	if err != nil {
+		log.Error("invalid price rule", sl.Err(err))
+		return models.Price{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	price := models.Price{
+		PricePerHour: box.PricePerHour,
+		PeopleAmount: 1,
+		SurgePercent: basePercent,
+		MinCharge:    box.MinCharge,
+	}
+	if box.SharedSessions {
+		price.PeopleAmount = peopleAmount
+	}
+
+	start := startsAt.In(loc)
+	end := start.Add(duration)
+	holidays := make(map[string]*models.HolidayRate)
+
+	for t := start; t.Before(end); {
+		date := t.Format(dateLayout)
+
+		holiday, ok := holidays[date]
+		if !ok {
+			holiday, err = p.holidayRate(ctx, box.Name, date)
+			if err != nil {
+				log.Error("failed to get holiday rate", sl.Err(err))
+				return models.Price{}, fmt.Errorf("%s: %w", op, err)
+			}
+			holidays[date] = holiday
+		}
+
+		var (
+			name    string
+			percent int64
+		)
+		if holiday != nil {
+			name, percent = holiday.Name, holiday.Percent
+		} else {
+			name, percent = compiled.rate(t)
+		}
+
+		segmentEnd := compiled.nextChange(t)
+		if segmentEnd.After(end) {
+			segmentEnd = end
+		}
+
+		if n := len(price.Lines); n > 0 && price.Lines[n-1].Name == name && price.Lines[n-1].Percent == percent {
+			price.Lines[n-1].ExpiresAt = segmentEnd
+		} else {
+			price.Lines = append(price.Lines, models.PriceLine{
+				Name:      name,
+				StartsAt:  t,
+				ExpiresAt: segmentEnd,
+				Percent:   percent,
+			})
+		}
+
+		t = segmentEnd
+	}
+
+	for i := range price.Lines {
+		line := &price.Lines[i]
+		minutes := int64(line.ExpiresAt.Sub(line.StartsAt).Round(time.Minute) / time.Minute)
+		line.Amount = box.PricePerHour * minutes * line.Percent * price.PeopleAmount / (60 * basePercent)
+		price.Subtotal += line.Amount
+	}
+
+	price.Total = price.Subtotal
+
+	if box.SurgeOccupancy > 0 && box.SurgePercent != basePercent {
+		occupancy, err := p.occupancyPercent(ctx, box, start)
+		if err != nil {
+			log.Error("failed to get box occupancy", sl.Err(err))
+			return models.Price{}, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if occupancy >= box.SurgeOccupancy {
+			price.SurgePercent = box.SurgePercent
+			price.Total = price.Subtotal * box.SurgePercent / basePercent
+		}
+	}
+
+	if price.Total < price.MinCharge {
+		price.Total = price.MinCharge
+	}
+
+	return price, nil
+}
+
+func (p *Pricing) holidayRate(ctx context.Context, boxName string, date string) (*models.HolidayRate, error) {
+	rate, err := p.rules.HolidayRate(ctx, boxName, date)
+	if err != nil {
+		if errors.Is(err, storage.ErrHolidayRateNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &rate, nil
+}
+
+// occupancyPercent returns how much of the opening hours of the box on the
+// day of date is already booked. Shared boxes count booked places.
+func (p *Pricing) occupancyPercent(ctx context.Context, box models.Box, date time.Time) (int64, error) {
+	opens, closes, err := box.OpeningHours(date)
+	if err != nil {
+		return 0, err
+	}
+
+	if !closes.After(opens) {
+		return 0, nil
+	}
+
+	busy, err := p.occupancy.BusyIntervals(ctx, box.Name, opens, closes)
+	if err != nil {
+		return 0, err
+	}
+
+	capacity := int64(closes.Sub(opens) / time.Minute)
+	if box.SharedSessions && box.Capacity > 0 {
+		capacity *= box.Capacity
+	}
+
+	var booked int64
+	for _, in := range busy {
+		from, to := in.StartsAt, in.ExpiresAt
+		if from.Before(opens) {
+			from = opens
+		}
+		if to.After(closes) {
+			to = closes
+		}
+		if !to.After(from) {
+			continue
+		}
+
+		minutes := int64(to.Sub(from) / time.Minute)
+		if box.SharedSessions {
+			minutes *= in.PeopleAmount
+		}
+		booked += minutes
+	}
+
+	return booked * 100 / capacity, nil
+}
+
+type compiledRule struct {
+	name     string
+	weekday  *time.Weekday
+	from, to int
+	percent  int64
+}
+
+type compiledRules []compiledRule
+
+func compileRules(rules []models.PriceRule) (compiledRules, error) {
+	compiled := make(compiledRules, 0, len(rules))
+
+	for _, rule := range rules {
+		from, err := minuteOfDay(rule.StartsAt)
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: %w", rule.Name, err)
+		}
+
+		to, err := minuteOfDay(rule.EndsAt)
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: %w", rule.Name, err)
+		}
+
+		compiled = append(compiled, compiledRule{
+			name:    rule.Name,
+			weekday: rule.Weekday,
+			from:    from,
+			to:      to,
+			percent: rule.Percent,
+		})
+	}
+
+	return compiled, nil
+}
+
+// rate returns the names of the rules covering the minute starting at t and
+// their combined percent.
+func (rules compiledRules) rate(t time.Time) (string, int64) {
+	var names []string
+	percent := int64(basePercent)
+	minute := t.Hour()*60 + t.Minute()
+
+	for _, rule := range rules {
+		if rule.weekday != nil && *rule.weekday != t.Weekday() {
+			continue
+		}
+		if minute < rule.from || minute >= rule.to {
+			continue
+		}
+
+		percent = percent * rule.percent / basePercent
+		names = append(names, rule.name)
+	}
+
+	if len(names) == 0 {
+		return baseName, percent
+	}
+
+	return strings.Join(names, ", "), percent
+}
+
+// nextChange returns when the rate after t can change next: at the start or
+// the end of a rule later that day, or at midnight, when the weekday and the
+// holiday change.
+func (rules compiledRules) nextChange(t time.Time) time.Time {
+	minute := t.Hour()*60 + t.Minute()
+	next := minutesADay
+
+	for _, rule := range rules {
+		if rule.from > minute && rule.from < next {
+			next = rule.from
+		}
+		if rule.to > minute && rule.to < next {
+			next = rule.to
+		}
+	}
+
+	year, month, day := t.Date()
+
+	change := time.Date(year, month, day, 0, next, 0, 0, t.Location())
+	if !change.After(t) {
+		// A clock change can move the wall clock back, the rate is then
+		// taken again a minute later.
+		change = t.Truncate(time.Minute).Add(time.Minute)
+	}
+
+	return change
+}
+
+// minuteOfDay parses an HH:MM clock time. 24:00 is the end of the day.
+func minuteOfDay(clock string) (int, error) {
+	if clock == "24:00" {
+		return minutesADay, nil
+	}
+
+	t, err := time.Parse(models.ClockLayout, clock)
+	if err != nil {
+		return 0, fmt.Errorf("invalid clock time %q: %w", clock, err)
+	}
+
+	return t.Hour()*60 + t.Minute(), nil
+}
@@ -0,0 +1,48 @@
+package refund
+
+import (
+	"booking/internal/domain/models"
+	"time"
+)
+
+const fullPercent = 100
+
+// Policy decides how much of the paid price is given back when a booking is
+// cancelled.
+type Policy struct {
+	fullRefundBefore time.Duration
+	partialPercent   int64
+}
+
+// New returns a policy that refunds the whole price until fullRefundBefore
+// before the start, partialPercent of it after that and nothing once the
+// booking has started.
+func New(fullRefundBefore time.Duration, partialPercent int64) *Policy {
+	return &Policy{
+		fullRefundBefore: fullRefundBefore,
+		partialPercent:   min(max(partialPercent, 0), fullPercent),
+	}
+}
+
+// Refund returns the refund of the booking cancelled at now. Cancellations
+// made by an operator are always refunded in full.
+func (p *Policy) Refund(booking models.Booking, initiator models.CancelInitiator, now time.Time) models.Refund {
+	refund := models.Refund{
+		FullRefundUntil: booking.StartsAt.Add(-p.fullRefundBefore),
+	}
+
+	switch {
+	case initiator == models.CancelByOperator:
+		refund.Policy, refund.Percent = models.RefundPolicyOperator, fullPercent
+	case now.Before(refund.FullRefundUntil):
+		refund.Policy, refund.Percent = models.RefundPolicyFull, fullPercent
+	case now.Before(booking.StartsAt):
+		refund.Policy, refund.Percent = models.RefundPolicyPartial, p.partialPercent
+	default:
+		refund.Policy, refund.Percent = models.RefundPolicyNone, 0
+	}
+
+	refund.Amount = booking.PricePaid * refund.Percent / fullPercent
+
+	return refund
+}
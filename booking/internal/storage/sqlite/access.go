@@ -0,0 +1,177 @@
+package sqlite
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/storage"
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// accessPINDigits is the length of the door PIN of a booking.
+const accessPINDigits = 6
+
+// newAccessPIN returns a random numeric door PIN.
+func newAccessPIN() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1_000_000))
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%0*d", accessPINDigits, n.Int64()), nil
+}
+
+// BookingByPIN returns the active booking of the box with the given unused
+// door PIN that ends first after at. PINs are random, so two bookings of a box
+// may share one, the nearest of them is the one at the door.
+func (s *Storage) BookingByPIN(ctx context.Context, boxName string, pin string, at time.Time) (models.Booking, error) {
+	const op = "storage.sqlite.BookingByPIN"
+
+	row := s.db.QueryRowContext(ctx, `
+		SELECT `+bookingColumns+` FROM bookings
+		WHERE boxName = ? AND accessPin = ? AND accessPinUsedAt IS NULL AND status = ? AND expiresAt > ?
+		ORDER BY startsAt LIMIT 1
+	`, boxName, pin, models.BookingStatusActive, at.Unix())
+
+	booking, err := scanBooking(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Booking{}, fmt.Errorf("%s: %w", op, storage.ErrBookingNotFound)
+		}
+		return models.Booking{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return booking, nil
+}
+
+// PINLockedUntil returns until when PIN entry at the door of the box is
+// locked, the zero time when it never was.
+func (s *Storage) PINLockedUntil(ctx context.Context, boxName string, door string) (time.Time, error) {
+	const op = "storage.sqlite.PINLockedUntil"
+
+	var lockedUntil int64
+
+	err := s.db.QueryRowContext(ctx, "SELECT lockedUntil FROM access_pin_attempts WHERE boxName = ? AND door = ?", boxName, door).Scan(&lockedUntil)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return time.Time{}, nil
+		}
+		return time.Time{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if lockedUntil == 0 {
+		return time.Time{}, nil
+	}
+
+	return time.Unix(lockedUntil, 0), nil
+}
+
+// RecordPINFailure counts a wrong PIN entered at the door of the box.
+// Failures older than lockout are forgotten. The maxFailures-th failure locks
+// PIN entry at the door for lockout from at, the lock end is returned then and
+// the zero time otherwise.
+func (s *Storage) RecordPINFailure(ctx context.Context, boxName string, door string, at time.Time, maxFailures int64, lockout time.Duration) (time.Time, error) {
+	const op = "storage.sqlite.RecordPINFailure"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	forgetBefore := at.Add(-lockout).Unix()
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO access_pin_attempts(boxName, door, failures, firstFailedAt) VALUES(?, ?, 1, ?)
+		ON CONFLICT (boxName, door) DO UPDATE SET
+			failures = CASE WHEN firstFailedAt <= ? THEN 1 ELSE failures + 1 END,
+			firstFailedAt = CASE WHEN firstFailedAt <= ? THEN excluded.firstFailedAt ELSE firstFailedAt END
+	`, boxName, door, at.Unix(), forgetBefore, forgetBefore); err != nil {
+		return time.Time{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var failures int64
+	if err := tx.QueryRowContext(ctx, "SELECT failures FROM access_pin_attempts WHERE boxName = ? AND door = ?", boxName, door).Scan(&failures); err != nil {
+		return time.Time{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var lockedUntil time.Time
+
+	if failures >= maxFailures {
+		lockedUntil = at.Add(lockout)
+
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE access_pin_attempts SET failures = 0, lockedUntil = ? WHERE boxName = ? AND door = ?
+		`, lockedUntil.Unix(), boxName, door); err != nil {
+			return time.Time{}, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return time.Time{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return lockedUntil, nil
+}
+
+// CheckIn records a door opened for a booking. The first check-in is kept on
+// the booking too. A check-in with the PIN uses it up, a PIN used by another
+// check-in in the meantime is not found.
+func (s *Storage) CheckIn(ctx context.Context, checkIn models.CheckIn) (models.CheckIn, error) {
+	const op = "storage.sqlite.CheckIn"
+
+	uid, err := uuid.NewV7()
+	if err != nil {
+		return models.CheckIn{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	checkIn.UID = uid.String()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.CheckIn{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO check_ins(uid, bookingUid, boxName, door, method, createdAt) VALUES(?, ?, ?, ?, ?, ?)
+	`, checkIn.UID, checkIn.BookingUID, checkIn.BoxName, checkIn.Door, checkIn.Method, checkIn.CreatedAt.Unix()); err != nil {
+		return models.CheckIn{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if checkIn.Method == models.CheckInMethodPIN {
+		res, err := tx.ExecContext(ctx, `
+			UPDATE bookings SET accessPinUsedAt = ? WHERE uid = ? AND accessPinUsedAt IS NULL
+		`, checkIn.CreatedAt.Unix(), checkIn.BookingUID)
+		if err != nil {
+			return models.CheckIn{}, fmt.Errorf("%s: %w", op, err)
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return models.CheckIn{}, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if affected == 0 {
+			return models.CheckIn{}, fmt.Errorf("%s: %w", op, storage.ErrBookingNotFound)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE bookings SET checkedInAt = ? WHERE uid = ? AND checkedInAt IS NULL
+	`, checkIn.CreatedAt.Unix(), checkIn.BookingUID); err != nil {
+		return models.CheckIn{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.CheckIn{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return checkIn, nil
+}
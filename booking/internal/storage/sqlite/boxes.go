@@ -0,0 +1,76 @@
+package sqlite
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/storage"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// boxColumns selects the time zone of the venue for a box that has one.
+const boxColumns = "id, name, address, description, capacity, pricePerHour, " +
+	"COALESCE((SELECT venues.timeZone FROM venues WHERE venues.id = boxes.venueId), timeZone), " +
+	"active, opensAt, closesAt, sharedSessions, minCharge, surgeOccupancy, surgePercent, venueId"
+
+func (s *Storage) Box(ctx context.Context, name string) (models.Box, error) {
+	const op = "storage.sqlite.Box"
+
+	row := s.db.QueryRowContext(ctx, "SELECT "+boxColumns+" FROM boxes WHERE name = ?", name)
+
+	box, err := scanBox(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Box{}, fmt.Errorf("%s: %w", op, storage.ErrBoxNotFound)
+		}
+		return models.Box{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return box, nil
+}
+
+func (s *Storage) Boxes(ctx context.Context, includeInactive bool) ([]models.Box, error) {
+	const op = "storage.sqlite.Boxes"
+
+	query := "SELECT " + boxColumns + " FROM boxes"
+	if !includeInactive {
+		query += " WHERE active = 1"
+	}
+	query += " ORDER BY name"
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var boxes []models.Box
+
+	for rows.Next() {
+		box, err := scanBox(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		boxes = append(boxes, box)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return boxes, nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanBox(row scanner) (models.Box, error) {
+	var box models.Box
+
+	err := row.Scan(&box.ID, &box.Name, &box.Address, &box.Description, &box.Capacity, &box.PricePerHour, &box.TimeZone, &box.Active, &box.OpensAt, &box.ClosesAt, &box.SharedSessions,
+		&box.MinCharge, &box.SurgeOccupancy, &box.SurgePercent, &box.VenueID)
+
+	return box, err
+}
@@ -0,0 +1,155 @@
+package sqlite
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/storage"
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// calendarStatuses are the bookings shown in calendar feeds. Cancelled ones
+// stay in the feed, so calendar apps mark their events as cancelled.
+var calendarStatuses = []any{
+	models.BookingStatusActive,
+	models.BookingStatusCancelling,
+	models.BookingStatusCancelled,
+	models.BookingStatusCompleted,
+	models.BookingStatusNoShow,
+}
+
+// CalendarFeed returns the calendar feed of the user, a feed is created on
+// first use.
+func (s *Storage) CalendarFeed(ctx context.Context, email string) (models.CalendarFeed, error) {
+	const op = "storage.sqlite.CalendarFeed"
+
+	token, err := newFeedToken()
+	if err != nil {
+		return models.CalendarFeed{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	feed := models.CalendarFeed{Email: email}
+
+	err = s.db.QueryRowContext(ctx, `
+		INSERT INTO calendar_feeds(email, token, createdAt) VALUES(?, ?, ?)
+		ON CONFLICT (email) DO UPDATE SET email = email
+		RETURNING token
+	`, email, token, time.Now().Unix()).Scan(&feed.Token)
+	if err != nil {
+		return models.CalendarFeed{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return feed, nil
+}
+
+// RotateCalendarFeed replaces the token of the calendar feed of the user, the
+// old feed URL stops working.
+func (s *Storage) RotateCalendarFeed(ctx context.Context, email string) (models.CalendarFeed, error) {
+	const op = "storage.sqlite.RotateCalendarFeed"
+
+	token, err := newFeedToken()
+	if err != nil {
+		return models.CalendarFeed{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO calendar_feeds(email, token, createdAt) VALUES(?, ?, ?)
+		ON CONFLICT (email) DO UPDATE SET token = excluded.token, createdAt = excluded.createdAt
+	`, email, token, time.Now().Unix())
+	if err != nil {
+		return models.CalendarFeed{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return models.CalendarFeed{Email: email, Token: token}, nil
+}
+
+// CalendarFeedByToken returns the calendar feed with the given token.
+func (s *Storage) CalendarFeedByToken(ctx context.Context, token string) (models.CalendarFeed, error) {
+	const op = "storage.sqlite.CalendarFeedByToken"
+
+	feed := models.CalendarFeed{Token: token}
+
+	err := s.db.QueryRowContext(ctx, `SELECT email FROM calendar_feeds WHERE token = ?`, token).Scan(&feed.Email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.CalendarFeed{}, fmt.Errorf("%s: %w", op, storage.ErrCalendarFeedNotFound)
+		}
+		return models.CalendarFeed{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return feed, nil
+}
+
+// UserCalendarBookings returns the bookings of the user and the ones the user
+// joined as a participant starting within [from, to) ordered by start time.
+func (s *Storage) UserCalendarBookings(ctx context.Context, email string, from time.Time, to time.Time) ([]models.Booking, error) {
+	const op = "storage.sqlite.UserCalendarBookings"
+
+	bookings, err := s.calendarBookings(ctx, ownedOrJoined, ownedOrJoinedArgs(email), from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return bookings, nil
+}
+
+// BoxCalendarBookings returns the bookings of the box starting within
+// [from, to) ordered by start time.
+func (s *Storage) BoxCalendarBookings(ctx context.Context, boxName string, from time.Time, to time.Time) ([]models.Booking, error) {
+	const op = "storage.sqlite.BoxCalendarBookings"
+
+	bookings, err := s.calendarBookings(ctx, "boxName = ?", []any{boxName}, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return bookings, nil
+}
+
+// calendarBookings returns the bookings matching condition, which takes
+// conditionArgs, starting within [from, to).
+func (s *Storage) calendarBookings(ctx context.Context, condition string, conditionArgs []any, from time.Time, to time.Time) ([]models.Booking, error) {
+	args := append([]any{}, conditionArgs...)
+	args = append(args, from.Unix(), to.Unix())
+	args = append(args, calendarStatuses...)
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+bookingColumns+` FROM bookings
+		WHERE `+condition+` AND startsAt >= ? AND startsAt < ? AND status IN (?, ?, ?, ?, ?)
+		ORDER BY startsAt, id
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var bookings []models.Booking
+
+	for rows.Next() {
+		b, err := scanBooking(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		bookings = append(bookings, b)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return bookings, nil
+}
+
+func newFeedToken() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
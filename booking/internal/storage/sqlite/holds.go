@@ -0,0 +1,206 @@
+package sqlite
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/storage"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// checkoutHold tells a held booking made by HoldSlot from the one of a
+// waitlist offer.
+const checkoutHold = `NOT EXISTS (SELECT 1 FROM waitlist WHERE waitlist.bookingUid = bookings.uid)`
+
+const holdColumns = "uid, email, boxName, startsAt, expiresAt, peopleAmount, heldUntil"
+
+// HoldSlot reserves the slot of the hold for its user until heldUntil. Like
+// an offer, the hold is a booking in the held status and is only stored if the
+// slot is free. A user has at most maxPerUser active holds, zero means no limit.
+func (s *Storage) HoldSlot(ctx context.Context, hold models.Hold, maxPerUser int64) (models.Hold, error) {
+	const op = "storage.sqlite.HoldSlot"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Hold{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	if maxPerUser > 0 {
+		var active int64
+
+		err := tx.QueryRowContext(ctx, `
+			SELECT COUNT(*) FROM bookings WHERE email = ? AND status = ? AND heldUntil > ? AND `+checkoutHold,
+			hold.Email, models.BookingStatusHeld, time.Now().Unix()).Scan(&active)
+		if err != nil {
+			return models.Hold{}, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if active >= maxPerUser {
+			return models.Hold{}, fmt.Errorf("%s: %w", op, storage.ErrTooManyHolds)
+		}
+	}
+
+	uid, err := uuid.NewV7()
+	if err != nil {
+		return models.Hold{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	pin, err := newAccessPIN()
+	if err != nil {
+		return models.Hold{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	args := []any{uid.String(), hold.Email, hold.BoxName, hold.StartsAt.Unix(), hold.ExpiresAt.Unix(), hold.PeopleAmount,
+		models.BookingStatusHeld, hold.HeldUntil.Unix(), pin, hold.BoxName}
+	args = append(args, slotFreeArgs(hold.BoxName, hold.StartsAt, hold.ExpiresAt, hold.PeopleAmount)...)
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO bookings(uid, email, boxName, startsAt, expiresAt, peopleAmount, status, heldUntil, accessPin)
+		SELECT ?, ?, ?, ?, ?, ?, ?, ?, ?
+		FROM boxes WHERE name = ? AND `+slotFreeCondition, args...)
+	if err != nil {
+		return models.Hold{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	inserted, err := res.RowsAffected()
+	if err != nil {
+		return models.Hold{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if inserted == 0 {
+		return models.Hold{}, fmt.Errorf("%s: %w", op, storage.ErrAlreadyBooked)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Hold{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	hold.UID = uid.String()
+	hold.HeldUntil = time.Unix(hold.HeldUntil.Unix(), 0)
+
+	return hold, nil
+}
+
+// Hold returns the checkout hold with the given ID, also when it has run out
+// but has not been swept yet.
+func (s *Storage) Hold(ctx context.Context, holdUID string) (models.Hold, error) {
+	const op = "storage.sqlite.Hold"
+
+	row := s.db.QueryRowContext(ctx, "SELECT "+holdColumns+" FROM bookings WHERE uid = ? AND status = ? AND "+checkoutHold,
+		holdUID, models.BookingStatusHeld)
+
+	hold, err := scanHold(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Hold{}, fmt.Errorf("%s: %w", op, storage.ErrHoldNotFound)
+		}
+		return models.Hold{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return hold, nil
+}
+
+// ConvertHold turns a hold that has not run out into a pending booking with a
+// book saga, the same state BookABox leaves a new booking in.
+func (s *Storage) ConvertHold(ctx context.Context, hold models.Hold, pricePaid int64) (models.Saga, error) {
+	const op = "storage.sqlite.ConvertHold"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Saga{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	var rowID int64
+
+	err = tx.QueryRowContext(ctx, `
+		UPDATE bookings SET status = ?, pricePaid = ?, heldUntil = NULL
+		WHERE uid = ? AND status = ? AND heldUntil > ? AND `+checkoutHold+`
+		RETURNING id
+	`, models.BookingStatusPending, pricePaid, hold.UID, models.BookingStatusHeld, time.Now().Unix()).Scan(&rowID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Saga{}, fmt.Errorf("%s: %w", op, storage.ErrHoldExpired)
+		}
+		return models.Saga{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	saga, err := insertSaga(ctx, tx, models.SagaKindBook, models.SagaStateReserved, rowID, hold.UID, hold.Email, pricePaid)
+	if err != nil {
+		return models.Saga{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Saga{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return saga, nil
+}
+
+// ReleaseHold gives up a checkout hold that has not been booked, so its slot is
+// free again.
+func (s *Storage) ReleaseHold(ctx context.Context, holdUID string) error {
+	const op = "storage.sqlite.ReleaseHold"
+
+	_, err := s.db.ExecContext(ctx, "UPDATE bookings SET status = ?, heldUntil = NULL WHERE uid = ? AND status = ? AND "+checkoutHold,
+		models.BookingStatusExpired, holdUID, models.BookingStatusHeld)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ExpireHolds releases the checkout holds that ran out by now and returns
+// them. Held bookings of waitlist offers are left to ExpireWaitlist.
+func (s *Storage) ExpireHolds(ctx context.Context, now time.Time) ([]models.Hold, error) {
+	const op = "storage.sqlite.ExpireHolds"
+
+	rows, err := s.db.QueryContext(ctx, `
+		UPDATE bookings SET status = ? WHERE status = ? AND heldUntil <= ? AND `+checkoutHold+`
+		RETURNING `+holdColumns,
+		models.BookingStatusExpired, models.BookingStatusHeld, now.Unix())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var holds []models.Hold
+
+	for rows.Next() {
+		hold, err := scanHold(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		holds = append(holds, hold)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return holds, nil
+}
+
+func scanHold(row scanner) (models.Hold, error) {
+	var (
+		hold                           models.Hold
+		startsAt, expiresAt, heldUntil int64
+	)
+
+	if err := row.Scan(&hold.UID, &hold.Email, &hold.BoxName, &startsAt, &expiresAt, &hold.PeopleAmount, &heldUntil); err != nil {
+		return models.Hold{}, err
+	}
+
+	hold.StartsAt = time.Unix(startsAt, 0)
+	hold.ExpiresAt = time.Unix(expiresAt, 0)
+	hold.HeldUntil = time.Unix(heldUntil, 0)
+
+	return hold, nil
+}
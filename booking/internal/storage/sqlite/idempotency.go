@@ -0,0 +1,107 @@
+package sqlite
+
+import (
+	"booking/internal/domain/models"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// ClaimIdempotencyKey stores the record of a request about to run. When the
+// key of the user is taken already it reports false and returns the stored
+// record instead, with the state of the saga a pending request was left with.
+// A key claimed for the same request before staleBefore without a result is
+// stale, its request died, and the key is claimed again.
+func (s *Storage) ClaimIdempotencyKey(ctx context.Context, record models.IdempotencyRecord, staleBefore time.Time) (models.IdempotencyRecord, bool, error) {
+	const op = "storage.sqlite.ClaimIdempotencyKey"
+
+	// The claim time moves on with the takeover, so of two retries of a stale
+	// key only one gets it.
+	res, err := s.db.ExecContext(ctx, `
+		INSERT INTO idempotency_keys(email, key, operation, request, createdAt) VALUES(?, ?, ?, ?, ?)
+		ON CONFLICT (email, key) DO UPDATE SET createdAt = excluded.createdAt
+		WHERE idempotency_keys.result IS NULL AND idempotency_keys.createdAt < ?
+		AND idempotency_keys.operation = excluded.operation AND idempotency_keys.request = excluded.request
+	`, record.Email, record.Key, record.Operation, record.Request, time.Now().Unix(), staleBefore.Unix())
+	if err != nil {
+		return models.IdempotencyRecord{}, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return models.IdempotencyRecord{}, false, fmt.Errorf("%s: %w", op, err)
+	}
+	if affected > 0 {
+		return record, true, nil
+	}
+
+	var (
+		stored    models.IdempotencyRecord
+		result    sql.NullString
+		sagaID    sql.NullInt64
+		sagaState sql.NullString
+		createdAt int64
+	)
+
+	err = s.db.QueryRowContext(ctx, `
+		SELECT k.email, k.key, k.operation, k.request, k.result, k.sagaId, s.state, k.createdAt
+		FROM idempotency_keys k
+		LEFT JOIN sagas s ON s.id = k.sagaId
+		WHERE k.email = ? AND k.key = ?
+	`, record.Email, record.Key).Scan(&stored.Email, &stored.Key, &stored.Operation, &stored.Request, &result, &sagaID, &sagaState, &createdAt)
+	if err != nil {
+		return models.IdempotencyRecord{}, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if result.Valid {
+		stored.Result = []byte(result.String)
+	}
+	stored.SagaID = sagaID.Int64
+	stored.SagaState = models.SagaState(sagaState.String)
+	stored.CreatedAt = time.Unix(createdAt, 0)
+
+	return stored, false, nil
+}
+
+// SaveIdempotencyResult stores the result of the request claimed with the key.
+func (s *Storage) SaveIdempotencyResult(ctx context.Context, email string, key string, result []byte) error {
+	const op = "storage.sqlite.SaveIdempotencyResult"
+
+	_, err := s.db.ExecContext(ctx, "UPDATE idempotency_keys SET result = ? WHERE email = ? AND key = ?", string(result), email, key)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// SavePendingIdempotencyResult stores the result a request will have once its
+// saga, left to the recovery, has completed.
+func (s *Storage) SavePendingIdempotencyResult(ctx context.Context, email string, key string, sagaID int64, result []byte) error {
+	const op = "storage.sqlite.SavePendingIdempotencyResult"
+
+	_, err := s.db.ExecContext(ctx, "UPDATE idempotency_keys SET result = ?, sagaId = ? WHERE email = ? AND key = ?", string(result), sagaID, email, key)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ReleaseIdempotencyKey forgets the key of a request that failed, so it can
+// be retried. Keys with a result are kept, unless the result was pending on a
+// saga that was rolled back.
+func (s *Storage) ReleaseIdempotencyKey(ctx context.Context, email string, key string) error {
+	const op = "storage.sqlite.ReleaseIdempotencyKey"
+
+	_, err := s.db.ExecContext(ctx, `
+		DELETE FROM idempotency_keys WHERE email = ? AND key = ?
+		AND (result IS NULL OR sagaId IN (SELECT id FROM sagas WHERE state = ?))
+	`, email, key, models.SagaStateCompensated)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,165 @@
+package sqlite
+
+import (
+	"booking/internal/domain/models"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const notificationColumns = "id, uid, key, email, kind, channel, locale, bookingUid, boxName, startsAt, expiresAt, peopleAmount, amount, remindBefore, status, attempts, nextAttemptAt, error, sentAt, createdAt"
+
+// EnqueueNotification stores a pending notification. A notification with the
+// same key is queued already when it reports false.
+func (s *Storage) EnqueueNotification(ctx context.Context, notification models.Notification) (bool, error) {
+	const op = "storage.sqlite.EnqueueNotification"
+
+	uid, err := uuid.NewV7()
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	now := time.Now().Unix()
+
+	res, err := s.db.ExecContext(ctx, `
+		INSERT INTO notifications(uid, key, email, kind, channel, locale, bookingUid, boxName, startsAt, expiresAt,
+			peopleAmount, amount, remindBefore, status, nextAttemptAt, createdAt, updatedAt)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (key) DO NOTHING
+	`, uid.String(), notification.Key, notification.Email, notification.Kind, notification.Channel, notification.Locale,
+		notification.BookingUID, notification.BoxName, notification.StartsAt.Unix(), notification.ExpiresAt.Unix(),
+		notification.PeopleAmount, notification.Amount, int64(notification.RemindBefore/time.Second),
+		models.NotificationStatusPending, notification.NextAttemptAt.Unix(), now, now)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return affected > 0, nil
+}
+
+// DueNotifications returns up to limit pending notifications whose next
+// attempt is due at now, the longest waiting first.
+func (s *Storage) DueNotifications(ctx context.Context, now time.Time, limit int) ([]models.Notification, error) {
+	const op = "storage.sqlite.DueNotifications"
+
+	rows, err := s.db.QueryContext(ctx, "SELECT "+notificationColumns+` FROM notifications
+		WHERE status = ? AND nextAttemptAt <= ?
+		ORDER BY nextAttemptAt, id
+		LIMIT ?
+	`, models.NotificationStatusPending, now.Unix(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var notifications []models.Notification
+
+	for rows.Next() {
+		notification, err := scanNotification(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		notifications = append(notifications, notification)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return notifications, nil
+}
+
+// UpdateNotification stores the delivery state of a notification: status,
+// attempts, next attempt, error and the time it was sent.
+func (s *Storage) UpdateNotification(ctx context.Context, notification models.Notification) error {
+	const op = "storage.sqlite.UpdateNotification"
+
+	var sentAt sql.NullInt64
+	if !notification.SentAt.IsZero() {
+		sentAt = sql.NullInt64{Int64: notification.SentAt.Unix(), Valid: true}
+	}
+
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE notifications SET status = ?, attempts = ?, nextAttemptAt = ?, error = ?, sentAt = ?, updatedAt = ?
+		WHERE id = ?
+	`, notification.Status, notification.Attempts, notification.NextAttemptAt.Unix(), notification.Error, sentAt,
+		time.Now().Unix(), notification.ID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// NotificationLocale returns the locale the user has chosen for
+// notifications, empty when there is none.
+func (s *Storage) NotificationLocale(ctx context.Context, email string) (models.Locale, error) {
+	const op = "storage.sqlite.NotificationLocale"
+
+	var locale models.Locale
+
+	err := s.db.QueryRowContext(ctx, `SELECT locale FROM notification_settings WHERE email = ?`, email).Scan(&locale)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return locale, nil
+}
+
+// SetNotificationLocale stores the locale of the notifications of the user.
+func (s *Storage) SetNotificationLocale(ctx context.Context, email string, locale models.Locale) error {
+	const op = "storage.sqlite.SetNotificationLocale"
+
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO notification_settings(email, locale, updatedAt) VALUES(?, ?, ?)
+		ON CONFLICT (email) DO UPDATE SET locale = excluded.locale, updatedAt = excluded.updatedAt
+	`, email, locale, time.Now().Unix())
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func scanNotification(row scanner) (models.Notification, error) {
+	var (
+		notification  models.Notification
+		startsAt      int64
+		expiresAt     int64
+		remindBefore  int64
+		nextAttemptAt int64
+		sentAt        sql.NullInt64
+		createdAt     int64
+	)
+
+	if err := row.Scan(&notification.ID, &notification.UID, &notification.Key, &notification.Email, &notification.Kind,
+		&notification.Channel, &notification.Locale, &notification.BookingUID, &notification.BoxName, &startsAt,
+		&expiresAt, &notification.PeopleAmount, &notification.Amount, &remindBefore, &notification.Status,
+		&notification.Attempts, &nextAttemptAt, &notification.Error, &sentAt, &createdAt); err != nil {
+		return models.Notification{}, err
+	}
+
+	notification.StartsAt = time.Unix(startsAt, 0)
+	notification.ExpiresAt = time.Unix(expiresAt, 0)
+	notification.RemindBefore = time.Duration(remindBefore) * time.Second
+	notification.NextAttemptAt = time.Unix(nextAttemptAt, 0)
+	notification.CreatedAt = time.Unix(createdAt, 0)
+	if sentAt.Valid {
+		notification.SentAt = time.Unix(sentAt.Int64, 0)
+	}
+
+	return notification, nil
+}
@@ -0,0 +1,189 @@
+package sqlite
+
+import (
+	"booking/internal/domain/models"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// bookingEvent is the payload of booking events. Amount is the price paid of
+// created bookings and the money given back otherwise.
+type bookingEvent struct {
+	BookingUID   string    `json:"bookingId"`
+	Email        string    `json:"email"`
+	BoxName      string    `json:"boxName"`
+	StartsAt     time.Time `json:"startsAt"`
+	ExpiresAt    time.Time `json:"expiresAt"`
+	PeopleAmount int64     `json:"peopleAmount"`
+	PricePaid    int64     `json:"pricePaid"`
+	Amount       int64     `json:"amount"`
+	Status       string    `json:"status"`
+	SeriesUID    string    `json:"seriesId,omitempty"`
+	Revision     int64     `json:"revision"`
+	OccurredAt   time.Time `json:"occurredAt"`
+}
+
+// addEvent writes an event about the booking with the given row ID into the
+// outbox, as part of the transaction changing the booking.
+func addEvent(ctx context.Context, tx *sql.Tx, eventType models.EventType, bookingRowID int64, amount int64) error {
+	row := tx.QueryRowContext(ctx, "SELECT "+bookingColumns+" FROM bookings WHERE id = ?", bookingRowID)
+
+	booking, err := scanBooking(row)
+	if err != nil {
+		return err
+	}
+
+	uid, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	payload, err := json.Marshal(bookingEvent{
+		BookingUID:   booking.UID,
+		Email:        booking.Email,
+		BoxName:      booking.BoxName,
+		StartsAt:     booking.StartsAt.UTC(),
+		ExpiresAt:    booking.ExpiresAt.UTC(),
+		PeopleAmount: booking.PeopleAmount,
+		PricePaid:    booking.PricePaid,
+		Amount:       amount,
+		Status:       string(booking.Status),
+		SeriesUID:    booking.SeriesUID,
+		Revision:     booking.Revision,
+		OccurredAt:   now.UTC().Truncate(time.Second),
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO outbox(uid, type, bookingUid, payload, nextAttemptAt, createdAt) VALUES(?, ?, ?, ?, ?, ?)
+	`, uid.String(), eventType, booking.UID, string(payload), now.Unix(), now.Unix())
+
+	return err
+}
+
+// PendingEvents returns up to limit unpublished events in outbox order,
+// stopping at the first one that waits for a retry so events keep their order.
+func (s *Storage) PendingEvents(ctx context.Context, now time.Time, limit int) ([]models.Event, error) {
+	const op = "storage.sqlite.PendingEvents"
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, uid, type, bookingUid, payload, attempts, error, createdAt, nextAttemptAt FROM outbox
+		WHERE publishedAt IS NULL
+		ORDER BY id
+		LIMIT ?
+	`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var events []models.Event
+
+	for rows.Next() {
+		var (
+			event         models.Event
+			payload       string
+			createdAt     int64
+			nextAttemptAt int64
+		)
+
+		if err := rows.Scan(&event.ID, &event.UID, &event.Type, &event.BookingUID, &payload, &event.Attempts,
+			&event.Error, &createdAt, &nextAttemptAt); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if nextAttemptAt > now.Unix() {
+			break
+		}
+
+		event.Payload = []byte(payload)
+		event.CreatedAt = time.Unix(createdAt, 0)
+
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return events, nil
+}
+
+// EventPublished marks the event as published.
+func (s *Storage) EventPublished(ctx context.Context, eventID int64) error {
+	const op = "storage.sqlite.EventPublished"
+
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE outbox SET publishedAt = ?, attempts = attempts + 1, error = '' WHERE id = ?
+	`, time.Now().Unix(), eventID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// EventFailed records a failed attempt to publish the event, the next one is
+// made at nextAttemptAt.
+func (s *Storage) EventFailed(ctx context.Context, eventID int64, nextAttemptAt time.Time, reason string) error {
+	const op = "storage.sqlite.EventFailed"
+
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE outbox SET attempts = attempts + 1, nextAttemptAt = ?, error = ? WHERE id = ?
+	`, nextAttemptAt.Unix(), reason, eventID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ReplayEvents marks the published events matching the filter as pending
+// again, so the relay publishes them once more. It returns how many there are.
+func (s *Storage) ReplayEvents(ctx context.Context, filter models.EventFilter) (int64, error) {
+	const op = "storage.sqlite.ReplayEvents"
+
+	conditions := []string{"publishedAt IS NOT NULL"}
+	args := []any{time.Now().Unix()}
+
+	if filter.FromID > 0 {
+		conditions = append(conditions, "id >= ?")
+		args = append(args, filter.FromID)
+	}
+	if !filter.Since.IsZero() {
+		conditions = append(conditions, "createdAt >= ?")
+		args = append(args, filter.Since.Unix())
+	}
+	if filter.Type != "" {
+		conditions = append(conditions, "type = ?")
+		args = append(args, filter.Type)
+	}
+	if filter.BookingUID != "" {
+		conditions = append(conditions, "bookingUid = ?")
+		args = append(args, filter.BookingUID)
+	}
+
+	res, err := s.db.ExecContext(ctx, `
+		UPDATE outbox SET publishedAt = NULL, nextAttemptAt = ?, error = ''
+		WHERE `+strings.Join(conditions, " AND "), args...)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	replayed, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return replayed, nil
+}
@@ -0,0 +1,200 @@
+package sqlite
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/storage"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const participantColumns = "bookings.uid, booking_participants.email, booking_participants.status, booking_participants.invitedAt, booking_participants.respondedAt"
+
+// InviteParticipant invites the email to the booking. The booking takes at
+// most maxParticipants invited and accepted participants, the check is part
+// of the INSERT so concurrent invitations can't go over it. A participant who
+// declined is invited again.
+func (s *Storage) InviteParticipant(ctx context.Context, bookingUID string, email string, maxParticipants int64) (models.Participant, error) {
+	const op = "storage.sqlite.InviteParticipant"
+
+	res, err := s.db.ExecContext(ctx, `
+		INSERT INTO booking_participants(bookingId, email, status, invitedAt)
+		SELECT id, ?, ?, ? FROM bookings
+		WHERE uid = ?
+		AND (SELECT COUNT(*) FROM booking_participants WHERE bookingId = bookings.id AND status IN (?, ?)) < ?
+		ON CONFLICT (bookingId, email) DO UPDATE SET status = excluded.status, invitedAt = excluded.invitedAt, respondedAt = NULL
+		WHERE booking_participants.status = ?
+	`, email, models.ParticipantStatusInvited, time.Now().Unix(), bookingUID,
+		models.ParticipantStatusInvited, models.ParticipantStatusAccepted, maxParticipants,
+		models.ParticipantStatusDeclined)
+	if err != nil {
+		return models.Participant{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	invited, err := res.RowsAffected()
+	if err != nil {
+		return models.Participant{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	participant, err := s.Participant(ctx, bookingUID, email)
+	if err != nil && !errors.Is(err, storage.ErrInvitationNotFound) {
+		return models.Participant{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if invited == 0 {
+		if err == nil && participant.Status != models.ParticipantStatusDeclined {
+			return models.Participant{}, fmt.Errorf("%s: %w", op, storage.ErrAlreadyInvited)
+		}
+		return models.Participant{}, fmt.Errorf("%s: %w", op, storage.ErrTooManyParticipants)
+	}
+
+	if err != nil {
+		return models.Participant{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return participant, nil
+}
+
+// Participant returns the invitation of the email to the booking.
+func (s *Storage) Participant(ctx context.Context, bookingUID string, email string) (models.Participant, error) {
+	const op = "storage.sqlite.Participant"
+
+	row := s.db.QueryRowContext(ctx, `
+		SELECT `+participantColumns+` FROM booking_participants
+		JOIN bookings ON bookings.id = booking_participants.bookingId
+		WHERE bookings.uid = ? AND booking_participants.email = ?
+	`, bookingUID, email)
+
+	participant, err := scanParticipant(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Participant{}, fmt.Errorf("%s: %w", op, storage.ErrInvitationNotFound)
+		}
+		return models.Participant{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return participant, nil
+}
+
+// RespondToInvitation accepts or declines the invitation. An accepted one can
+// still be declined, a declined one can't be accepted until the owner invites
+// the participant again.
+func (s *Storage) RespondToInvitation(ctx context.Context, bookingUID string, email string, status models.ParticipantStatus) (models.Participant, error) {
+	const op = "storage.sqlite.RespondToInvitation"
+
+	// The statuses the invitation can be answered from.
+	from := []any{models.ParticipantStatusInvited, models.ParticipantStatusAccepted, models.ParticipantStatusAccepted}
+	if status == models.ParticipantStatusDeclined {
+		from[2] = models.ParticipantStatusDeclined
+	}
+
+	args := []any{status, status, time.Now().Unix(), bookingUID, email}
+	args = append(args, from...)
+
+	res, err := s.db.ExecContext(ctx, `
+		UPDATE booking_participants SET status = ?, respondedAt = CASE WHEN status = ? THEN respondedAt ELSE ? END
+		WHERE bookingId = (SELECT id FROM bookings WHERE uid = ?) AND email = ? AND status IN (?, ?, ?)
+	`, args...)
+	if err != nil {
+		return models.Participant{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	updated, err := res.RowsAffected()
+	if err != nil {
+		return models.Participant{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	participant, err := s.Participant(ctx, bookingUID, email)
+	if err != nil {
+		return models.Participant{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if updated == 0 {
+		return models.Participant{}, fmt.Errorf("%s: %w", op, storage.ErrInvitationDeclined)
+	}
+
+	return participant, nil
+}
+
+// Participants returns everyone invited to the booking in the order they were
+// invited.
+func (s *Storage) Participants(ctx context.Context, bookingUID string) ([]models.Participant, error) {
+	const op = "storage.sqlite.Participants"
+
+	participants, err := s.participants(ctx, `
+		SELECT `+participantColumns+` FROM booking_participants
+		JOIN bookings ON bookings.id = booking_participants.bookingId
+		WHERE bookings.uid = ?
+		ORDER BY booking_participants.invitedAt, booking_participants.id
+	`, bookingUID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return participants, nil
+}
+
+// Invitations returns the unanswered invitations of the email to active
+// bookings that have not started yet, the soonest first.
+func (s *Storage) Invitations(ctx context.Context, email string, now time.Time) ([]models.Participant, error) {
+	const op = "storage.sqlite.Invitations"
+
+	participants, err := s.participants(ctx, `
+		SELECT `+participantColumns+` FROM booking_participants
+		JOIN bookings ON bookings.id = booking_participants.bookingId
+		WHERE booking_participants.email = ? AND booking_participants.status = ?
+		AND bookings.status = ? AND bookings.startsAt > ?
+		ORDER BY bookings.startsAt, bookings.id
+	`, email, models.ParticipantStatusInvited, models.BookingStatusActive, now.Unix())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return participants, nil
+}
+
+func (s *Storage) participants(ctx context.Context, query string, args ...any) ([]models.Participant, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var participants []models.Participant
+
+	for rows.Next() {
+		participant, err := scanParticipant(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		participants = append(participants, participant)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return participants, nil
+}
+
+func scanParticipant(row scanner) (models.Participant, error) {
+	var (
+		participant models.Participant
+		invitedAt   int64
+		respondedAt sql.NullInt64
+	)
+
+	if err := row.Scan(&participant.BookingUID, &participant.Email, &participant.Status, &invitedAt, &respondedAt); err != nil {
+		return models.Participant{}, err
+	}
+
+	participant.InvitedAt = time.Unix(invitedAt, 0)
+	if respondedAt.Valid {
+		participant.RespondedAt = time.Unix(respondedAt.Int64, 0)
+	}
+
+	return participant, nil
+}
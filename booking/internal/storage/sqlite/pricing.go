@@ -0,0 +1,74 @@
+package sqlite
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/storage"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// PriceRules returns the rules of the box together with the rules of every box.
+func (s *Storage) PriceRules(ctx context.Context, boxName string) ([]models.PriceRule, error) {
+	const op = "storage.sqlite.PriceRules"
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT name, boxName, weekday, startsAt, endsAt, percent FROM price_rules
+		WHERE boxName = '' OR boxName = ?
+		ORDER BY id
+	`, boxName)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var rules []models.PriceRule
+
+	for rows.Next() {
+		var (
+			rule    models.PriceRule
+			weekday sql.NullInt64
+		)
+
+		if err := rows.Scan(&rule.Name, &rule.BoxName, &weekday, &rule.StartsAt, &rule.EndsAt, &rule.Percent); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if weekday.Valid {
+			day := time.Weekday(weekday.Int64)
+			rule.Weekday = &day
+		}
+
+		rules = append(rules, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return rules, nil
+}
+
+// HolidayRate returns the holiday rate of the box on the date given as
+// YYYY-MM-DD. A rate set for the box wins over a rate set for every box.
+func (s *Storage) HolidayRate(ctx context.Context, boxName string, date string) (models.HolidayRate, error) {
+	const op = "storage.sqlite.HolidayRate"
+
+	var rate models.HolidayRate
+
+	err := s.db.QueryRowContext(ctx, `
+		SELECT name, boxName, date, percent FROM holiday_rates
+		WHERE date = ? AND (boxName = '' OR boxName = ?)
+		ORDER BY boxName DESC LIMIT 1
+	`, date, boxName).Scan(&rate.Name, &rate.BoxName, &rate.Date, &rate.Percent)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.HolidayRate{}, fmt.Errorf("%s: %w", op, storage.ErrHolidayRateNotFound)
+		}
+		return models.HolidayRate{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return rate, nil
+}
@@ -0,0 +1,125 @@
+package sqlite
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/storage"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// PromoCode returns the promo code, the code is case insensitive.
+func (s *Storage) PromoCode(ctx context.Context, code string) (models.PromoCode, error) {
+	const op = "storage.sqlite.PromoCode"
+
+	var (
+		promo                 models.PromoCode
+		validFrom, validUntil sql.NullInt64
+	)
+
+	err := s.db.QueryRowContext(ctx, `
+		SELECT code, kind, value, boxName, startsAt, endsAt, validFrom, validUntil, maxRedemptions, maxPerUser
+		FROM promo_codes WHERE code = ?
+	`, code).Scan(&promo.Code, &promo.Kind, &promo.Value, &promo.BoxName, &promo.StartsAt, &promo.EndsAt,
+		&validFrom, &validUntil, &promo.MaxRedemptions, &promo.MaxPerUser)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.PromoCode{}, fmt.Errorf("%s: %w", op, storage.ErrPromoCodeNotFound)
+		}
+		return models.PromoCode{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if validFrom.Valid {
+		promo.ValidFrom = time.Unix(validFrom.Int64, 0)
+	}
+	if validUntil.Valid {
+		promo.ValidUntil = time.Unix(validUntil.Int64, 0)
+	}
+
+	return promo, nil
+}
+
+// PromoUsage returns the redemptions of the code that were not given back, in
+// total and by the user.
+func (s *Storage) PromoUsage(ctx context.Context, code string, email string) (models.PromoUsage, error) {
+	const op = "storage.sqlite.PromoUsage"
+
+	var usage models.PromoUsage
+
+	err := s.db.QueryRowContext(ctx, `
+		SELECT COUNT(*), COUNT(CASE WHEN email = ? THEN 1 END)
+		FROM promo_redemptions WHERE code = ? AND returnedAt IS NULL
+	`, email, code).Scan(&usage.Total, &usage.ByUser)
+	if err != nil {
+		return models.PromoUsage{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return usage, nil
+}
+
+// RedeemPromoCode records the use of the code by the booking. The limits of
+// the code are checked by the INSERT itself, so concurrent bookings can't
+// redeem it more often than allowed.
+func (s *Storage) RedeemPromoCode(ctx context.Context, promo models.PromoCode, email string, bookingUID string, discount int64) error {
+	const op = "storage.sqlite.RedeemPromoCode"
+
+	res, err := s.db.ExecContext(ctx, `
+		INSERT INTO promo_redemptions(code, email, bookingId, discount, createdAt)
+		SELECT ?, ?, id, ?, ? FROM bookings
+		WHERE uid = ?
+		AND (? = 0 OR (SELECT COUNT(*) FROM promo_redemptions WHERE code = ? AND returnedAt IS NULL) < ?)
+		AND (? = 0 OR (SELECT COUNT(*) FROM promo_redemptions WHERE code = ? AND email = ? AND returnedAt IS NULL) < ?)
+	`, promo.Code, email, discount, time.Now().Unix(), bookingUID,
+		promo.MaxRedemptions, promo.Code, promo.MaxRedemptions,
+		promo.MaxPerUser, promo.Code, email, promo.MaxPerUser)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	inserted, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if inserted == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrPromoCodeExhausted)
+	}
+
+	return nil
+}
+
+// PromoDiscount returns the discount the booking got with a promo code, zero
+// without one.
+func (s *Storage) PromoDiscount(ctx context.Context, bookingUID string) (string, int64, error) {
+	const op = "storage.sqlite.PromoDiscount"
+
+	var (
+		code     string
+		discount int64
+	)
+
+	err := s.db.QueryRowContext(ctx, `
+		SELECT promo_redemptions.code, promo_redemptions.discount FROM promo_redemptions
+		JOIN bookings ON bookings.id = promo_redemptions.bookingId
+		WHERE bookings.uid = ?
+	`, bookingUID).Scan(&code, &discount)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", 0, nil
+		}
+		return "", 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return code, discount, nil
+}
+
+// returnPromoCode gives the redemption of the booking back to its code.
+func returnPromoCode(ctx context.Context, tx *sql.Tx, bookingRowID int64, now int64) error {
+	_, err := tx.ExecContext(ctx, `
+		UPDATE promo_redemptions SET returnedAt = ? WHERE bookingId = ? AND returnedAt IS NULL
+	`, now, bookingRowID)
+
+	return err
+}
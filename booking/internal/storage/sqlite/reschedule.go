@@ -0,0 +1,115 @@
+package sqlite
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/storage"
+	"context"
+	"fmt"
+	"time"
+)
+
+// ReserveReschedule records the charge of the price difference of a booking
+// that is about to be moved, before the wallet is charged.
+func (s *Storage) ReserveReschedule(ctx context.Context, booking models.Booking, amount int64) (models.Saga, error) {
+	const op = "storage.sqlite.ReserveReschedule"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Saga{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	saga, err := insertSaga(ctx, tx, models.SagaKindReschedule, models.SagaStateReserved, booking.ID/reserveIDFactor, booking.UID, booking.Email, amount)
+	if err != nil {
+		return models.Saga{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Saga{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return saga, nil
+}
+
+// RescheduleBooking moves an active booking that has not started yet to the
+// box, time and price of moved. The booking is only moved if it has not
+// changed since it was read and the new slot has room with the booking itself
+// left out.
+//
+// chargeSagaID is the reschedule saga that charged a higher price, it is
+// completed together with the move. A lower price is recorded in a new
+// reschedule saga refunding the difference, which is returned.
+func (s *Storage) RescheduleBooking(ctx context.Context, booking models.Booking, moved models.Booking, chargeSagaID int64) (models.Saga, error) {
+	const op = "storage.sqlite.RescheduleBooking"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Saga{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now().Unix()
+
+	unchanged := []any{booking.UID, models.BookingStatusActive, booking.BoxName, booking.StartsAt.Unix(), booking.ExpiresAt.Unix(), now}
+
+	args := []any{moved.BoxName, moved.StartsAt.Unix(), moved.ExpiresAt.Unix(), moved.PricePaid}
+	args = append(args, unchanged...)
+	args = append(args, moved.BoxName)
+	args = append(args, slotFreeArgsExcept(booking.UID, moved.BoxName, moved.StartsAt, moved.ExpiresAt, booking.PeopleAmount)...)
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE bookings SET boxName = ?, startsAt = ?, expiresAt = ?, pricePaid = ?, revision = revision + 1
+		WHERE uid = ? AND status = ? AND boxName = ? AND startsAt = ? AND expiresAt = ? AND startsAt > ?
+		AND EXISTS (SELECT 1 FROM boxes WHERE name = ? AND `+slotFreeCondition+`)
+	`, args...)
+	if err != nil {
+		return models.Saga{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	updated, err := res.RowsAffected()
+	if err != nil {
+		return models.Saga{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if updated == 0 {
+		var exists bool
+
+		err := tx.QueryRowContext(ctx, `
+			SELECT EXISTS (
+				SELECT 1 FROM bookings
+				WHERE uid = ? AND status = ? AND boxName = ? AND startsAt = ? AND expiresAt = ? AND startsAt > ?
+			)
+		`, unchanged...).Scan(&exists)
+		if err != nil {
+			return models.Saga{}, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if !exists {
+			return models.Saga{}, fmt.Errorf("%s: %w", op, storage.ErrBookingNotActive)
+		}
+
+		return models.Saga{}, fmt.Errorf("%s: %w", op, storage.ErrAlreadyBooked)
+	}
+
+	var saga models.Saga
+
+	switch {
+	case chargeSagaID > 0:
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE sagas SET state = ?, updatedAt = ? WHERE id = ?
+		`, models.SagaStateCompleted, now, chargeSagaID); err != nil {
+			return models.Saga{}, fmt.Errorf("%s: %w", op, err)
+		}
+	case moved.PricePaid < booking.PricePaid:
+		saga, err = insertSaga(ctx, tx, models.SagaKindReschedule, models.SagaStateRefunding, booking.ID/reserveIDFactor, booking.UID, booking.Email, booking.PricePaid-moved.PricePaid)
+		if err != nil {
+			return models.Saga{}, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Saga{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return saga, nil
+}
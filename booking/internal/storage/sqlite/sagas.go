@@ -0,0 +1,300 @@
+package sqlite
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/storage"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+func insertSaga(ctx context.Context, tx *sql.Tx, kind models.SagaKind, state models.SagaState, bookingRowID int64, bookingUID string, email string, amount int64) (models.Saga, error) {
+	now := time.Now()
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO sagas(kind, state, bookingId, email, amount, createdAt, updatedAt) VALUES(?, ?, ?, ?, ?, ?, ?)
+	`, kind, state, bookingRowID, email, amount, now.Unix(), now.Unix())
+	if err != nil {
+		return models.Saga{}, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return models.Saga{}, err
+	}
+
+	return models.Saga{
+		ID:              id,
+		Kind:            kind,
+		State:           state,
+		BookingID:       bookingUID,
+		LegacyBookingID: reserveIDFactor * bookingRowID,
+		Email:           email,
+		Amount:          amount,
+		UpdatedAt:       time.Unix(now.Unix(), 0),
+	}, nil
+}
+
+// SetSagaState records the step the saga has reached.
+func (s *Storage) SetSagaState(ctx context.Context, sagaID int64, state models.SagaState, reason string) error {
+	const op = "storage.sqlite.SetSagaState"
+
+	res, err := s.db.ExecContext(ctx, `
+		UPDATE sagas SET state = ?, error = ?, updatedAt = ? WHERE id = ?
+	`, state, reason, time.Now().Unix(), sagaID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrSagaNotFound)
+	}
+
+	return nil
+}
+
+// SetSagaPayment records the idempotency key and the total of the payment
+// the sagas are charged with together.
+func (s *Storage) SetSagaPayment(ctx context.Context, sagaIDs []int64, paymentKey string, amount int64) error {
+	const op = "storage.sqlite.SetSagaPayment"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	for _, sagaID := range sagaIDs {
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE sagas SET paymentKey = ?, paymentAmount = ?, updatedAt = ? WHERE id = ?
+		`, paymentKey, amount, time.Now().Unix(), sagaID); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ConfirmBooking activates the pending booking of a charged saga and completes the saga.
+func (s *Storage) ConfirmBooking(ctx context.Context, sagaID int64) error {
+	const op = "storage.sqlite.ConfirmBooking"
+
+	if err := s.finishSaga(ctx, sagaID, models.BookingStatusPending, models.BookingStatusActive, models.SagaStateCompleted, "", models.EventBookingCreated); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ReleaseBooking frees the slot held by a pending booking and closes the saga
+// with the given final state.
+func (s *Storage) ReleaseBooking(ctx context.Context, sagaID int64, state models.SagaState, reason string) error {
+	const op = "storage.sqlite.ReleaseBooking"
+
+	if err := s.finishSaga(ctx, sagaID, models.BookingStatusPending, models.BookingStatusFailed, state, reason, ""); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// RefundBooking releases the slot of a booking whose charge was given back
+// and compensates the saga.
+func (s *Storage) RefundBooking(ctx context.Context, sagaID int64, reason string) error {
+	const op = "storage.sqlite.RefundBooking"
+
+	if err := s.finishSaga(ctx, sagaID, models.BookingStatusPending, models.BookingStatusFailed, models.SagaStateCompensated, reason, models.EventBookingRefunded); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// RefundSaga records that the refund of a cancel or reschedule saga was paid
+// by moving the saga to state. A refund of more than zero is published as an
+// event.
+func (s *Storage) RefundSaga(ctx context.Context, sagaID int64, state models.SagaState, reason string) error {
+	const op = "storage.sqlite.RefundSaga"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	var bookingRowID, amount int64
+
+	err = tx.QueryRowContext(ctx, `
+		UPDATE sagas SET state = ?, error = ?, updatedAt = ? WHERE id = ?
+		RETURNING bookingId, amount
+	`, state, reason, time.Now().Unix(), sagaID).Scan(&bookingRowID, &amount)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, storage.ErrSagaNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if amount > 0 {
+		if err := addEvent(ctx, tx, models.EventBookingRefunded, bookingRowID, amount); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// FinishCancel marks the booking of a refunded cancellation saga as cancelled.
+func (s *Storage) FinishCancel(ctx context.Context, sagaID int64) error {
+	const op = "storage.sqlite.FinishCancel"
+
+	if err := s.finishSaga(ctx, sagaID, models.BookingStatusCancelling, models.BookingStatusCancelled, models.SagaStateCompleted, "", models.EventBookingCancelled); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// AbortCancel returns the booking of a failed cancellation saga to active.
+func (s *Storage) AbortCancel(ctx context.Context, sagaID int64, reason string) error {
+	const op = "storage.sqlite.AbortCancel"
+
+	if err := s.finishSaga(ctx, sagaID, models.BookingStatusCancelling, models.BookingStatusActive, models.SagaStateCompensated, reason, ""); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// finishSaga moves the booking of the saga from one status to another and
+// stores the final saga state, the event of the change, if any, the returned
+// promo code and the failed waitlist entry in the same transaction.
+func (s *Storage) finishSaga(ctx context.Context, sagaID int64, from models.BookingStatus, to models.BookingStatus, state models.SagaState, reason string, event models.EventType) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var bookingRowID, amount int64
+
+	err = tx.QueryRowContext(ctx, `SELECT bookingId, amount FROM sagas WHERE id = ?`, sagaID).Scan(&bookingRowID, &amount)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return storage.ErrSagaNotFound
+		}
+		return err
+	}
+
+	now := time.Now().Unix()
+
+	query := "UPDATE bookings SET status = ?"
+	args := []any{to}
+	if to == models.BookingStatusCancelled {
+		query += ", cancelledAt = ?, revision = revision + 1"
+		args = append(args, now)
+	}
+	query += " WHERE id = ? AND status = ?"
+	args = append(args, bookingRowID, from)
+
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return storage.ErrBookingNotActive
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE sagas SET state = ?, error = ?, updatedAt = ? WHERE id = ?
+	`, state, reason, now, sagaID); err != nil {
+		return err
+	}
+
+	if event != "" {
+		if err := addEvent(ctx, tx, event, bookingRowID, amount); err != nil {
+			return err
+		}
+	}
+
+	// A booking that failed or was cancelled gives its promo code back.
+	if to == models.BookingStatusFailed || to == models.BookingStatusCancelled {
+		if err := returnPromoCode(ctx, tx, bookingRowID, now); err != nil {
+			return err
+		}
+	}
+
+	// A waitlist entry booked before its payment went through fails with the
+	// booking, so it isn't left booked without one.
+	if to == models.BookingStatusFailed {
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE waitlist SET status = ?, error = ?, updatedAt = ?
+			WHERE bookingUid = (SELECT uid FROM bookings WHERE id = ?) AND status = ?
+		`, models.WaitlistStatusFailed, reason, now, bookingRowID, models.WaitlistStatusBooked); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// StaleSagas returns the unfinished sagas that have not moved since before.
+func (s *Storage) StaleSagas(ctx context.Context, before time.Time) ([]models.Saga, error) {
+	const op = "storage.sqlite.StaleSagas"
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT s.id, s.kind, s.state, b.uid, b.id, s.email, s.amount, s.error, s.paymentKey, s.paymentAmount, s.updatedAt
+		FROM sagas s JOIN bookings b ON b.id = s.bookingId
+		WHERE s.state NOT IN (?, ?, ?) AND s.updatedAt < ?
+		ORDER BY s.updatedAt
+	`, models.SagaStateCompleted, models.SagaStateCompensated, models.SagaStateFailed, before.Unix())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var sagas []models.Saga
+
+	for rows.Next() {
+		var (
+			saga      models.Saga
+			updatedAt int64
+		)
+
+		if err := rows.Scan(&saga.ID, &saga.Kind, &saga.State, &saga.BookingID, &saga.LegacyBookingID, &saga.Email, &saga.Amount, &saga.Error, &saga.PaymentKey, &saga.PaymentAmount, &updatedAt); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		saga.LegacyBookingID *= reserveIDFactor
+		saga.UpdatedAt = time.Unix(updatedAt, 0)
+
+		sagas = append(sagas, saga)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return sagas, nil
+}
@@ -0,0 +1,265 @@
+package sqlite
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/storage"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WeeklyHours returns the weekly opening hours of the box ordered by weekday.
+func (s *Storage) WeeklyHours(ctx context.Context, boxName string) ([]models.WeeklyHours, error) {
+	const op = "storage.sqlite.WeeklyHours"
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT weekday, opensAt, closesAt, closed FROM opening_hours
+		WHERE boxName = ?
+		ORDER BY weekday
+	`, boxName)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var hours []models.WeeklyHours
+
+	for rows.Next() {
+		var h models.WeeklyHours
+
+		if err := rows.Scan(&h.Weekday, &h.OpensAt, &h.ClosesAt, &h.Closed); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		hours = append(hours, h)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return hours, nil
+}
+
+// SetWeeklyHours replaces the weekly opening hours of the box. Weekdays left
+// out fall back to the daily hours of the box.
+func (s *Storage) SetWeeklyHours(ctx context.Context, boxName string, hours []models.WeeklyHours) error {
+	const op = "storage.sqlite.SetWeeklyHours"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM opening_hours WHERE boxName = ?`, boxName); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, h := range hours {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO opening_hours(boxName, weekday, opensAt, closesAt, closed) VALUES(?, ?, ?, ?, ?)
+		`, boxName, h.Weekday, h.OpensAt, h.ClosesAt, h.Closed); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Closure returns the closure of the box on the date given as YYYY-MM-DD. A
+// closure of the box wins over a closure of every box.
+func (s *Storage) Closure(ctx context.Context, boxName string, date string) (models.Closure, error) {
+	const op = "storage.sqlite.Closure"
+
+	row := s.db.QueryRowContext(ctx, `
+		SELECT `+closureColumns+` FROM closures
+		WHERE date = ? AND (boxName = '' OR boxName = ?)
+		ORDER BY boxName DESC LIMIT 1
+	`, date, boxName)
+
+	closure, err := scanClosure(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Closure{}, fmt.Errorf("%s: %w", op, storage.ErrClosureNotFound)
+		}
+		return models.Closure{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return closure, nil
+}
+
+// Closures returns the closures of the box and of every box between the
+// dates from and to, both YYYY-MM-DD and inclusive, ordered by date.
+func (s *Storage) Closures(ctx context.Context, boxName string, from string, to string) ([]models.Closure, error) {
+	const op = "storage.sqlite.Closures"
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+closureColumns+` FROM closures
+		WHERE (boxName = '' OR boxName = ?) AND date >= ? AND date <= ?
+		ORDER BY date, boxName
+	`, boxName, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var closures []models.Closure
+
+	for rows.Next() {
+		closure, err := scanClosure(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		closures = append(closures, closure)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return closures, nil
+}
+
+// AddClosure stores a closure. A second closure of the same box on the same
+// date replaces the first one.
+func (s *Storage) AddClosure(ctx context.Context, closure models.Closure) (models.Closure, error) {
+	const op = "storage.sqlite.AddClosure"
+
+	uid, err := uuid.NewV7()
+	if err != nil {
+		return models.Closure{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	closure.UID = uid.String()
+
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO closures(uid, boxName, date, kind, name, createdAt) VALUES(?, ?, ?, ?, ?, ?)
+		ON CONFLICT (boxName, date) DO UPDATE SET uid = excluded.uid, kind = excluded.kind, name = excluded.name
+	`, closure.UID, closure.BoxName, closure.Date, closure.Kind, closure.Name, time.Now().Unix())
+	if err != nil {
+		return models.Closure{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return closure, nil
+}
+
+func (s *Storage) RemoveClosure(ctx context.Context, closureUID string) error {
+	const op = "storage.sqlite.RemoveClosure"
+
+	if err := s.deleteByUID(ctx, "closures", closureUID, storage.ErrClosureNotFound); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Blackouts returns the blackouts of the box overlapping [from, to), ordered
+// by start time.
+func (s *Storage) Blackouts(ctx context.Context, boxName string, from time.Time, to time.Time) ([]models.Blackout, error) {
+	const op = "storage.sqlite.Blackouts"
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT uid, boxName, startsAt, endsAt, reason FROM blackouts
+		WHERE boxName = ? AND startsAt < ? AND endsAt > ?
+		ORDER BY startsAt
+	`, boxName, to.Unix(), from.Unix())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var blackouts []models.Blackout
+
+	for rows.Next() {
+		var (
+			blackout models.Blackout
+			startsAt int64
+			endsAt   int64
+		)
+
+		if err := rows.Scan(&blackout.UID, &blackout.BoxName, &startsAt, &endsAt, &blackout.Reason); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		blackout.StartsAt = time.Unix(startsAt, 0)
+		blackout.EndsAt = time.Unix(endsAt, 0)
+
+		blackouts = append(blackouts, blackout)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return blackouts, nil
+}
+
+func (s *Storage) AddBlackout(ctx context.Context, blackout models.Blackout) (models.Blackout, error) {
+	const op = "storage.sqlite.AddBlackout"
+
+	uid, err := uuid.NewV7()
+	if err != nil {
+		return models.Blackout{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	blackout.UID = uid.String()
+
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO blackouts(uid, boxName, startsAt, endsAt, reason, createdAt) VALUES(?, ?, ?, ?, ?, ?)
+	`, blackout.UID, blackout.BoxName, blackout.StartsAt.Unix(), blackout.EndsAt.Unix(), blackout.Reason, time.Now().Unix())
+	if err != nil {
+		return models.Blackout{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return blackout, nil
+}
+
+func (s *Storage) RemoveBlackout(ctx context.Context, blackoutUID string) error {
+	const op = "storage.sqlite.RemoveBlackout"
+
+	if err := s.deleteByUID(ctx, "blackouts", blackoutUID, storage.ErrBlackoutNotFound); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// deleteByUID deletes the row with the given uid from table, notFound is
+// returned when there is no such row.
+func (s *Storage) deleteByUID(ctx context.Context, table string, uid string, notFound error) error {
+	res, err := s.db.ExecContext(ctx, "DELETE FROM "+table+" WHERE uid = ?", uid)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if deleted == 0 {
+		return notFound
+	}
+
+	return nil
+}
+
+const closureColumns = "uid, boxName, date, kind, name"
+
+func scanClosure(row scanner) (models.Closure, error) {
+	var closure models.Closure
+
+	err := row.Scan(&closure.UID, &closure.BoxName, &closure.Date, &closure.Kind, &closure.Name)
+
+	return closure, err
+}
@@ -0,0 +1,114 @@
+package sqlite
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/storage"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateSeries stores a new booking series. Its occurrences are booked one by
+// one with BookOccurrence.
+func (s *Storage) CreateSeries(ctx context.Context, series models.Series) (models.Series, error) {
+	const op = "storage.sqlite.CreateSeries"
+
+	uid, err := uuid.NewV7()
+	if err != nil {
+		return models.Series{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	series.UID = uid.String()
+	series.CreatedAt = time.Unix(time.Now().Unix(), 0)
+
+	res, err := s.db.ExecContext(ctx, `
+		INSERT INTO booking_series(uid, email, boxName, rule, payment, startsAt, duration, peopleAmount, createdAt)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, series.UID, series.Email, series.BoxName, series.Rule, series.Payment, series.StartsAt.Unix(),
+		int64(series.Duration/time.Second), series.PeopleAmount, series.CreatedAt.Unix())
+	if err != nil {
+		return models.Series{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	series.ID, err = res.LastInsertId()
+	if err != nil {
+		return models.Series{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return series, nil
+}
+
+// Series returns the booking series with the given ID.
+func (s *Storage) Series(ctx context.Context, seriesUID string) (models.Series, error) {
+	const op = "storage.sqlite.Series"
+
+	var (
+		series    models.Series
+		startsAt  int64
+		duration  int64
+		createdAt int64
+	)
+
+	err := s.db.QueryRowContext(ctx, `
+		SELECT id, uid, email, boxName, rule, payment, startsAt, duration, peopleAmount, createdAt
+		FROM booking_series WHERE uid = ?
+	`, seriesUID).Scan(&series.ID, &series.UID, &series.Email, &series.BoxName, &series.Rule, &series.Payment,
+		&startsAt, &duration, &series.PeopleAmount, &createdAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Series{}, fmt.Errorf("%s: %w", op, storage.ErrSeriesNotFound)
+		}
+		return models.Series{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	series.StartsAt = time.Unix(startsAt, 0)
+	series.Duration = time.Duration(duration) * time.Second
+	series.CreatedAt = time.Unix(createdAt, 0)
+
+	return series, nil
+}
+
+// BookOccurrence reserves one occurrence of a series the same way BookABox
+// reserves a single booking.
+func (s *Storage) BookOccurrence(ctx context.Context, seriesUID string, email string, boxName string, startsAt time.Time, expiresAt time.Time, peopleAmount int64, pricePaid int64) (models.Saga, error) {
+	const op = "storage.sqlite.BookOccurrence"
+
+	saga, err := s.reserve(ctx, seriesUID, email, boxName, startsAt, expiresAt, peopleAmount, pricePaid)
+	if err != nil {
+		return models.Saga{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return saga, nil
+}
+
+// SeriesBookings returns the bookings of the series ordered by start time.
+func (s *Storage) SeriesBookings(ctx context.Context, seriesUID string) ([]models.Booking, error) {
+	const op = "storage.sqlite.SeriesBookings"
+
+	rows, err := s.db.QueryContext(ctx, "SELECT "+bookingColumns+" FROM bookings WHERE seriesUid = ? ORDER BY startsAt", seriesUID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var bookings []models.Booking
+
+	for rows.Next() {
+		b, err := scanBooking(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		bookings = append(bookings, b)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return bookings, nil
+}
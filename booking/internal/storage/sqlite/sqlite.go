@@ -1,16 +1,95 @@
 package sqlite
 
 import (
+	"booking/internal/domain/models"
 	"booking/internal/storage"
 	"context"
 	"database/sql"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	_ "github.com/mattn/go-sqlite3"
 )
 
+// reserveIDFactor scales row IDs into the legacy numeric booking IDs.
+const reserveIDFactor = 1000
+
+// bookingKey returns the column and value a booking ID is looked up by.
+// Numeric IDs are the legacy reserveIDFactor*rowid ones, anything else is
+// an opaque UID.
+func bookingKey(bookingID string) (string, any, error) {
+	legacy, err := strconv.ParseInt(bookingID, 10, 64)
+	if err != nil {
+		return "uid", bookingID, nil
+	}
+
+	if legacy <= 0 || legacy%reserveIDFactor != 0 {
+		return "", nil, storage.ErrBookingNotFound
+	}
+
+	return "id", legacy / reserveIDFactor, nil
+}
+
+// holdsSlot tells whether a booking row takes its slot: active bookings, the
+// ones in the middle of a saga and the held ones until their hold runs out.
+// The arguments come from holdsSlotArgs.
+const holdsSlot = `(status IN (?, ?, ?) OR (status = ? AND heldUntil > ?))`
+
+func holdsSlotArgs() []any {
+	return []any{models.BookingStatusActive, models.BookingStatusPending, models.BookingStatusCancelling, models.BookingStatusHeld, time.Now().Unix()}
+}
+
+// slotFreeCondition tells whether a booking still fits into the box row it is
+// evaluated against. A box with shared sessions takes overlapping bookings while
+// the people of all of them fit into its capacity, any other box takes one
+// booking per slot. The arguments come from slotFreeArgs or slotFreeArgsExcept.
+const slotFreeCondition = `CASE WHEN sharedSessions THEN (
+		SELECT COALESCE(SUM(peopleAmount), 0) FROM bookings
+		WHERE boxName = ? AND uid IS NOT ? AND ` + holdsSlot + ` AND startsAt < ? AND expiresAt > ?
+	) + ? <= capacity ELSE NOT EXISTS (
+		SELECT 1 FROM bookings
+		WHERE boxName = ? AND uid IS NOT ? AND ` + holdsSlot + ` AND startsAt < ? AND expiresAt > ?
+	) END`
+
+func slotFreeArgs(boxName string, startsAt time.Time, expiresAt time.Time, peopleAmount int64) []any {
+	return slotFreeArgsExcept("", boxName, startsAt, expiresAt, peopleAmount)
+}
+
+// slotFreeArgsExcept leaves the booking with the given UID out of the check,
+// so that a booking being moved does not collide with itself.
+func slotFreeArgsExcept(exceptUID string, boxName string, startsAt time.Time, expiresAt time.Time, peopleAmount int64) []any {
+	overlap := append([]any{boxName, exceptUID}, holdsSlotArgs()...)
+	overlap = append(overlap, expiresAt.Unix(), startsAt.Unix())
+
+	args := append([]any{}, overlap...)
+	args = append(args, peopleAmount)
+
+	return append(args, overlap...)
+}
+
+// connParams make every transaction take the write lock when it begins and
+// wait for a busy lock instead of failing right away. A deferred transaction
+// that reads before it writes can't wait for the lock once another writer has
+// it, so concurrent bookings would otherwise fail with "database is locked".
+const connParams = "_txlock=immediate&_journal_mode=WAL&_busy_timeout=5000"
+
+// ownedOrJoined matches the bookings of the user and the ones the user
+// accepted an invitation to. Invitations are stored lowercased while the
+// caller's email is spelled the way the account was, so both the owner and
+// the participant emails are compared ignoring the case. The arguments come
+// from ownedOrJoinedArgs.
+const ownedOrJoined = `(email = ? COLLATE NOCASE OR id IN (
+		SELECT bookingId FROM booking_participants WHERE email = ? COLLATE NOCASE AND status = ?
+	))`
+
+func ownedOrJoinedArgs(email string) []any {
+	return []any{email, email, models.ParticipantStatusAccepted}
+}
+
 type Storage struct {
 	db *sql.DB
 }
@@ -18,7 +97,12 @@ type Storage struct {
 func New(storagePath string) (*Storage, error) {
 	const op = "storage.sqlite.New"
 
-	db, err := sql.Open("sqlite3", storagePath)
+	sep := "?"
+	if strings.Contains(storagePath, "?") {
+		sep = "&"
+	}
+
+	db, err := sql.Open("sqlite3", storagePath+sep+connParams)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -26,73 +110,145 @@ func New(storagePath string) (*Storage, error) {
 	return &Storage{db: db}, nil
 }
 
-func (s *Storage) BookABox(ctx context.Context, email string, boxName string, startTime string, timeHrs int64, timeMins int64) (resID int64, success bool, err error) {
+// BookABox reserves the slot for the duration of the booking saga: the booking
+// is stored as pending together with a saga in the reserved state.
+//
+// The capacity check is a part of the INSERT itself, so SQLite runs both under
+// one write lock and concurrent requests for the same slot can't both succeed.
+func (s *Storage) BookABox(ctx context.Context, email string, boxName string, startsAt time.Time, expiresAt time.Time, peopleAmount int64, pricePaid int64) (models.Saga, error) {
 	const op = "storage.sqlite.BookABox"
 
-	dur := time.Duration(timeHrs)*time.Hour + time.Duration(timeMins)*time.Minute
+	saga, err := s.reserve(ctx, "", email, boxName, startsAt, expiresAt, peopleAmount, pricePaid)
+	if err != nil {
+		return models.Saga{}, fmt.Errorf("%s: %w", op, err)
+	}
 
-	startsAt, err := time.Parse("15:04", startTime)
+	return saga, nil
+}
+
+// reserve stores a pending booking, optionally belonging to a series, and its
+// book saga if the slot is free.
+func (s *Storage) reserve(ctx context.Context, seriesUID string, email string, boxName string, startsAt time.Time, expiresAt time.Time, peopleAmount int64, pricePaid int64) (models.Saga, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
-		return 0, false, fmt.Errorf("%s: %w", op, err)
+		return models.Saga{}, err
 	}
+	defer tx.Rollback()
 
-	timeEnd := startsAt.Add(dur).Local().Unix()
+	uid, err := uuid.NewV7()
+	if err != nil {
+		return models.Saga{}, err
+	}
 
-	isNotBooked, err := s.IsNotBooked(ctx, boxName, startsAt.Unix(), timeEnd)
+	pin, err := newAccessPIN()
 	if err != nil {
-		return 0, false, fmt.Errorf("%s: %w", op, err)
+		return models.Saga{}, err
 	}
 
-	fmt.Println("isNotBooked:", isNotBooked)
+	series := sql.NullString{String: seriesUID, Valid: seriesUID != ""}
 
-	if isNotBooked {
-		fmt.Println("Попытка вставить запись")
-		stmt, err := s.db.Prepare("INSERT INTO bookings(email, boxName, startsAt, expiresAt) VALUES(?, ?, ?, ?)")
-		if err != nil {
-			fmt.Println("Ошибка подготовки запроса:", err)
-			return 0, false, fmt.Errorf("%s: %w", op, err)
-		}
+	args := []any{uid.String(), email, boxName, startsAt.Unix(), expiresAt.Unix(), peopleAmount, pricePaid, models.BookingStatusPending, series, pin, boxName}
+	args = append(args, slotFreeArgs(boxName, startsAt, expiresAt, peopleAmount)...)
 
-		res, err := stmt.ExecContext(ctx, email, boxName, startsAt.Unix(), timeEnd)
-		if err != nil {
-			fmt.Println("Ошибка выполнения вставки:", err)
-			return 0, false, fmt.Errorf("%s: %w", op, err)
-		} else {
-			fmt.Println("Вставка выполнена успешно")
-		}
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO bookings(uid, email, boxName, startsAt, expiresAt, peopleAmount, pricePaid, status, seriesUid, accessPin)
+		SELECT ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+		FROM boxes WHERE name = ? AND `+slotFreeCondition, args...)
+	if err != nil {
+		return models.Saga{}, err
+	}
 
-		id, err := res.LastInsertId()
-		if err != nil {
-			fmt.Println("Ошибка получения last insert id:", err)
-			return 0, false, fmt.Errorf("%s: %w", op, err)
-		}
-		fmt.Printf("Последний вставленный ID: %d\n", id)
-		return 1000 * id, true, nil
-	} else {
-		return 0, false, fmt.Errorf("%s: %w", op, err)
+	inserted, err := res.RowsAffected()
+	if err != nil {
+		return models.Saga{}, err
+	}
+
+	if inserted == 0 {
+		return models.Saga{}, storage.ErrAlreadyBooked
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return models.Saga{}, err
+	}
+
+	saga, err := insertSaga(ctx, tx, models.SagaKindBook, models.SagaStateReserved, id, uid.String(), email, pricePaid)
+	if err != nil {
+		return models.Saga{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Saga{}, err
 	}
+
+	return saga, nil
 }
 
-func (s *Storage) TimeCheck(ctx context.Context, timeNow int64) (bool, error) {
-	const op = "storage.sqlite.TimeCheck"
+// IsSlotFree tells whether a booking for the given people and time would fit
+// into the box now.
+func (s *Storage) IsSlotFree(ctx context.Context, boxName string, startsAt time.Time, expiresAt time.Time, peopleAmount int64) (bool, error) {
+	const op = "storage.sqlite.IsSlotFree"
 
-	tx, err := s.db.BeginTx(ctx, nil)
+	args := append([]any{boxName}, slotFreeArgs(boxName, startsAt, expiresAt, peopleAmount)...)
+
+	var free bool
+
+	err := s.db.QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM boxes WHERE name = ? AND `+slotFreeCondition+`)
+	`, args...).Scan(&free)
 	if err != nil {
 		return false, fmt.Errorf("%s: %w", op, err)
 	}
 
-	_, err = tx.ExecContext(ctx, `
-        DELETE FROM bookings WHERE expiresAt < ?
-    `, timeNow)
+	return free, nil
+}
+
+// CompleteExpired moves every active booking that ended before timeNow to the
+// completed status. Rows are kept for history, reporting and refund disputes.
+func (s *Storage) CompleteExpired(ctx context.Context, timeNow int64) (int64, error) {
+	const op = "storage.sqlite.CompleteExpired"
+
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
-		return false, fmt.Errorf("%s: %w", op, err)
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+        UPDATE bookings SET status = ?, completedAt = ? WHERE status = ? AND expiresAt < ?
+        RETURNING id
+    `, models.BookingStatusCompleted, timeNow, models.BookingStatusActive, timeNow)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var completed []int64
+
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
+		completed = append(completed, id)
+	}
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, id := range completed {
+		if err := addEvent(ctx, tx, models.EventBookingCompleted, id, 0); err != nil {
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
 	}
 
 	if err := tx.Commit(); err != nil {
-		return false, fmt.Errorf("%s: %w", op, err)
+		return 0, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return true, nil
+	return int64(len(completed)), nil
 }
 
 func (s *Storage) StartDbChecker(ctx context.Context, interval int64) <-chan error {
@@ -107,7 +263,7 @@ func (s *Storage) StartDbChecker(ctx context.Context, interval int64) <-chan err
 			select {
 			case <-ticker.C:
 				now := time.Now().Unix()
-				if _, err := s.TimeCheck(ctx, now); err != nil {
+				if _, err := s.CompleteExpired(ctx, now); err != nil {
 					errCh <- err
 					return
 				}
@@ -120,97 +276,190 @@ func (s *Storage) StartDbChecker(ctx context.Context, interval int64) <-chan err
 	return errCh
 }
 
-func (s *Storage) IsNotBooked(ctx context.Context, boxName string, startTime int64, expirationTime int64) (bool, error) {
-	const op = "storage.sqlite.IsBooked"
+// Booking returns the booking with the given opaque or legacy numeric ID.
+func (s *Storage) Booking(ctx context.Context, bookingID string) (models.Booking, error) {
+	const op = "storage.sqlite.Booking"
 
-	tx, err := s.db.BeginTx(ctx, nil)
+	column, key, err := bookingKey(bookingID)
 	if err != nil {
-		return false, err
+		return models.Booking{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	row := tx.QueryRowContext(ctx, `
-        SELECT COUNT(*) FROM bookings WHERE boxName = ? AND (startsAt <= ? AND expiresAt >= ?) 
-    `, boxName, expirationTime, startTime)
-	var count int
-	fmt.Printf("Проверка брони для boxName=%s, startTime=%d, expirationTime=%d\n", boxName, startTime, expirationTime)
-	if err := row.Scan(&count); err != nil {
+	row := s.db.QueryRowContext(ctx, "SELECT "+bookingColumns+" FROM bookings WHERE "+column+" = ?", key)
+
+	b, err := scanBooking(row)
+	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return false, fmt.Errorf("%s: %w", op, storage.ErrBookingNotFound)
+			return models.Booking{}, fmt.Errorf("%s: %w", op, storage.ErrBookingNotFound)
 		}
-		return false, fmt.Errorf("%s: %w", op, err)
-	}
-		fmt.Printf("Результат COUNT=%d\n", count)
-	if count > 0 {
-		if err := tx.Commit(); err != nil {
-			return false, fmt.Errorf("%s: %w", op, err)
-		}
-		return false, fmt.Errorf("%s: %w", op, storage.ErrAlreadyBooked)
-	}
-
-	if err := tx.Commit(); err != nil {
-		return false, fmt.Errorf("%s: %w", op, err)
+		return models.Booking{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return true, nil
+	return b, nil
 }
 
-func (s *Storage) CancelBooking(ctx context.Context, email string, bookingID int64) (refundedAmount int64, success bool, err error) {
+// CancelBooking starts the cancellation of an active booking that has not
+// ended yet and records the refund to pay in a cancel saga. The booking is
+// only cancelled if it has not been moved since it was read.
+func (s *Storage) CancelBooking(ctx context.Context, booking models.Booking, refundAmount int64) (models.Saga, error) {
 	const op = "storage.sqlite.CancelBooking"
 
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
-		return 0, false, fmt.Errorf("%s: %w", op, err)
+		return models.Saga{}, fmt.Errorf("%s: %w", op, err)
 	}
 	defer tx.Rollback()
 
-	row := tx.QueryRowContext(ctx, `
-		SELECT email, boxName, startsAt, expiresAt FROM bookings WHERE bookingId = ?
-	`, bookingID/1000)
-	
-	var (
-		bookingEmail string
-		boxName      string
-		startsAt     int64
-		expiresAt    int64
-	)
+	result, err := tx.ExecContext(ctx, `
+		UPDATE bookings SET status = ?
+		WHERE uid = ? AND status = ? AND startsAt = ? AND expiresAt = ? AND expiresAt > ?
+	`, models.BookingStatusCancelling, booking.UID, models.BookingStatusActive,
+		booking.StartsAt.Unix(), booking.ExpiresAt.Unix(), time.Now().Unix())
+	if err != nil {
+		return models.Saga{}, fmt.Errorf("%s: %w", op, err)
+	}
 
-	if err := row.Scan(&bookingEmail, &boxName, &startsAt, &expiresAt); err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return 0, false, fmt.Errorf("%s: %w", op, storage.ErrBookingNotFound)
-		}
-		return 0, false, fmt.Errorf("%s: %w", op, err)
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return models.Saga{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	if bookingEmail != email {
-		return 0, false, fmt.Errorf("%s: %w", op, storage.ErrNotYourBooking)
+	if rowsAffected == 0 {
+		return models.Saga{}, fmt.Errorf("%s: %w", op, storage.ErrBookingNotActive)
 	}
 
-	now := time.Now().Unix()
-	if now >= expiresAt {
-		return 0, false, fmt.Errorf("%s: booking has already expired", op)
+	saga, err := insertSaga(ctx, tx, models.SagaKindCancel, models.SagaStateRefunding, booking.ID/reserveIDFactor, booking.UID, booking.Email, refundAmount)
+	if err != nil {
+		return models.Saga{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	remainingTime := expiresAt - now
-	totalTime := expiresAt - startsAt
-	refundedAmount = (remainingTime * 100) / totalTime 
+	if err := tx.Commit(); err != nil {
+		return models.Saga{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return saga, nil
+}
+
+// Bookings returns the bookings of the given user ordered by start time, newest first.
+func (s *Storage) Bookings(ctx context.Context, email string, filter models.BookingFilter) ([]models.Booking, error) {
+	const op = "storage.sqlite.Bookings"
 
-	result, err := tx.ExecContext(ctx, "DELETE FROM bookings WHERE bookingId = ?", bookingID/1000)
+	// Bookings the user accepted an invitation to are listed too.
+	query := "SELECT " + bookingColumns + " FROM bookings WHERE " + ownedOrJoined
+	args := ownedOrJoinedArgs(email)
+
+	if filter.Status != "" {
+		query += " AND status = ?"
+		args = append(args, filter.Status)
+	}
+	if !filter.From.IsZero() {
+		query += " AND startsAt >= ?"
+		args = append(args, filter.From.Unix())
+	}
+	if !filter.To.IsZero() {
+		query += " AND startsAt < ?"
+		args = append(args, filter.To.Unix())
+	}
+	if filter.After != nil {
+		query += " AND (startsAt < ? OR (startsAt = ? AND id < ?))"
+		args = append(args, filter.After.StartsAt, filter.After.StartsAt, filter.After.ID/reserveIDFactor)
+	}
+
+	query += " ORDER BY startsAt DESC, id DESC LIMIT ?"
+	args = append(args, filter.Limit)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return 0, false, fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
+	defer rows.Close()
 
-	rowsAffected, err := result.RowsAffected()
+	var bookings []models.Booking
+
+	for rows.Next() {
+		b, err := scanBooking(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		bookings = append(bookings, b)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return bookings, nil
+}
+
+const bookingColumns = "id, uid, email, boxName, startsAt, expiresAt, peopleAmount, pricePaid, status, cancelledAt, seriesUid, accessPin, checkedInAt, revision"
+
+func scanBooking(row scanner) (models.Booking, error) {
+	var (
+		b           models.Booking
+		startsAt    int64
+		expiresAt   int64
+		cancelledAt sql.NullInt64
+		seriesUID   sql.NullString
+		checkedInAt sql.NullInt64
+	)
+
+	if err := row.Scan(&b.ID, &b.UID, &b.Email, &b.BoxName, &startsAt, &expiresAt, &b.PeopleAmount, &b.PricePaid, &b.Status, &cancelledAt, &seriesUID,
+		&b.AccessPIN, &checkedInAt, &b.Revision); err != nil {
+		return models.Booking{}, err
+	}
+
+	b.ID *= reserveIDFactor
+	b.StartsAt = time.Unix(startsAt, 0)
+	b.ExpiresAt = time.Unix(expiresAt, 0)
+	if cancelledAt.Valid {
+		b.CancelledAt = time.Unix(cancelledAt.Int64, 0)
+	}
+	b.SeriesUID = seriesUID.String
+	if checkedInAt.Valid {
+		b.CheckedInAt = time.Unix(checkedInAt.Int64, 0)
+	}
+
+	return b, nil
+}
+
+// BusyIntervals returns the bookings of the box that hold their slot (active,
+// in the middle of a saga or held) and overlap [from, to), ordered by start time.
+func (s *Storage) BusyIntervals(ctx context.Context, boxName string, from time.Time, to time.Time) ([]models.Interval, error) {
+	const op = "storage.sqlite.BusyIntervals"
+
+	args := append([]any{boxName}, holdsSlotArgs()...)
+	args = append(args, to.Unix(), from.Unix())
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT startsAt, expiresAt, peopleAmount FROM bookings
+		WHERE boxName = ? AND `+holdsSlot+` AND startsAt < ? AND expiresAt > ?
+		ORDER BY startsAt
+	`, args...)
 	if err != nil {
-		return 0, false, fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
+	defer rows.Close()
 
-	if rowsAffected == 0 {
-		return 0, false, fmt.Errorf("%s: %w", op, storage.ErrBookingNotFound)
+	var intervals []models.Interval
+
+	for rows.Next() {
+		var startsAt, expiresAt, peopleAmount int64
+
+		if err := rows.Scan(&startsAt, &expiresAt, &peopleAmount); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		intervals = append(intervals, models.Interval{
+			StartsAt:     time.Unix(startsAt, 0),
+			ExpiresAt:    time.Unix(expiresAt, 0),
+			PeopleAmount: peopleAmount,
+		})
 	}
 
-	if err := tx.Commit(); err != nil {
-		return 0, false, fmt.Errorf("%s: %w", op, err)
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return refundedAmount, true, nil
-}
\ No newline at end of file
+	return intervals, nil
+}
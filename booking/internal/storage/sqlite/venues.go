@@ -0,0 +1,51 @@
+package sqlite
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/lib/geo"
+	"context"
+	"fmt"
+)
+
+// Venues returns the venues within the bounds, ordered by name.
+func (s *Storage) Venues(ctx context.Context, bounds geo.Bounds) ([]models.Venue, error) {
+	const op = "storage.sqlite.Venues"
+
+	query := `
+		SELECT id, name, address, latitude, longitude, timeZone, phone, email, website
+		FROM venues WHERE latitude BETWEEN ? AND ?`
+	args := []any{bounds.MinLatitude, bounds.MaxLatitude}
+
+	if bounds.MinLongitude <= bounds.MaxLongitude {
+		query += " AND longitude BETWEEN ? AND ?"
+	} else {
+		query += " AND (longitude >= ? OR longitude <= ?)"
+	}
+	args = append(args, bounds.MinLongitude, bounds.MaxLongitude)
+
+	rows, err := s.db.QueryContext(ctx, query+" ORDER BY name", args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var venues []models.Venue
+
+	for rows.Next() {
+		var venue models.Venue
+
+		err := rows.Scan(&venue.ID, &venue.Name, &venue.Address, &venue.Latitude, &venue.Longitude, &venue.TimeZone,
+			&venue.Phone, &venue.Email, &venue.Website)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		venues = append(venues, venue)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return venues, nil
+}
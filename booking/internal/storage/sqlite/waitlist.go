@@ -0,0 +1,357 @@
+package sqlite
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/storage"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const waitlistColumns = "id, uid, email, boxName, startsAt, expiresAt, peopleAmount, mode, status, offerExpiresAt, bookingUid, error, createdAt"
+
+// JoinWaitlist stores a new waiting entry.
+func (s *Storage) JoinWaitlist(ctx context.Context, entry models.WaitlistEntry) (models.WaitlistEntry, error) {
+	const op = "storage.sqlite.JoinWaitlist"
+
+	uid, err := uuid.NewV7()
+	if err != nil {
+		return models.WaitlistEntry{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	now := time.Now().Unix()
+
+	entry.UID = uid.String()
+	entry.Status = models.WaitlistStatusWaiting
+	entry.CreatedAt = time.Unix(now, 0)
+
+	res, err := s.db.ExecContext(ctx, `
+		INSERT INTO waitlist(uid, email, boxName, startsAt, expiresAt, peopleAmount, mode, status, createdAt, updatedAt)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, entry.UID, entry.Email, entry.BoxName, entry.StartsAt.Unix(), entry.ExpiresAt.Unix(), entry.PeopleAmount,
+		entry.Mode, entry.Status, now, now)
+	if err != nil {
+		return models.WaitlistEntry{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	entry.ID, err = res.LastInsertId()
+	if err != nil {
+		return models.WaitlistEntry{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return entry, nil
+}
+
+// WaitlistEntry returns the waitlist entry with the given ID.
+func (s *Storage) WaitlistEntry(ctx context.Context, entryUID string) (models.WaitlistEntry, error) {
+	const op = "storage.sqlite.WaitlistEntry"
+
+	row := s.db.QueryRowContext(ctx, "SELECT "+waitlistColumns+" FROM waitlist WHERE uid = ?", entryUID)
+
+	entry, err := scanWaitlistEntry(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.WaitlistEntry{}, fmt.Errorf("%s: %w", op, storage.ErrWaitlistEntryNotFound)
+		}
+		return models.WaitlistEntry{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return entry, nil
+}
+
+// Waitlist returns the waitlist entries of the user, newest first.
+func (s *Storage) Waitlist(ctx context.Context, email string) ([]models.WaitlistEntry, error) {
+	const op = "storage.sqlite.Waitlist"
+
+	entries, err := s.waitlist(ctx, "SELECT "+waitlistColumns+" FROM waitlist WHERE email = ? ORDER BY id DESC", email)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return entries, nil
+}
+
+// WaitingEntries returns the entries still waiting for a future slot in the
+// order they joined.
+func (s *Storage) WaitingEntries(ctx context.Context) ([]models.WaitlistEntry, error) {
+	const op = "storage.sqlite.WaitingEntries"
+
+	entries, err := s.waitlist(ctx, "SELECT "+waitlistColumns+" FROM waitlist WHERE status = ? AND startsAt > ? ORDER BY id",
+		models.WaitlistStatusWaiting, time.Now().Unix())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return entries, nil
+}
+
+func (s *Storage) waitlist(ctx context.Context, query string, args ...any) ([]models.WaitlistEntry, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []models.WaitlistEntry
+
+	for rows.Next() {
+		entry, err := scanWaitlistEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+// OfferSlot holds the slot of a waiting entry for its user until heldUntil.
+// The hold is a booking in the held status, it is only stored if the slot is
+// free.
+func (s *Storage) OfferSlot(ctx context.Context, entry models.WaitlistEntry, heldUntil time.Time) (string, error) {
+	const op = "storage.sqlite.OfferSlot"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	uid, err := uuid.NewV7()
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	pin, err := newAccessPIN()
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	args := []any{uid.String(), entry.Email, entry.BoxName, entry.StartsAt.Unix(), entry.ExpiresAt.Unix(), entry.PeopleAmount,
+		models.BookingStatusHeld, heldUntil.Unix(), pin, entry.BoxName}
+	args = append(args, slotFreeArgs(entry.BoxName, entry.StartsAt, entry.ExpiresAt, entry.PeopleAmount)...)
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO bookings(uid, email, boxName, startsAt, expiresAt, peopleAmount, status, heldUntil, accessPin)
+		SELECT ?, ?, ?, ?, ?, ?, ?, ?, ?
+		FROM boxes WHERE name = ? AND `+slotFreeCondition, args...)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	inserted, err := res.RowsAffected()
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	if inserted == 0 {
+		return "", fmt.Errorf("%s: %w", op, storage.ErrAlreadyBooked)
+	}
+
+	res, err = tx.ExecContext(ctx, `
+		UPDATE waitlist SET status = ?, offerExpiresAt = ?, bookingUid = ?, updatedAt = ? WHERE id = ? AND status = ?
+	`, models.WaitlistStatusOffered, heldUntil.Unix(), uid.String(), time.Now().Unix(), entry.ID, models.WaitlistStatusWaiting)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	updated, err := res.RowsAffected()
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	if updated == 0 {
+		return "", fmt.Errorf("%s: %w", op, storage.ErrWaitlistEntryNotFound)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return uid.String(), nil
+}
+
+// AcceptOffer turns the held booking of an offered entry into a pending one
+// with a book saga, the same state BookABox leaves a new booking in.
+func (s *Storage) AcceptOffer(ctx context.Context, entry models.WaitlistEntry, pricePaid int64) (models.Saga, error) {
+	const op = "storage.sqlite.AcceptOffer"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Saga{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now().Unix()
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE waitlist SET status = ?, updatedAt = ? WHERE id = ? AND status = ? AND offerExpiresAt > ?
+	`, models.WaitlistStatusBooked, now, entry.ID, models.WaitlistStatusOffered, now)
+	if err != nil {
+		return models.Saga{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	updated, err := res.RowsAffected()
+	if err != nil {
+		return models.Saga{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if updated == 0 {
+		return models.Saga{}, fmt.Errorf("%s: %w", op, storage.ErrOfferExpired)
+	}
+
+	var rowID int64
+
+	err = tx.QueryRowContext(ctx, `
+		UPDATE bookings SET status = ?, pricePaid = ?, heldUntil = NULL
+		WHERE uid = ? AND status = ? AND heldUntil > ?
+		RETURNING id
+	`, models.BookingStatusPending, pricePaid, entry.BookingUID, models.BookingStatusHeld, now).Scan(&rowID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Saga{}, fmt.Errorf("%s: %w", op, storage.ErrOfferExpired)
+		}
+		return models.Saga{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	saga, err := insertSaga(ctx, tx, models.SagaKindBook, models.SagaStateReserved, rowID, entry.BookingUID, entry.Email, pricePaid)
+	if err != nil {
+		return models.Saga{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Saga{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return saga, nil
+}
+
+// SetWaitlistStatus records the outcome of a waitlist entry.
+func (s *Storage) SetWaitlistStatus(ctx context.Context, entryID int64, status models.WaitlistStatus, bookingUID string, reason string) error {
+	const op = "storage.sqlite.SetWaitlistStatus"
+
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE waitlist SET status = ?, bookingUid = COALESCE(NULLIF(?, ''), bookingUid), error = ?, updatedAt = ? WHERE id = ?
+	`, status, bookingUID, reason, time.Now().Unix(), entryID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// LeaveWaitlist cancels a waiting or offered entry and gives the held slot of
+// an offer up.
+func (s *Storage) LeaveWaitlist(ctx context.Context, entry models.WaitlistEntry) error {
+	const op = "storage.sqlite.LeaveWaitlist"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE waitlist SET status = ?, updatedAt = ? WHERE id = ? AND status IN (?, ?)
+	`, models.WaitlistStatusCancelled, time.Now().Unix(), entry.ID, models.WaitlistStatusWaiting, models.WaitlistStatusOffered)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	updated, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if updated == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrWaitlistEntryNotFound)
+	}
+
+	if entry.BookingUID != "" {
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE bookings SET status = ? WHERE uid = ? AND status = ?
+		`, models.BookingStatusExpired, entry.BookingUID, models.BookingStatusHeld); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ExpireWaitlist closes the offers and their held bookings that ran out and the
+// entries whose slot has already started. Checkout holds are left to
+// ExpireHolds. It returns the number of expired offers.
+func (s *Storage) ExpireWaitlist(ctx context.Context, now time.Time) (int64, error) {
+	const op = "storage.sqlite.ExpireWaitlist"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE bookings SET status = ? WHERE status = ? AND heldUntil <= ? AND NOT `+checkoutHold+`
+	`, models.BookingStatusExpired, models.BookingStatusHeld, now.Unix()); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE waitlist SET status = ?, updatedAt = ? WHERE status = ? AND offerExpiresAt <= ?
+	`, models.WaitlistStatusExpired, now.Unix(), models.WaitlistStatusOffered, now.Unix())
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	expired, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE waitlist SET status = ?, updatedAt = ? WHERE status = ? AND startsAt <= ?
+	`, models.WaitlistStatusExpired, now.Unix(), models.WaitlistStatusWaiting, now.Unix()); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return expired, nil
+}
+
+func scanWaitlistEntry(row scanner) (models.WaitlistEntry, error) {
+	var (
+		entry          models.WaitlistEntry
+		startsAt       int64
+		expiresAt      int64
+		offerExpiresAt sql.NullInt64
+		bookingUID     sql.NullString
+		createdAt      int64
+	)
+
+	if err := row.Scan(&entry.ID, &entry.UID, &entry.Email, &entry.BoxName, &startsAt, &expiresAt, &entry.PeopleAmount,
+		&entry.Mode, &entry.Status, &offerExpiresAt, &bookingUID, &entry.Error, &createdAt); err != nil {
+		return models.WaitlistEntry{}, err
+	}
+
+	entry.StartsAt = time.Unix(startsAt, 0)
+	entry.ExpiresAt = time.Unix(expiresAt, 0)
+	if offerExpiresAt.Valid {
+		entry.OfferExpiresAt = time.Unix(offerExpiresAt.Int64, 0)
+	}
+	entry.BookingUID = bookingUID.String
+	entry.CreatedAt = time.Unix(createdAt, 0)
+
+	return entry, nil
+}
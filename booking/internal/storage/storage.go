@@ -7,4 +7,23 @@ var (
 	ErrBookingNotFound = errors.New("booking not found")
 	ErrAlreadyBooked = errors.New("this box is already booked")
 	ErrNotYourBooking = errors.New("this booking belongs to another user")
+	ErrBookingNotActive = errors.New("booking is not active")
+	ErrBoxNotFound = errors.New("box not found")
+	ErrSagaNotFound = errors.New("saga not found")
+	ErrHolidayRateNotFound = errors.New("holiday rate not found")
+	ErrSeriesNotFound = errors.New("series not found")
+	ErrWaitlistEntryNotFound = errors.New("waitlist entry not found")
+	ErrOfferExpired = errors.New("waitlist offer has expired")
+	ErrClosureNotFound = errors.New("closure not found")
+	ErrBlackoutNotFound = errors.New("blackout not found")
+	ErrCalendarFeedNotFound = errors.New("calendar feed not found")
+	ErrHoldNotFound = errors.New("hold not found")
+	ErrHoldExpired = errors.New("hold has expired")
+	ErrTooManyHolds = errors.New("too many active holds")
+	ErrPromoCodeNotFound = errors.New("promo code not found")
+	ErrPromoCodeExhausted = errors.New("promo code has been used up")
+	ErrInvitationNotFound = errors.New("invitation not found")
+	ErrAlreadyInvited = errors.New("already invited")
+	ErrInvitationDeclined = errors.New("invitation was declined")
+	ErrTooManyParticipants = errors.New("too many participants")
 )
\ No newline at end of file
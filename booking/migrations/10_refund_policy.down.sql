@@ -0,0 +1,2 @@
+-- The prices of old bookings stay backfilled.
+SELECT 1;
@@ -0,0 +1,2 @@
+-- Bookings made before prices were stored were charged 13 per minute and person.
+UPDATE bookings SET pricePaid = ((expiresAt - startsAt) / 60) * 13 * peopleAmount WHERE pricePaid = 0;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_bookings_seriesUid;
+
+ALTER TABLE bookings DROP COLUMN seriesUid;
+
+DROP TABLE IF EXISTS booking_series;
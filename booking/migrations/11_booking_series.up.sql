@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS booking_series
+(
+    id INTEGER PRIMARY KEY,
+    uid TEXT NOT NULL UNIQUE,
+    email TEXT NOT NULL,
+    boxName TEXT NOT NULL,
+    rule TEXT NOT NULL,
+    payment TEXT NOT NULL,
+    startsAt INTEGER NOT NULL,
+    duration INTEGER NOT NULL,
+    peopleAmount INTEGER NOT NULL,
+    createdAt INTEGER NOT NULL
+);
+
+ALTER TABLE bookings ADD COLUMN seriesUid TEXT REFERENCES booking_series (uid);
+CREATE INDEX IF NOT EXISTS idx_bookings_seriesUid ON bookings (seriesUid);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_waitlist_email;
+DROP INDEX IF EXISTS idx_waitlist_status_startsAt;
+DROP TABLE IF EXISTS waitlist;
+
+ALTER TABLE bookings DROP COLUMN heldUntil;
@@ -0,0 +1,21 @@
+ALTER TABLE bookings ADD COLUMN heldUntil INTEGER;
+
+CREATE TABLE IF NOT EXISTS waitlist
+(
+    id INTEGER PRIMARY KEY,
+    uid TEXT NOT NULL UNIQUE,
+    email TEXT NOT NULL,
+    boxName TEXT NOT NULL,
+    startsAt INTEGER NOT NULL,
+    expiresAt INTEGER NOT NULL,
+    peopleAmount INTEGER NOT NULL,
+    mode TEXT NOT NULL,
+    status TEXT NOT NULL,
+    offerExpiresAt INTEGER,
+    bookingUid TEXT,
+    error TEXT NOT NULL DEFAULT '',
+    createdAt INTEGER NOT NULL,
+    updatedAt INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_waitlist_status_startsAt ON waitlist (status, startsAt);
+CREATE INDEX IF NOT EXISTS idx_waitlist_email ON waitlist (email);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_blackouts_boxName_startsAt;
+DROP TABLE IF EXISTS blackouts;
+
+DROP INDEX IF EXISTS idx_closures_date;
+DROP TABLE IF EXISTS closures;
+
+DROP TABLE IF EXISTS opening_hours;
@@ -0,0 +1,41 @@
+-- Weekly hours replace the daily opensAt and closesAt of the box on their
+-- weekday (0 is Sunday). Times are local to the box, closesAt '24:00' means
+-- midnight.
+CREATE TABLE IF NOT EXISTS opening_hours
+(
+    id INTEGER PRIMARY KEY,
+    boxName TEXT NOT NULL,
+    weekday INTEGER NOT NULL,
+    opensAt TEXT NOT NULL,
+    closesAt TEXT NOT NULL,
+    closed INTEGER NOT NULL DEFAULT 0,
+    UNIQUE (boxName, weekday)
+);
+
+-- A closure keeps the box shut the whole local date. Empty boxName closes
+-- every box.
+CREATE TABLE IF NOT EXISTS closures
+(
+    id INTEGER PRIMARY KEY,
+    uid TEXT NOT NULL UNIQUE,
+    boxName TEXT NOT NULL DEFAULT '',
+    date TEXT NOT NULL,
+    kind TEXT NOT NULL,
+    name TEXT NOT NULL DEFAULT '',
+    createdAt INTEGER NOT NULL,
+    UNIQUE (boxName, date)
+);
+CREATE INDEX IF NOT EXISTS idx_closures_date ON closures (date);
+
+-- A blackout takes the box out of service for maintenance.
+CREATE TABLE IF NOT EXISTS blackouts
+(
+    id INTEGER PRIMARY KEY,
+    uid TEXT NOT NULL UNIQUE,
+    boxName TEXT NOT NULL,
+    startsAt INTEGER NOT NULL,
+    endsAt INTEGER NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    createdAt INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_blackouts_boxName_startsAt ON blackouts (boxName, startsAt);
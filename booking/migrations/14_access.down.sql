@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_check_ins_bookingUid;
+DROP TABLE IF EXISTS check_ins;
+
+DROP INDEX IF EXISTS idx_bookings_boxName_accessPin;
+ALTER TABLE bookings DROP COLUMN accessPinUsedAt;
+ALTER TABLE bookings DROP COLUMN checkedInAt;
+ALTER TABLE bookings DROP COLUMN accessPin;
@@ -0,0 +1,19 @@
+-- accessPin opens the door of the box for the booking. Bookings made before
+-- have no PIN and are opened with their signed token only.
+ALTER TABLE bookings ADD COLUMN accessPin TEXT NOT NULL DEFAULT '';
+ALTER TABLE bookings ADD COLUMN checkedInAt INTEGER;
+-- A PIN opens the door once, accessPinUsedAt is the check-in it was used at.
+ALTER TABLE bookings ADD COLUMN accessPinUsedAt INTEGER;
+CREATE INDEX IF NOT EXISTS idx_bookings_boxName_accessPin ON bookings (boxName, accessPin);
+
+CREATE TABLE IF NOT EXISTS check_ins
+(
+    id INTEGER PRIMARY KEY,
+    uid TEXT NOT NULL UNIQUE,
+    bookingUid TEXT NOT NULL,
+    boxName TEXT NOT NULL,
+    door TEXT NOT NULL DEFAULT '',
+    method TEXT NOT NULL,
+    createdAt INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_check_ins_bookingUid ON check_ins (bookingUid);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS calendar_feeds;
+
+ALTER TABLE bookings DROP COLUMN revision;
@@ -0,0 +1,12 @@
+-- revision grows whenever a booking is moved or cancelled, calendar feeds
+-- use it as the SEQUENCE of the event.
+ALTER TABLE bookings ADD COLUMN revision INTEGER NOT NULL DEFAULT 0;
+
+-- A calendar feed is the private URL of the bookings of one user.
+CREATE TABLE IF NOT EXISTS calendar_feeds
+(
+    id INTEGER PRIMARY KEY,
+    email TEXT NOT NULL UNIQUE,
+    token TEXT NOT NULL UNIQUE,
+    createdAt INTEGER NOT NULL
+);
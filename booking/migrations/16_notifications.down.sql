@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS notification_settings;
+
+DROP INDEX IF EXISTS idx_notifications_bookingUid;
+DROP INDEX IF EXISTS idx_notifications_status_nextAttemptAt;
+DROP TABLE IF EXISTS notifications;
@@ -0,0 +1,35 @@
+-- A notification is the delivery of one message about a booking over one
+-- channel. key keeps the same message from being queued twice.
+CREATE TABLE IF NOT EXISTS notifications
+(
+    id INTEGER PRIMARY KEY,
+    uid TEXT NOT NULL UNIQUE,
+    key TEXT NOT NULL UNIQUE,
+    email TEXT NOT NULL,
+    kind TEXT NOT NULL,
+    channel TEXT NOT NULL,
+    locale TEXT NOT NULL,
+    bookingUid TEXT NOT NULL,
+    boxName TEXT NOT NULL,
+    startsAt INTEGER NOT NULL,
+    expiresAt INTEGER NOT NULL,
+    peopleAmount INTEGER NOT NULL,
+    amount INTEGER NOT NULL DEFAULT 0,
+    remindBefore INTEGER NOT NULL DEFAULT 0,
+    status TEXT NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    nextAttemptAt INTEGER NOT NULL,
+    error TEXT NOT NULL DEFAULT '',
+    sentAt INTEGER,
+    createdAt INTEGER NOT NULL,
+    updatedAt INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_notifications_status_nextAttemptAt ON notifications (status, nextAttemptAt);
+CREATE INDEX IF NOT EXISTS idx_notifications_bookingUid ON notifications (bookingUid);
+
+CREATE TABLE IF NOT EXISTS notification_settings
+(
+    email TEXT PRIMARY KEY,
+    locale TEXT NOT NULL,
+    updatedAt INTEGER NOT NULL
+);
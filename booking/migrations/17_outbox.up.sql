@@ -0,0 +1,17 @@
+-- The outbox holds booking lifecycle events written together with the
+-- booking change. The relay publishes them in id order and sets publishedAt.
+CREATE TABLE IF NOT EXISTS outbox
+(
+    id INTEGER PRIMARY KEY,
+    uid TEXT NOT NULL UNIQUE,
+    type TEXT NOT NULL,
+    bookingUid TEXT NOT NULL,
+    payload TEXT NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    nextAttemptAt INTEGER NOT NULL,
+    error TEXT NOT NULL DEFAULT '',
+    publishedAt INTEGER,
+    createdAt INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_outbox_unpublished ON outbox (id) WHERE publishedAt IS NULL;
+CREATE INDEX IF NOT EXISTS idx_outbox_bookingUid ON outbox (bookingUid);
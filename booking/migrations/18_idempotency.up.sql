@@ -0,0 +1,15 @@
+-- Results of requests made with an idempotency key, so a retried request
+-- gets the first result instead of running again. result is NULL while the
+-- first request is still running. sagaId is the saga a request was left to the
+-- recovery with, its result only stands once that saga has completed.
+CREATE TABLE IF NOT EXISTS idempotency_keys
+(
+    email TEXT NOT NULL,
+    key TEXT NOT NULL,
+    operation TEXT NOT NULL,
+    request TEXT NOT NULL,
+    result TEXT,
+    sagaId INTEGER REFERENCES sagas (id),
+    createdAt INTEGER NOT NULL,
+    PRIMARY KEY (email, key)
+);
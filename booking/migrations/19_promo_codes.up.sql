@@ -0,0 +1,39 @@
+-- A promo code takes value percent (kind 'percent') or value (kind 'fixed') off
+-- the price of a booking. Empty boxName applies to every box. The booking has to
+-- fit between startsAt and endsAt, local to the box, endsAt '24:00' means
+-- midnight. NULL validFrom and validUntil leave the window open, zero
+-- maxRedemptions and maxPerUser mean no limit.
+CREATE TABLE IF NOT EXISTS promo_codes
+(
+    id INTEGER PRIMARY KEY,
+    code TEXT NOT NULL UNIQUE COLLATE NOCASE,
+    kind TEXT NOT NULL,
+    value INTEGER NOT NULL,
+    boxName TEXT NOT NULL DEFAULT '',
+    startsAt TEXT NOT NULL DEFAULT '00:00',
+    endsAt TEXT NOT NULL DEFAULT '24:00',
+    validFrom INTEGER,
+    validUntil INTEGER,
+    maxRedemptions INTEGER NOT NULL DEFAULT 0,
+    maxPerUser INTEGER NOT NULL DEFAULT 0
+);
+
+-- A redemption counts against the limits of its code until returnedAt is set,
+-- when its booking fails or is cancelled.
+CREATE TABLE IF NOT EXISTS promo_redemptions
+(
+    id INTEGER PRIMARY KEY,
+    code TEXT NOT NULL COLLATE NOCASE,
+    email TEXT NOT NULL,
+    bookingId INTEGER NOT NULL UNIQUE,
+    discount INTEGER NOT NULL,
+    createdAt INTEGER NOT NULL,
+    returnedAt INTEGER
+);
+CREATE INDEX IF NOT EXISTS idx_promo_redemptions_code_email ON promo_redemptions (code, email);
+
+INSERT INTO promo_codes (code, kind, value, maxPerUser)
+VALUES ('FIRST50', 'percent', 50, 1);
+
+INSERT INTO promo_codes (code, kind, value, startsAt, endsAt)
+VALUES ('MORNING20', 'percent', 20, '06:00', '12:00');
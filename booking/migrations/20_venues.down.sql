@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_boxes_venueId;
+ALTER TABLE boxes DROP COLUMN venueId;
+
+DROP INDEX IF EXISTS idx_venues_latitude;
+DROP TABLE IF EXISTS venues;
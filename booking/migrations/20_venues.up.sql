@@ -0,0 +1,28 @@
+-- A venue is the place a box stands at. Boxes of a venue take its time zone,
+-- venueId 0 leaves a box without one.
+CREATE TABLE IF NOT EXISTS venues
+(
+    id INTEGER PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    address TEXT NOT NULL DEFAULT '',
+    latitude REAL NOT NULL,
+    longitude REAL NOT NULL,
+    timeZone TEXT NOT NULL DEFAULT 'Asia/Novosibirsk',
+    phone TEXT NOT NULL DEFAULT '',
+    email TEXT NOT NULL DEFAULT '',
+    website TEXT NOT NULL DEFAULT ''
+);
+CREATE INDEX IF NOT EXISTS idx_venues_latitude ON venues (latitude);
+
+ALTER TABLE boxes ADD COLUMN venueId INTEGER NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS idx_boxes_venueId ON boxes (venueId);
+
+INSERT OR IGNORE INTO venues (name, address, latitude, longitude, timeZone)
+VALUES
+    ('Sibirskaya', 'Sibirskaya St., Novosibirsk', 55.0415, 82.9238, 'Asia/Novosibirsk'),
+    ('Lenina', 'Lenina St., Novosibirsk', 55.0302, 82.9135, 'Asia/Novosibirsk'),
+    ('Lunacharskogo', 'Lunacharskogo St., Novosibirsk', 55.0447, 82.9296, 'Asia/Novosibirsk');
+
+UPDATE boxes SET venueId = (SELECT id FROM venues WHERE name = 'Sibirskaya') WHERE name = 'SibirskayaBox';
+UPDATE boxes SET venueId = (SELECT id FROM venues WHERE name = 'Lenina') WHERE name = 'LeninaBox';
+UPDATE boxes SET venueId = (SELECT id FROM venues WHERE name = 'Lunacharskogo') WHERE name = 'LunacharskogoBox';
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_booking_participants_email_status;
+DROP TABLE IF EXISTS booking_participants;
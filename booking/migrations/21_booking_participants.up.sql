@@ -0,0 +1,13 @@
+-- A participant is invited to a booking by its owner. status is invited,
+-- accepted or declined, accepted participants see the booking as their own.
+CREATE TABLE IF NOT EXISTS booking_participants
+(
+    id INTEGER PRIMARY KEY,
+    bookingId INTEGER NOT NULL,
+    email TEXT NOT NULL COLLATE NOCASE,
+    status TEXT NOT NULL DEFAULT 'invited',
+    invitedAt INTEGER NOT NULL,
+    respondedAt INTEGER,
+    UNIQUE (bookingId, email)
+);
+CREATE INDEX IF NOT EXISTS idx_booking_participants_email_status ON booking_participants (email, status);
@@ -0,0 +1,12 @@
+-- Failed PIN attempts at a door of a box. Wrong PINs are counted per door, so
+-- guessing at one door doesn't lock the others. failures counts the attempts
+-- since firstFailedAt, too many of them lock PIN entry until lockedUntil.
+CREATE TABLE IF NOT EXISTS access_pin_attempts
+(
+    boxName TEXT NOT NULL,
+    door TEXT NOT NULL,
+    failures INTEGER NOT NULL DEFAULT 0,
+    firstFailedAt INTEGER NOT NULL,
+    lockedUntil INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (boxName, door)
+);
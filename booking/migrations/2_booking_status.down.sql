@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_boxName_startsAt;
+DROP INDEX IF EXISTS idx_status_expiresAt;
+ALTER TABLE bookings DROP COLUMN completedAt;
+ALTER TABLE bookings DROP COLUMN cancelledAt;
+ALTER TABLE bookings DROP COLUMN status;
@@ -0,0 +1,5 @@
+ALTER TABLE bookings ADD COLUMN status TEXT NOT NULL DEFAULT 'active';
+ALTER TABLE bookings ADD COLUMN cancelledAt INTEGER;
+ALTER TABLE bookings ADD COLUMN completedAt INTEGER;
+CREATE INDEX IF NOT EXISTS idx_status_expiresAt ON bookings (status, expiresAt);
+CREATE INDEX IF NOT EXISTS idx_boxName_startsAt ON bookings (boxName, startsAt);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_email_startsAt;
+ALTER TABLE bookings DROP COLUMN pricePaid;
+ALTER TABLE bookings DROP COLUMN peopleAmount;
@@ -0,0 +1,3 @@
+ALTER TABLE bookings ADD COLUMN peopleAmount INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE bookings ADD COLUMN pricePaid INTEGER NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS idx_email_startsAt ON bookings (email, startsAt);
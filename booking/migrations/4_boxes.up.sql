@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS boxes
+(
+    id INTEGER PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    address TEXT NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    capacity INTEGER NOT NULL DEFAULT 1,
+    pricePerHour INTEGER NOT NULL,
+    timeZone TEXT NOT NULL DEFAULT 'Asia/Novosibirsk',
+    active INTEGER NOT NULL DEFAULT 1
+);
+CREATE INDEX IF NOT EXISTS idx_boxes_active ON boxes (active);
+
+INSERT OR IGNORE INTO boxes (name, address, description, capacity, pricePerHour, timeZone)
+VALUES
+    ('SibirskayaBox', 'Sibirskaya St.', 'Sport box on Sibirskaya street', 1, 780, 'Asia/Novosibirsk'),
+    ('LeninaBox', 'Lenina St.', 'Sport box on Lenina street', 1, 780, 'Asia/Novosibirsk'),
+    ('LunacharskogoBox', 'Lunacharskogo St.', 'Sport box on Lunacharskogo street', 1, 780, 'Asia/Novosibirsk');
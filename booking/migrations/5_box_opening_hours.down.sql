@@ -0,0 +1,2 @@
+ALTER TABLE boxes DROP COLUMN closesAt;
+ALTER TABLE boxes DROP COLUMN opensAt;
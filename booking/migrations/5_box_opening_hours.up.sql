@@ -0,0 +1,2 @@
+ALTER TABLE boxes ADD COLUMN opensAt TEXT NOT NULL DEFAULT '08:00';
+ALTER TABLE boxes ADD COLUMN closesAt TEXT NOT NULL DEFAULT '22:00';
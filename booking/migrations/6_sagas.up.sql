@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS sagas
+(
+    id INTEGER PRIMARY KEY,
+    kind TEXT NOT NULL,
+    state TEXT NOT NULL,
+    bookingId INTEGER NOT NULL REFERENCES bookings (id),
+    email TEXT NOT NULL,
+    amount INTEGER NOT NULL,
+    paymentKey TEXT NOT NULL DEFAULT '',
+    paymentAmount INTEGER NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    createdAt INTEGER NOT NULL,
+    updatedAt INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_sagas_state_updatedAt ON sagas (state, updatedAt);
@@ -0,0 +1,3 @@
+UPDATE boxes SET capacity = 1 WHERE name IN ('SibirskayaBox', 'LeninaBox', 'LunacharskogoBox');
+
+ALTER TABLE boxes DROP COLUMN sharedSessions;
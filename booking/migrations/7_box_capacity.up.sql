@@ -0,0 +1,3 @@
+ALTER TABLE boxes ADD COLUMN sharedSessions INTEGER NOT NULL DEFAULT 0;
+
+UPDATE boxes SET capacity = 12 WHERE name IN ('SibirskayaBox', 'LeninaBox', 'LunacharskogoBox') AND capacity = 1;
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS holiday_rates;
+DROP TABLE IF EXISTS price_rules;
+
+ALTER TABLE boxes DROP COLUMN surgePercent;
+ALTER TABLE boxes DROP COLUMN surgeOccupancy;
+ALTER TABLE boxes DROP COLUMN minCharge;
@@ -0,0 +1,42 @@
+ALTER TABLE boxes ADD COLUMN minCharge INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE boxes ADD COLUMN surgeOccupancy INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE boxes ADD COLUMN surgePercent INTEGER NOT NULL DEFAULT 100;
+
+-- A rule multiplies the base rate of the minutes it covers by percent/100.
+-- Empty boxName applies to every box, NULL weekday (0 is Sunday) to every day.
+-- Times are local to the box, endsAt '24:00' means midnight.
+CREATE TABLE IF NOT EXISTS price_rules
+(
+    id INTEGER PRIMARY KEY,
+    name TEXT NOT NULL,
+    boxName TEXT NOT NULL DEFAULT '',
+    weekday INTEGER,
+    startsAt TEXT NOT NULL DEFAULT '00:00',
+    endsAt TEXT NOT NULL DEFAULT '24:00',
+    percent INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_price_rules_boxName ON price_rules (boxName);
+
+-- A holiday rate replaces every rule on its date.
+CREATE TABLE IF NOT EXISTS holiday_rates
+(
+    id INTEGER PRIMARY KEY,
+    name TEXT NOT NULL,
+    boxName TEXT NOT NULL DEFAULT '',
+    date TEXT NOT NULL,
+    percent INTEGER NOT NULL,
+    UNIQUE (boxName, date)
+);
+
+INSERT INTO price_rules (name, weekday, startsAt, endsAt, percent)
+VALUES
+    ('evening peak', 1, '18:00', '22:00', 125),
+    ('evening peak', 2, '18:00', '22:00', 125),
+    ('evening peak', 3, '18:00', '22:00', 125),
+    ('evening peak', 4, '18:00', '22:00', 125),
+    ('evening peak', 5, '18:00', '22:00', 125),
+    ('weekend', 6, '00:00', '24:00', 115),
+    ('weekend', 0, '00:00', '24:00', 115);
+
+UPDATE boxes SET minCharge = 390, surgeOccupancy = 80, surgePercent = 110
+WHERE name IN ('SibirskayaBox', 'LeninaBox', 'LunacharskogoBox');
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_bookings_uid;
+
+ALTER TABLE bookings DROP COLUMN uid;
@@ -0,0 +1,9 @@
+ALTER TABLE bookings ADD COLUMN uid TEXT;
+
+-- Random version 4 UUIDs for the bookings made before opaque IDs.
+UPDATE bookings SET uid = lower(
+    hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' || substr(hex(randomblob(2)), 2) || '-' ||
+    substr('89ab', 1 + (abs(random()) % 4), 1) || substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6))
+) WHERE uid IS NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bookings_uid ON bookings (uid);
@@ -0,0 +1,188 @@
+package tests
+
+import (
+	"booking/internal/clients/lock"
+	"booking/internal/domain/models"
+	bookgrpc "booking/internal/grpc/book"
+	"booking/internal/services/book"
+	"booking/tests/suite"
+	"database/sql"
+	"fmt"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestVerifyAccess_LockOpensWithPINAndToken(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	// The PIN is stored with the booking.
+	booking, err := st.Service.Booking(ctx, bookSlot(t, st, "door@example.com", time.Now().Add(5*time.Minute)).UID)
+	require.NoError(t, err)
+
+	credentials := st.Service.Credentials(booking)
+
+	require.Len(t, credentials.PIN, 6)
+	require.NotEmpty(t, credentials.Token)
+	assert.True(t, booking.StartsAt.Add(-suite.EarlyEntry).Equal(credentials.ValidFrom))
+	assert.True(t, booking.ExpiresAt.Equal(credentials.ValidUntil))
+
+	door := newLock(t, st, boxName)
+
+	for _, code := range []string{credentials.PIN, credentials.Token} {
+		result, err := door.Try(ctx, code)
+		require.NoError(t, err)
+		assert.True(t, result.Open, result.Reason)
+		assert.Equal(t, booking.UID, result.BookingUID)
+	}
+
+	// The PIN opens the door once, the token keeps working.
+	result, err := door.Try(ctx, credentials.PIN)
+	require.NoError(t, err)
+	assert.False(t, result.Open)
+	assert.Equal(t, book.ErrInvalidAccessCode.Error(), result.Reason)
+
+	result, err = door.Try(ctx, credentials.Token)
+	require.NoError(t, err)
+	assert.True(t, result.Open, result.Reason)
+
+	result, err = door.Try(ctx, credentials.Token+"x")
+	require.NoError(t, err)
+	assert.False(t, result.Open)
+	assert.Equal(t, book.ErrInvalidAccessCode.Error(), result.Reason)
+
+	stored, err := st.Service.Booking(ctx, booking.UID)
+	require.NoError(t, err)
+	assert.False(t, stored.CheckedInAt.IsZero())
+
+	// The door of another box stays locked.
+	result, err = newLock(t, st, "SibirskayaBox").Try(ctx, credentials.Token)
+	require.NoError(t, err)
+	assert.False(t, result.Open)
+}
+
+func TestVerifyAccess_Window(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	const email = "window@example.com"
+
+	later, err := st.Service.Booking(ctx, bookSlot(t, st, email, time.Now().Add(2*time.Hour).Truncate(time.Minute)).UID)
+	require.NoError(t, err)
+
+	_, _, err = st.Service.VerifyAccess(ctx, boxName, st.Service.Credentials(later).Token, "main")
+	assert.ErrorIs(t, err, book.ErrAccessNotYetValid)
+
+	db, err := sql.Open("sqlite3", st.StoragePath)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec("UPDATE bookings SET startsAt = ?, expiresAt = ? WHERE uid = ?",
+		time.Now().Add(-2*time.Hour).Unix(), time.Now().Add(-time.Hour).Unix(), later.UID)
+	require.NoError(t, err)
+
+	_, _, err = st.Service.VerifyAccess(ctx, boxName, st.Service.Credentials(later).Token, "main")
+	assert.ErrorIs(t, err, book.ErrAccessExpired)
+
+	_, _, err = st.Service.VerifyAccess(ctx, boxName, later.AccessPIN, "main")
+	assert.ErrorIs(t, err, book.ErrInvalidAccessCode)
+
+	soon := bookSlot(t, st, email, time.Now().Add(10*time.Minute))
+
+	_, _, err = st.Service.CancelBooking(ctx, email, soon.UID, models.CancelByUser, "")
+	require.NoError(t, err)
+
+	_, _, err = st.Service.VerifyAccess(ctx, boxName, st.Service.Credentials(soon).Token, "main")
+	assert.ErrorIs(t, err, book.ErrBookingNotActive)
+}
+
+func TestVerifyAccess_WrongPINsLockPINEntryAtTheDoor(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	first, err := st.Service.Booking(ctx, bookSlot(t, st, "guess@example.com", time.Now().Add(5*time.Minute)).UID)
+	require.NoError(t, err)
+
+	pin := st.Service.Credentials(first).PIN
+
+	wrong := wrongPIN(t, pin)
+
+	for range suite.MaxPINAttempts - 1 {
+		_, _, err = st.Service.VerifyAccess(ctx, boxName, wrong, "main")
+		require.ErrorIs(t, err, book.ErrInvalidAccessCode)
+	}
+
+	// Below the limit the right PIN still opens the door.
+	_, _, err = st.Service.VerifyAccess(ctx, boxName, pin, "main")
+	require.NoError(t, err)
+
+	second, err := st.Service.Booking(ctx, bookSlot(t, st, "late@example.com", time.Now().Add(2*time.Hour).Truncate(time.Minute)).UID)
+	require.NoError(t, err)
+
+	_, _, err = st.Service.VerifyAccess(ctx, boxName, wrong, "main")
+	require.ErrorIs(t, err, book.ErrInvalidAccessCode)
+
+	// Now PIN entry at this door is locked, other doors and tokens still work.
+	db, err := sql.Open("sqlite3", st.StoragePath)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec("UPDATE bookings SET startsAt = ? WHERE uid = ?", time.Now().Add(-time.Minute).Unix(), second.UID)
+	require.NoError(t, err)
+
+	secondPIN := st.Service.Credentials(second).PIN
+
+	_, _, err = st.Service.VerifyAccess(ctx, boxName, secondPIN, "main")
+	require.ErrorIs(t, err, book.ErrAccessLocked)
+
+	_, _, err = st.Service.VerifyAccess(ctx, boxName, st.Service.Credentials(first).Token, "main")
+	require.NoError(t, err)
+
+	_, _, err = st.Service.VerifyAccess(ctx, boxName, secondPIN, "side")
+	require.NoError(t, err)
+
+	// The lock ends after the lockout.
+	_, err = db.Exec("UPDATE access_pin_attempts SET lockedUntil = ? WHERE boxName = ? AND door = ?", time.Now().Add(-time.Second).Unix(), boxName, "main")
+	require.NoError(t, err)
+
+	_, _, err = st.Service.VerifyAccess(ctx, boxName, pin, "main")
+	require.ErrorIs(t, err, book.ErrInvalidAccessCode, "the PIN has been used")
+}
+
+// wrongPIN returns a PIN other than pin.
+func wrongPIN(t *testing.T, pin string) string {
+	t.Helper()
+
+	n, err := strconv.Atoi(pin)
+	require.NoError(t, err)
+
+	return fmt.Sprintf("%06d", (n+1)%1_000_000)
+}
+
+// newLock serves the booking service over gRPC and returns a simulated door
+// of the box talking to it.
+func newLock(t *testing.T, st *suite.Suite, boxName string) *lock.Lock {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	srv := grpc.NewServer()
+	bookgrpc.Register(srv, st.Service)
+
+	go func() {
+		_ = srv.Serve(l)
+	}()
+	t.Cleanup(srv.Stop)
+
+	door, err := lock.New(t.Context(), l.Addr().String(), boxName, "main")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = door.Close()
+	})
+
+	return door
+}
@@ -0,0 +1,134 @@
+package tests
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/services/book"
+	"booking/tests/suite"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCancelBooking_ByUID(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	const email = "cancel-uid@example.com"
+
+	_, _, err := st.Payments.AddFunds(ctx, email, funds, "")
+	require.NoError(t, err)
+
+	startsAt := time.Now().Add(96 * time.Hour).Truncate(time.Hour)
+
+	booking, _, _, err := st.Service.Book(ctx, email, boxName, startsAt, time.Hour, 1, "", "")
+	require.NoError(t, err)
+	require.NotEmpty(t, booking.UID)
+
+	_, _, err = st.Service.CancelBooking(ctx, email, booking.UID, models.CancelByUser, "")
+	require.NoError(t, err)
+
+	_, _, err = st.Service.CancelBooking(ctx, email, booking.UID, models.CancelByUser, "")
+	assert.ErrorIs(t, err, book.ErrBookingNotActive)
+}
+
+func TestCancelBooking_ByLegacyID(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	const email = "cancel-legacy@example.com"
+
+	_, _, err := st.Payments.AddFunds(ctx, email, funds, "")
+	require.NoError(t, err)
+
+	startsAt := time.Now().Add(120 * time.Hour).Truncate(time.Hour)
+
+	booking, _, _, err := st.Service.Book(ctx, email, boxName, startsAt, time.Hour, 1, "", "")
+	require.NoError(t, err)
+	require.Positive(t, booking.ID)
+
+	_, _, err = st.Service.CancelBooking(ctx, email, strconv.FormatInt(booking.ID+1, 10), models.CancelByUser, "")
+	assert.ErrorIs(t, err, book.ErrBookingNotFound)
+
+	_, _, err = st.Service.CancelBooking(ctx, "someone@example.com", strconv.FormatInt(booking.ID, 10), models.CancelByUser, "")
+	assert.ErrorIs(t, err, book.ErrNotYourBooking)
+
+	_, _, err = st.Service.CancelBooking(ctx, email, strconv.FormatInt(booking.ID, 10), models.CancelByUser, "")
+	require.NoError(t, err)
+}
+
+func TestCancelBooking_RefundPolicy(t *testing.T) {
+	tests := []struct {
+		name      string
+		startsIn  time.Duration
+		initiator models.CancelInitiator
+		policy    models.RefundPolicyName
+		percent   int64
+	}{
+		{
+			name:      "full refund before the deadline",
+			startsIn:  suite.FullRefundBefore + 2*time.Hour,
+			initiator: models.CancelByUser,
+			policy:    models.RefundPolicyFull,
+			percent:   100,
+		},
+		{
+			name:      "partial refund after the deadline",
+			startsIn:  2 * time.Hour,
+			initiator: models.CancelByUser,
+			policy:    models.RefundPolicyPartial,
+			percent:   suite.PartialPercent,
+		},
+		{
+			name:      "no refund once started",
+			startsIn:  -30 * time.Minute,
+			initiator: models.CancelByUser,
+			policy:    models.RefundPolicyNone,
+			percent:   0,
+		},
+		{
+			name:      "operator always refunds in full",
+			startsIn:  -30 * time.Minute,
+			initiator: models.CancelByOperator,
+			policy:    models.RefundPolicyOperator,
+			percent:   100,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, st := suite.New(t)
+
+			const email = "refund@example.com"
+
+			_, _, err := st.Payments.AddFunds(ctx, email, funds, "")
+			require.NoError(t, err)
+
+			startsAt := time.Now().Add(tt.startsIn).Truncate(time.Minute)
+
+			booking, _, _, err := st.Service.Book(ctx, email, boxName, startsAt, 2*time.Hour, 1, "", "")
+			require.NoError(t, err)
+			require.Positive(t, booking.PricePaid)
+
+			cancelledBy := email
+			if tt.initiator == models.CancelByOperator {
+				cancelledBy = ""
+			}
+
+			refund, balance, err := st.Service.CancelBooking(ctx, cancelledBy, booking.UID, tt.initiator, "")
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.policy, refund.Policy)
+			assert.Equal(t, tt.percent, refund.Percent)
+			assert.Equal(t, booking.PricePaid*tt.percent/100, refund.Amount)
+			assert.Equal(t, startsAt.Add(-suite.FullRefundBefore), refund.FullRefundUntil)
+
+			wallet, err := st.Payments.Balance(ctx, email)
+			require.NoError(t, err)
+			assert.Equal(t, funds-booking.PricePaid+refund.Amount, wallet)
+			if refund.Amount > 0 {
+				assert.Equal(t, wallet, balance)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package tests
+
+import (
+	"booking/internal/storage"
+	"booking/tests/suite"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBookABox_SharedSession_FillsUpToCapacity(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	shareBox(t, st, boxName, 12)
+
+	startsAt := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+
+	_, err := st.Storage.BookABox(ctx, "first@example.com", boxName, startsAt, startsAt.Add(time.Hour), 5, price)
+	require.NoError(t, err)
+
+	_, err = st.Storage.BookABox(ctx, "second@example.com", boxName, startsAt.Add(30*time.Minute), startsAt.Add(90*time.Minute), 7, price)
+	require.NoError(t, err)
+
+	_, err = st.Storage.BookABox(ctx, "third@example.com", boxName, startsAt, startsAt.Add(time.Hour), 1, price)
+	require.ErrorIs(t, err, storage.ErrAlreadyBooked)
+
+	// The first booking is over by then, the box has room for five people again.
+	_, err = st.Storage.BookABox(ctx, "third@example.com", boxName, startsAt.Add(time.Hour), startsAt.Add(2*time.Hour), 5, price)
+	require.NoError(t, err)
+
+	box, err := st.Storage.Box(ctx, boxName)
+	require.NoError(t, err)
+	assert.True(t, box.SharedSessions)
+	assert.Equal(t, int64(12), box.Capacity)
+}
+
+func shareBox(t *testing.T, st *suite.Suite, name string, capacity int64) {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", st.StoragePath)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec("UPDATE boxes SET sharedSessions = 1, capacity = ? WHERE name = ?", capacity, name)
+	require.NoError(t, err)
+}
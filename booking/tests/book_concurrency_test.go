@@ -0,0 +1,134 @@
+package tests
+
+import (
+	"booking/internal/services/book"
+	"booking/internal/storage"
+	"booking/tests/suite"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	boxName       = "LeninaBox"
+	parallelCalls = 50
+	price         = 780
+	// funds covers any price of a one hour booking with the seeded rules.
+	funds = 10000
+)
+
+func TestBook_ConcurrentSameSlot_OneWins(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	startsAt := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		winners []string
+		errs    []error
+	)
+
+	for i := 0; i < parallelCalls; i++ {
+		email := fmt.Sprintf("user%d@example.com", i)
+		_, _, err := st.Payments.AddFunds(ctx, email, funds, "")
+		require.NoError(t, err)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			booking, _, _, err := st.Service.Book(ctx, email, boxName, startsAt, time.Hour, 1, "", "")
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				errs = append(errs, err)
+				return
+			}
+			winners = append(winners, booking.UID)
+		}()
+	}
+
+	wg.Wait()
+
+	require.Len(t, winners, 1)
+	require.Len(t, errs, parallelCalls-1)
+	for _, err := range errs {
+		assert.ErrorIs(t, err, book.ErrAlreadyBooked)
+	}
+
+	// Only the winner has been charged.
+	charged := 0
+	for i := 0; i < parallelCalls; i++ {
+		balance, err := st.Payments.Balance(ctx, fmt.Sprintf("user%d@example.com", i))
+		require.NoError(t, err)
+		if balance < funds {
+			charged++
+		}
+	}
+	assert.Equal(t, 1, charged)
+}
+
+func TestBookABox_ConcurrentOverlappingSlots_OneWins(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	startsAt := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		winners  int
+		conflict int
+	)
+
+	for i := 0; i < parallelCalls; i++ {
+		// Every request overlaps all the others by at least half an hour.
+		shift := time.Duration(i%30) * time.Minute
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			_, err := st.Storage.BookABox(ctx, "user@example.com", boxName, startsAt.Add(shift), startsAt.Add(shift+time.Hour), 1, price)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			switch {
+			case err == nil:
+				winners++
+			case errors.Is(err, storage.ErrAlreadyBooked):
+				conflict++
+			default:
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, 1, winners)
+	assert.Equal(t, parallelCalls-1, conflict)
+}
+
+func TestBookABox_AdjacentSlots_BothSucceed(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	startsAt := time.Now().Add(72 * time.Hour).Truncate(time.Hour)
+
+	_, err := st.Storage.BookABox(ctx, "user@example.com", boxName, startsAt, startsAt.Add(time.Hour), 1, price)
+	require.NoError(t, err)
+
+	_, err = st.Storage.BookABox(ctx, "user@example.com", boxName, startsAt.Add(time.Hour), startsAt.Add(2*time.Hour), 1, price)
+	require.NoError(t, err)
+
+	_, err = st.Storage.BookABox(ctx, "user@example.com", boxName, startsAt.Add(30*time.Minute), startsAt.Add(90*time.Minute), 1, price)
+	require.ErrorIs(t, err, storage.ErrAlreadyBooked)
+}
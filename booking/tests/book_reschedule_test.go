@@ -0,0 +1,122 @@
+package tests
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/services/book"
+	"booking/tests/suite"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRescheduleBooking_PriceDifference(t *testing.T) {
+	tests := []struct {
+		name        string
+		oldDuration time.Duration
+		newDuration time.Duration
+	}{
+		{name: "same length", oldDuration: time.Hour, newDuration: time.Hour},
+		{name: "longer booking is charged", oldDuration: time.Hour, newDuration: 2 * time.Hour},
+		{name: "shorter booking is refunded", oldDuration: 2 * time.Hour, newDuration: time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, st := suite.New(t)
+
+			const email = "move@example.com"
+
+			_, _, err := st.Payments.AddFunds(ctx, email, funds, "")
+			require.NoError(t, err)
+
+			startsAt := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+			newStartsAt := startsAt.Add(24 * time.Hour)
+
+			booking, _, _, err := st.Service.Book(ctx, email, boxName, startsAt, tt.oldDuration, 1, "", "")
+			require.NoError(t, err)
+
+			moved, price, balance, err := st.Service.RescheduleBooking(ctx, email, booking.UID, boxName, newStartsAt, tt.newDuration)
+			require.NoError(t, err)
+
+			assert.Equal(t, booking.UID, moved.UID)
+			assert.True(t, newStartsAt.Equal(moved.StartsAt))
+			assert.True(t, newStartsAt.Add(tt.newDuration).Equal(moved.ExpiresAt))
+			assert.Equal(t, price.Total, moved.PricePaid)
+
+			wallet, err := st.Payments.Balance(ctx, email)
+			require.NoError(t, err)
+			assert.Equal(t, funds-price.Total, wallet)
+			assert.Equal(t, wallet, balance)
+
+			stored, err := st.Service.Booking(ctx, booking.UID)
+			require.NoError(t, err)
+			assert.True(t, newStartsAt.Equal(stored.StartsAt))
+			assert.Equal(t, price.Total, stored.PricePaid)
+			assert.Equal(t, models.BookingStatusActive, stored.Status)
+
+			// The old slot is free again.
+			bookSlot(t, st, "other@example.com", startsAt)
+		})
+	}
+}
+
+func TestRescheduleBooking_OverlapsItself(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	const email = "shift@example.com"
+
+	startsAt := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+	booking := bookSlot(t, st, email, startsAt)
+
+	moved, _, _, err := st.Service.RescheduleBooking(ctx, email, booking.UID, boxName, startsAt.Add(30*time.Minute), time.Hour)
+	require.NoError(t, err)
+	assert.True(t, startsAt.Add(30*time.Minute).Equal(moved.StartsAt))
+}
+
+func TestRescheduleBooking_SlotTaken(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	const email = "blocked@example.com"
+
+	startsAt := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+	booking := bookSlot(t, st, email, startsAt)
+	bookSlot(t, st, "other@example.com", startsAt.Add(24*time.Hour))
+
+	before, err := st.Payments.Balance(ctx, email)
+	require.NoError(t, err)
+
+	// A longer booking is charged first and refunded when the move fails.
+	_, _, _, err = st.Service.RescheduleBooking(ctx, email, booking.UID, boxName, startsAt.Add(24*time.Hour), 2*time.Hour)
+	assert.ErrorIs(t, err, book.ErrAlreadyBooked)
+
+	after, err := st.Payments.Balance(ctx, email)
+	require.NoError(t, err)
+	assert.Equal(t, before, after)
+
+	stored, err := st.Service.Booking(ctx, booking.UID)
+	require.NoError(t, err)
+	assert.True(t, startsAt.Equal(stored.StartsAt))
+}
+
+func TestRescheduleBooking_NotAllowed(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	const email = "owner@example.com"
+
+	startsAt := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+	booking := bookSlot(t, st, email, startsAt)
+
+	_, _, _, err := st.Service.RescheduleBooking(ctx, "someone@example.com", booking.UID, boxName, startsAt.Add(time.Hour), time.Hour)
+	assert.ErrorIs(t, err, book.ErrNotYourBooking)
+
+	_, _, _, err = st.Service.RescheduleBooking(ctx, email, "missing", boxName, startsAt.Add(time.Hour), time.Hour)
+	assert.ErrorIs(t, err, book.ErrBookingNotFound)
+
+	_, _, err = st.Service.CancelBooking(ctx, email, booking.UID, models.CancelByUser, "")
+	require.NoError(t, err)
+
+	_, _, _, err = st.Service.RescheduleBooking(ctx, email, booking.UID, boxName, startsAt.Add(time.Hour), time.Hour)
+	assert.ErrorIs(t, err, book.ErrBookingNotActive)
+}
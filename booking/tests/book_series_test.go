@@ -0,0 +1,165 @@
+package tests
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/services/book"
+	"booking/tests/suite"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const weeklyRule = "FREQ=WEEKLY;COUNT=4"
+
+func TestBookSeries_PerOccurrence_ReportsConflicts(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	const email = "regular@example.com"
+
+	_, _, err := st.Payments.AddFunds(ctx, email, funds, "")
+	require.NoError(t, err)
+
+	startsAt := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+
+	// Someone else already has the third week.
+	_, err = st.Storage.BookABox(ctx, "other@example.com", boxName, startsAt.AddDate(0, 0, 14), startsAt.AddDate(0, 0, 14).Add(time.Hour), 1, price)
+	require.NoError(t, err)
+
+	series, occurrences, balance, err := st.Service.BookSeries(ctx, email, boxName, startsAt, time.Hour, 1, weeklyRule, models.SeriesPaymentPerOccurrence)
+	require.NoError(t, err)
+	require.NotEmpty(t, series.UID)
+	require.Len(t, occurrences, 4)
+
+	var paid int64
+	for i, occurrence := range occurrences {
+		assert.True(t, startsAt.AddDate(0, 0, 7*i).Equal(occurrence.StartsAt))
+
+		if i == 2 {
+			assert.Equal(t, models.OccurrenceConflict, occurrence.Status)
+			assert.Empty(t, occurrence.BookingUID)
+			continue
+		}
+
+		assert.Equal(t, models.OccurrenceBooked, occurrence.Status)
+		assert.NotEmpty(t, occurrence.BookingUID)
+		paid += occurrence.Price.Total
+	}
+
+	assert.Equal(t, funds-paid, balance)
+
+	bookings, err := st.Storage.SeriesBookings(ctx, series.UID)
+	require.NoError(t, err)
+	assert.Len(t, bookings, 3)
+}
+
+func TestBookSeries_PendingPaymentIsReported(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	const email = "series-pending@example.com"
+
+	_, _, err := st.Payments.AddFunds(ctx, email, funds, "")
+	require.NoError(t, err)
+
+	startsAt := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+
+	// The reply to the charge of the first occurrence gets lost.
+	st.Payments.LoseReply(errLostReply)
+
+	_, occurrences, _, err := st.Service.BookSeries(ctx, email, boxName, startsAt, time.Hour, 1, weeklyRule, models.SeriesPaymentPerOccurrence)
+	require.NoError(t, err)
+	require.Len(t, occurrences, 4)
+
+	assert.Equal(t, models.OccurrencePaymentPending, occurrences[0].Status)
+	assert.Equal(t, book.ErrPaymentPending.Error(), occurrences[0].Error)
+	assert.NotEmpty(t, occurrences[0].BookingUID)
+
+	for _, occurrence := range occurrences[1:] {
+		assert.Equal(t, models.OccurrenceBooked, occurrence.Status)
+	}
+
+	require.NoError(t, st.Service.RecoverSagas(ctx, -time.Minute))
+
+	booking, err := st.Service.Booking(ctx, occurrences[0].BookingUID)
+	require.NoError(t, err)
+	assert.Equal(t, models.BookingStatusActive, booking.Status)
+}
+
+func TestBookSeries_Upfront_AllOrNothing(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	const email = "upfront@example.com"
+
+	// Enough for one occurrence, not for the series.
+	_, _, err := st.Payments.AddFunds(ctx, email, 2*price, "")
+	require.NoError(t, err)
+
+	startsAt := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+
+	_, occurrences, _, err := st.Service.BookSeries(ctx, email, boxName, startsAt, time.Hour, 1, weeklyRule, models.SeriesPaymentUpfront)
+	require.NoError(t, err)
+
+	for _, occurrence := range occurrences {
+		assert.Equal(t, models.OccurrencePaymentFailed, occurrence.Status)
+		assert.Equal(t, book.ErrNotEnoughFunds.Error(), occurrence.Error)
+	}
+
+	wallet, err := st.Payments.Balance(ctx, email)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2*price), wallet)
+
+	// The released slots can be booked again.
+	free, err := st.Storage.IsSlotFree(ctx, boxName, startsAt, startsAt.Add(time.Hour), 1)
+	require.NoError(t, err)
+	assert.True(t, free)
+
+	_, _, err = st.Payments.AddFunds(ctx, email, funds, "")
+	require.NoError(t, err)
+
+	_, occurrences, balance, err := st.Service.BookSeries(ctx, email, boxName, startsAt, time.Hour, 1, weeklyRule, models.SeriesPaymentUpfront)
+	require.NoError(t, err)
+
+	var paid int64
+	for _, occurrence := range occurrences {
+		assert.Equal(t, models.OccurrenceBooked, occurrence.Status)
+		paid += occurrence.Price.Total
+	}
+	assert.Equal(t, funds+2*price-paid, balance)
+}
+
+func TestCancelSeries_OneOccurrenceThenTheRest(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	const email = "cancel-series@example.com"
+
+	_, _, err := st.Payments.AddFunds(ctx, email, funds, "")
+	require.NoError(t, err)
+
+	startsAt := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+
+	series, occurrences, _, err := st.Service.BookSeries(ctx, email, boxName, startsAt, time.Hour, 1, weeklyRule, models.SeriesPaymentPerOccurrence)
+	require.NoError(t, err)
+
+	_, _, err = st.Service.CancelBooking(ctx, email, occurrences[1].BookingUID, models.CancelByUser, "")
+	require.NoError(t, err)
+
+	_, _, err = st.Service.CancelSeries(ctx, "someone@example.com", series.UID, models.CancelByUser)
+	assert.ErrorIs(t, err, book.ErrNotYourBooking)
+
+	cancellations, balance, err := st.Service.CancelSeries(ctx, email, series.UID, models.CancelByUser)
+	require.NoError(t, err)
+	require.Len(t, cancellations, 3)
+
+	for _, cancellation := range cancellations {
+		assert.Empty(t, cancellation.Error)
+		assert.Equal(t, models.RefundPolicyFull, cancellation.Refund.Policy)
+	}
+	assert.Equal(t, int64(funds), balance)
+
+	_, _, err = st.Service.CancelSeries(ctx, email, series.UID, models.CancelByUser)
+	assert.ErrorIs(t, err, book.ErrBookingNotActive)
+
+	_, _, err = st.Service.CancelSeries(ctx, email, "unknown", models.CancelByUser)
+	assert.ErrorIs(t, err, book.ErrSeriesNotFound)
+}
@@ -0,0 +1,160 @@
+package tests
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/lib/ical"
+	"booking/internal/services/book"
+	"booking/tests/suite"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestICal_EscapesAndFoldsLines(t *testing.T) {
+	start := time.Date(2030, time.January, 7, 10, 0, 0, 0, time.UTC)
+
+	calendar := ical.Calendar{
+		ProdID: "-//test//EN",
+		Events: []ical.Event{{
+			UID:     "event@test",
+			Stamp:   start,
+			Start:   start,
+			End:     start.Add(time.Hour),
+			Summary: "Спортивная коробка; football, tennis\n" + strings.Repeat("бокс ", 20),
+		}},
+	}
+
+	raw := string(calendar.Marshal())
+
+	require.True(t, strings.HasSuffix(raw, "END:VCALENDAR\r\n"))
+
+	for _, line := range strings.Split(strings.TrimSuffix(raw, "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(line), 75, line)
+	}
+
+	unfolded := strings.ReplaceAll(raw, "\r\n ", "")
+	assert.Contains(t, unfolded, `SUMMARY:Спортивная коробка\; football\, tennis\n`+strings.Repeat("бокс ", 20)+"\r\n")
+	assert.Contains(t, unfolded, "DTSTART:20300107T100000Z\r\n")
+	assert.Contains(t, unfolded, "DTEND:20300107T110000Z\r\n")
+}
+
+func TestUserCalendar_RescheduleAndCancelKeepTheUID(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	const email = "calendar@example.com"
+
+	startsAt := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+	booking := bookSlot(t, st, email, startsAt)
+
+	feed, err := st.Service.CalendarFeed(ctx, email, false)
+	require.NoError(t, err)
+	require.NotEmpty(t, feed.Token)
+
+	again, err := st.Service.CalendarFeed(ctx, email, false)
+	require.NoError(t, err)
+	assert.Equal(t, feed.Token, again.Token)
+
+	uid := "UID:booking-" + booking.UID + "@sport-box\r\n"
+
+	calendar := userCalendar(t, st, feed.Token)
+	assert.Equal(t, 1, strings.Count(calendar, "BEGIN:VEVENT"))
+	assert.Contains(t, calendar, uid)
+	assert.Contains(t, calendar, "SEQUENCE:0\r\n")
+	assert.Contains(t, calendar, "STATUS:CONFIRMED\r\n")
+
+	movedTo := startsAt.Add(24 * time.Hour)
+
+	_, _, _, err = st.Service.RescheduleBooking(ctx, email, booking.UID, boxName, movedTo, time.Hour)
+	require.NoError(t, err)
+
+	calendar = userCalendar(t, st, feed.Token)
+	assert.Equal(t, 1, strings.Count(calendar, "BEGIN:VEVENT"))
+	assert.Contains(t, calendar, uid)
+	assert.Contains(t, calendar, "SEQUENCE:1\r\n")
+	assert.Contains(t, calendar, "DTSTART:"+movedTo.UTC().Format("20060102T150405Z")+"\r\n")
+
+	_, _, err = st.Service.CancelBooking(ctx, email, booking.UID, models.CancelByUser, "")
+	require.NoError(t, err)
+
+	calendar = userCalendar(t, st, feed.Token)
+	assert.Equal(t, 1, strings.Count(calendar, "BEGIN:VEVENT"))
+	assert.Contains(t, calendar, uid)
+	assert.Contains(t, calendar, "SEQUENCE:2\r\n")
+	assert.Contains(t, calendar, "STATUS:CANCELLED\r\n")
+
+	rotated, err := st.Service.CalendarFeed(ctx, email, true)
+	require.NoError(t, err)
+	assert.NotEqual(t, feed.Token, rotated.Token)
+
+	_, err = st.Service.UserCalendar(ctx, feed.Token)
+	assert.ErrorIs(t, err, book.ErrCalendarFeedNotFound)
+}
+
+func TestUserCalendar_JoinedBookings(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	const (
+		owner  = "calendar-owner@example.com"
+		friend = "calendar-friend@example.com"
+	)
+
+	booking := groupBooking(t, st, owner, 2)
+
+	_, err := st.Service.InviteParticipant(ctx, owner, booking.UID, friend)
+	require.NoError(t, err)
+
+	feed, err := st.Service.CalendarFeed(ctx, friend, false)
+	require.NoError(t, err)
+
+	uid := "UID:booking-" + booking.UID + "@sport-box\r\n"
+
+	// Like the bookings list, the feed shows accepted invitations only.
+	assert.NotContains(t, userCalendar(t, st, feed.Token), uid)
+
+	_, err = st.Service.RespondToInvitation(ctx, friend, booking.UID, true)
+	require.NoError(t, err)
+
+	assert.Contains(t, userCalendar(t, st, feed.Token), uid)
+}
+
+func TestBoxCalendar_WithoutPersonalData(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	const email = "private@example.com"
+
+	startsAt := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+	booking := bookSlot(t, st, email, startsAt)
+
+	_, err := st.Service.AddBlackout(ctx, models.Blackout{
+		BoxName:  boxName,
+		StartsAt: startsAt.Add(24 * time.Hour),
+		EndsAt:   startsAt.Add(26 * time.Hour),
+		Reason:   "floor repair",
+	})
+	require.NoError(t, err)
+
+	raw, err := st.Service.BoxCalendar(ctx, boxName)
+	require.NoError(t, err)
+
+	calendar := strings.ReplaceAll(string(raw), "\r\n ", "")
+	assert.Equal(t, 2, strings.Count(calendar, "BEGIN:VEVENT"))
+	assert.Contains(t, calendar, "UID:slot-"+booking.UID+"@sport-box\r\n")
+	assert.Contains(t, calendar, "SUMMARY:Maintenance: floor repair\r\n")
+	assert.NotContains(t, calendar, email)
+	assert.NotContains(t, calendar, "booking-"+booking.UID)
+
+	_, err = st.Service.BoxCalendar(ctx, "NoSuchBox")
+	assert.ErrorIs(t, err, book.ErrBoxNotFound)
+}
+
+func userCalendar(t *testing.T, st *suite.Suite, token string) string {
+	t.Helper()
+
+	raw, err := st.Service.UserCalendar(t.Context(), token)
+	require.NoError(t, err)
+
+	return strings.ReplaceAll(string(raw), "\r\n ", "")
+}
@@ -0,0 +1,180 @@
+package tests
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/services/book"
+	"booking/tests/suite"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHold_BlocksOthersAndIsBooked(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	const (
+		email = "checkout@example.com"
+		other = "other@example.com"
+	)
+
+	startsAt := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+
+	hold, price, err := st.Service.HoldSlot(ctx, email, boxName, startsAt, time.Hour, 1)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(suite.HoldTTL), hold.HeldUntil, time.Minute)
+	assert.Positive(t, price.Total)
+
+	// Nothing is charged for a hold.
+	_, _, err = st.Payments.AddFunds(ctx, email, funds, "")
+	require.NoError(t, err)
+
+	_, _, err = st.Payments.AddFunds(ctx, other, funds, "")
+	require.NoError(t, err)
+
+	_, _, _, err = st.Service.Book(ctx, other, boxName, startsAt.Add(30*time.Minute), time.Hour, 1, "", "")
+	require.ErrorIs(t, err, book.ErrAlreadyBooked)
+
+	_, _, err = st.Service.HoldSlot(ctx, other, boxName, startsAt, time.Hour, 1)
+	require.ErrorIs(t, err, book.ErrAlreadyBooked)
+
+	_, _, _, err = st.Service.BookHold(ctx, other, hold.UID, "", "")
+	require.ErrorIs(t, err, book.ErrNotYourHold)
+
+	booking, bookPrice, balance, err := st.Service.BookHold(ctx, email, hold.UID, "", "")
+	require.NoError(t, err)
+	assert.Equal(t, hold.UID, booking.UID)
+	assert.Equal(t, models.BookingStatusActive, booking.Status)
+	assert.Equal(t, price.Total, bookPrice.Total)
+	assert.EqualValues(t, funds-price.Total, balance)
+
+	stored, err := st.Service.Booking(ctx, booking.UID)
+	require.NoError(t, err)
+	assert.Equal(t, models.BookingStatusActive, stored.Status)
+	assert.Equal(t, price.Total, stored.PricePaid)
+
+	_, _, _, err = st.Service.BookHold(ctx, email, hold.UID, "", "")
+	require.ErrorIs(t, err, book.ErrHoldNotFound)
+}
+
+func TestHold_FailedPaymentReleasesTheSlot(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	startsAt := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+
+	hold, _, err := st.Service.HoldSlot(ctx, "broke@example.com", boxName, startsAt, time.Hour, 1)
+	require.NoError(t, err)
+
+	_, _, _, err = st.Service.BookHold(ctx, "broke@example.com", hold.UID, "", "")
+	require.ErrorIs(t, err, book.ErrNotEnoughFunds)
+
+	bookSlot(t, st, "other@example.com", startsAt)
+}
+
+func TestHold_ClosedSlotReleasesTheHold(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	const email = "checkout@example.com"
+
+	startsAt := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+
+	_, _, err := st.Payments.AddFunds(ctx, email, funds, "")
+	require.NoError(t, err)
+
+	hold, _, err := st.Service.HoldSlot(ctx, email, boxName, startsAt, time.Hour, 1)
+	require.NoError(t, err)
+
+	blackout, err := st.Service.AddBlackout(ctx, models.Blackout{
+		BoxName:  boxName,
+		StartsAt: startsAt,
+		EndsAt:   startsAt.Add(time.Hour),
+		Reason:   "floor repair",
+	})
+	require.NoError(t, err)
+
+	_, _, _, err = st.Service.BookHold(ctx, email, hold.UID, "", "")
+	require.ErrorIs(t, err, book.ErrMaintenance)
+
+	// Nothing was charged and the hold is gone.
+	balance, err := st.Payments.Balance(ctx, email)
+	require.NoError(t, err)
+	assert.EqualValues(t, funds, balance)
+
+	_, _, _, err = st.Service.BookHold(ctx, email, hold.UID, "", "")
+	require.ErrorIs(t, err, book.ErrHoldNotFound)
+
+	require.NoError(t, st.Service.RemoveBlackout(ctx, blackout.UID))
+
+	bookSlot(t, st, "other@example.com", startsAt)
+}
+
+func TestHold_SweeperReleasesExpiredHolds(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	const (
+		email  = "slow@example.com"
+		waiter = "waiter@example.com"
+	)
+
+	startsAt := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+
+	hold, _, err := st.Service.HoldSlot(ctx, email, boxName, startsAt, time.Hour, 1)
+	require.NoError(t, err)
+
+	entry, err := st.Service.JoinWaitlist(ctx, waiter, boxName, startsAt, time.Hour, 1, models.WaitlistModeOffer)
+	require.NoError(t, err)
+
+	// Holds that have not run out are kept.
+	require.NoError(t, st.Service.SweepHolds(ctx))
+	assert.Equal(t, models.WaitlistStatusWaiting, waitlistEntry(t, st, waiter, entry.UID).Status)
+
+	db, err := sql.Open("sqlite3", st.StoragePath)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec("UPDATE bookings SET heldUntil = ? WHERE uid = ?", time.Now().Add(-time.Second).Unix(), hold.UID)
+	require.NoError(t, err)
+
+	_, err = st.Storage.ExpireWaitlist(ctx, time.Now())
+	require.NoError(t, err)
+
+	_, _, err = st.Payments.AddFunds(ctx, email, funds, "")
+	require.NoError(t, err)
+
+	_, _, _, err = st.Service.BookHold(ctx, email, hold.UID, "", "")
+	require.ErrorIs(t, err, book.ErrHoldExpired)
+
+	require.NoError(t, st.Service.SweepHolds(ctx))
+
+	stored, err := st.Service.Booking(ctx, hold.UID)
+	require.NoError(t, err)
+	assert.Equal(t, models.BookingStatusExpired, stored.Status)
+
+	// The freed slot goes to the waitlist.
+	assert.Equal(t, models.WaitlistStatusOffered, waitlistEntry(t, st, waiter, entry.UID).Status)
+
+	_, _, _, err = st.Service.BookHold(ctx, email, hold.UID, "", "")
+	require.ErrorIs(t, err, book.ErrHoldNotFound)
+}
+
+func TestHold_LimitPerUser(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	const email = "greedy@example.com"
+
+	startsAt := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+
+	for i := range suite.MaxHolds {
+		_, _, err := st.Service.HoldSlot(ctx, email, boxName, startsAt.Add(time.Duration(i)*time.Hour), time.Hour, 1)
+		require.NoError(t, err)
+	}
+
+	_, _, err := st.Service.HoldSlot(ctx, email, boxName, startsAt.Add(suite.MaxHolds*time.Hour), time.Hour, 1)
+	require.ErrorIs(t, err, book.ErrTooManyHolds)
+
+	// The limit is per user.
+	_, _, err = st.Service.HoldSlot(ctx, "other@example.com", boxName, startsAt.Add(suite.MaxHolds*time.Hour), time.Hour, 1)
+	require.NoError(t, err)
+}
@@ -0,0 +1,330 @@
+package tests
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/services/book"
+	"booking/tests/suite"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotency_RepeatedBookChargesOnce(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	const (
+		email = "retry@example.com"
+		key   = "book-1"
+	)
+
+	_, _, err := st.Payments.AddFunds(ctx, email, funds, "")
+	require.NoError(t, err)
+
+	startsAt := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+
+	first, price, balance, err := st.Service.Book(ctx, email, boxName, startsAt, time.Hour, 1, "", key)
+	require.NoError(t, err)
+
+	again, againPrice, againBalance, err := st.Service.Book(ctx, email, boxName, startsAt, time.Hour, 1, "", key)
+	require.NoError(t, err)
+
+	assert.Equal(t, first.UID, again.UID)
+	assert.Equal(t, price.Total, againPrice.Total)
+	assert.Equal(t, balance, againBalance)
+
+	wallet, err := st.Payments.Balance(ctx, email)
+	require.NoError(t, err)
+	assert.EqualValues(t, funds-price.Total, wallet)
+
+	bookings, _, err := st.Service.Bookings(ctx, email, models.BookingFilter{}, "")
+	require.NoError(t, err)
+	assert.Len(t, bookings, 1)
+
+	// Keys belong to the user, another one books with the same key.
+	_, _, err = st.Payments.AddFunds(ctx, "other@example.com", funds, "")
+	require.NoError(t, err)
+
+	other, _, _, err := st.Service.Book(ctx, "other@example.com", boxName, startsAt.Add(2*time.Hour), time.Hour, 1, "", key)
+	require.NoError(t, err)
+	assert.NotEqual(t, first.UID, other.UID)
+}
+
+func TestIdempotency_KeyReusedForAnotherRequest(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	const (
+		email = "reuse@example.com"
+		key   = "book-2"
+	)
+
+	_, _, err := st.Payments.AddFunds(ctx, email, funds, "")
+	require.NoError(t, err)
+
+	startsAt := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+
+	booking, _, _, err := st.Service.Book(ctx, email, boxName, startsAt, time.Hour, 1, "", key)
+	require.NoError(t, err)
+
+	_, _, _, err = st.Service.Book(ctx, email, boxName, startsAt.Add(time.Hour), time.Hour, 1, "", key)
+	require.ErrorIs(t, err, book.ErrIdempotencyKeyReused)
+
+	_, _, err = st.Service.CancelBooking(ctx, email, booking.UID, models.CancelByUser, key)
+	require.ErrorIs(t, err, book.ErrIdempotencyKeyReused)
+}
+
+func TestIdempotency_FailedBookCanBeRetried(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	const (
+		email = "broke@example.com"
+		key   = "book-3"
+	)
+
+	startsAt := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+
+	_, _, _, err := st.Service.Book(ctx, email, boxName, startsAt, time.Hour, 1, "", key)
+	require.ErrorIs(t, err, book.ErrNotEnoughFunds)
+
+	_, _, err = st.Payments.AddFunds(ctx, email, funds, "")
+	require.NoError(t, err)
+
+	booking, _, _, err := st.Service.Book(ctx, email, boxName, startsAt, time.Hour, 1, "", key)
+	require.NoError(t, err)
+	assert.Equal(t, models.BookingStatusActive, booking.Status)
+}
+
+func TestIdempotency_RepeatedCancelRefundsOnce(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	const (
+		email = "undo@example.com"
+		key   = "cancel-1"
+	)
+
+	booking := bookSlot(t, st, email, time.Now().Add(48*time.Hour).Truncate(time.Hour))
+
+	refund, balance, err := st.Service.CancelBooking(ctx, email, booking.UID, models.CancelByUser, key)
+	require.NoError(t, err)
+	require.Equal(t, booking.PricePaid, refund.Amount)
+
+	again, againBalance, err := st.Service.CancelBooking(ctx, email, booking.UID, models.CancelByUser, key)
+	require.NoError(t, err)
+	assert.Equal(t, refund.Amount, again.Amount)
+	assert.Equal(t, refund.Policy, again.Policy)
+	assert.Equal(t, balance, againBalance)
+
+	wallet, err := st.Payments.Balance(ctx, email)
+	require.NoError(t, err)
+	assert.EqualValues(t, funds, wallet)
+
+	// Without the key the booking is simply not active any more.
+	_, _, err = st.Service.CancelBooking(ctx, email, booking.UID, models.CancelByUser, "")
+	require.ErrorIs(t, err, book.ErrBookingNotActive)
+}
+
+func TestIdempotency_ReleasedKey(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	const (
+		email = "twice@example.com"
+		key   = "book-4"
+	)
+
+	_, _, err := st.Payments.AddFunds(ctx, email, funds, "")
+	require.NoError(t, err)
+
+	startsAt := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+
+	// Another request has claimed the key and failed to release it.
+	_, _, err = st.Storage.ClaimIdempotencyKey(ctx, models.IdempotencyRecord{
+		Email:     email,
+		Key:       key,
+		Operation: models.IdempotentBook,
+		Request:   "running",
+	}, time.Now().Add(-suite.ClaimTimeout))
+	require.NoError(t, err)
+
+	_, _, _, err = st.Service.Book(ctx, email, boxName, startsAt, time.Hour, 1, "", key)
+	require.ErrorIs(t, err, book.ErrIdempotencyKeyReused)
+
+	require.NoError(t, st.Storage.ReleaseIdempotencyKey(ctx, email, key))
+
+	first, _, _, err := st.Service.Book(ctx, email, boxName, startsAt, time.Hour, 1, "", key)
+	require.NoError(t, err)
+
+	// A finished request is not released.
+	require.NoError(t, st.Storage.ReleaseIdempotencyKey(ctx, email, key))
+
+	again, _, _, err := st.Service.Book(ctx, email, boxName, startsAt, time.Hour, 1, "", key)
+	require.NoError(t, err)
+	assert.Equal(t, first.UID, again.UID)
+}
+
+func TestIdempotency_StaleClaimIsTakenOver(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	const (
+		email = "crash@example.com"
+		key   = "book-5"
+	)
+
+	_, _, err := st.Payments.AddFunds(ctx, email, funds, "")
+	require.NoError(t, err)
+
+	startsAt := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+
+	// The service died between claiming the key for this very Book call and
+	// storing its result.
+	_, claimed, err := st.Storage.ClaimIdempotencyKey(ctx, models.IdempotencyRecord{
+		Email:     email,
+		Key:       key,
+		Operation: models.IdempotentBook,
+		Request:   fmt.Sprintf("%s|%d|%d|%d", boxName, startsAt.Unix(), int64(time.Hour/time.Second), 1),
+	}, time.Now().Add(-suite.ClaimTimeout))
+	require.NoError(t, err)
+	require.True(t, claimed)
+
+	_, _, _, err = st.Service.Book(ctx, email, boxName, startsAt, time.Hour, 1, "", key)
+	require.ErrorIs(t, err, book.ErrRequestInProgress)
+
+	db, err := sql.Open("sqlite3", st.StoragePath)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec("UPDATE idempotency_keys SET createdAt = ? WHERE email = ? AND key = ?",
+		time.Now().Add(-suite.ClaimTimeout-time.Minute).Unix(), email, key)
+	require.NoError(t, err)
+
+	first, _, _, err := st.Service.Book(ctx, email, boxName, startsAt, time.Hour, 1, "", key)
+	require.NoError(t, err)
+	assert.Equal(t, models.BookingStatusActive, first.Status)
+
+	// The key is settled now and replays the booking.
+	again, _, _, err := st.Service.Book(ctx, email, boxName, startsAt, time.Hour, 1, "", key)
+	require.NoError(t, err)
+	assert.Equal(t, first.UID, again.UID)
+}
+
+func TestIdempotency_PendingPaymentKeepsTheKey(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	const (
+		email = "pending@example.com"
+		key   = "book-6"
+	)
+
+	_, _, err := st.Payments.AddFunds(ctx, email, funds, "")
+	require.NoError(t, err)
+
+	startsAt := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+
+	st.Payments.LoseReply(errLostReply)
+
+	_, _, _, err = st.Service.Book(ctx, email, boxName, startsAt, time.Hour, 1, "", key)
+	require.ErrorIs(t, err, book.ErrPaymentPending)
+
+	// The booking is left to the recovery, a retry doesn't start another one,
+	// not even once the claim would have timed out.
+	_, _, _, err = st.Service.Book(ctx, email, boxName, startsAt, time.Hour, 1, "", key)
+	require.ErrorIs(t, err, book.ErrRequestInProgress)
+
+	db, err := sql.Open("sqlite3", st.StoragePath)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec("UPDATE idempotency_keys SET createdAt = ? WHERE email = ? AND key = ?",
+		time.Now().Add(-suite.ClaimTimeout-time.Minute).Unix(), email, key)
+	require.NoError(t, err)
+
+	_, _, _, err = st.Service.Book(ctx, email, boxName, startsAt, time.Hour, 1, "", key)
+	require.ErrorIs(t, err, book.ErrRequestInProgress)
+
+	require.NoError(t, st.Service.RecoverSagas(ctx, -time.Minute))
+
+	// The recovery completed the booking, the retry gets it.
+	booking, price, _, err := st.Service.Book(ctx, email, boxName, startsAt, time.Hour, 1, "", key)
+	require.NoError(t, err)
+
+	bookings, _, err := st.Service.Bookings(ctx, email, models.BookingFilter{}, "")
+	require.NoError(t, err)
+	require.Len(t, bookings, 1)
+	assert.Equal(t, bookings[0].UID, booking.UID)
+	assert.Equal(t, models.BookingStatusActive, bookings[0].Status)
+
+	wallet, err := st.Payments.Balance(ctx, email)
+	require.NoError(t, err)
+	assert.EqualValues(t, funds-price.Total, wallet)
+}
+
+func TestIdempotency_RolledBackPaymentReleasesTheKey(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	const (
+		email = "rolled-back@example.com"
+		key   = "book-7"
+	)
+
+	_, _, err := st.Payments.AddFunds(ctx, email, funds, "")
+	require.NoError(t, err)
+
+	startsAt := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+
+	st.Payments.FailNext(errLostReply)
+
+	first, _, _, err := st.Service.Book(ctx, email, boxName, startsAt, time.Hour, 1, "", key)
+	require.ErrorIs(t, err, book.ErrPaymentPending)
+
+	// The wallet is emptied meanwhile, so the recovery releases the slot.
+	_, _, err = st.Payments.Pay(ctx, email, funds, "")
+	require.NoError(t, err)
+
+	require.NoError(t, st.Service.RecoverSagas(ctx, -time.Minute))
+
+	_, _, err = st.Payments.AddFunds(ctx, email, funds, "")
+	require.NoError(t, err)
+
+	// The first request failed after all, the retry books again.
+	again, _, _, err := st.Service.Book(ctx, email, boxName, startsAt, time.Hour, 1, "", key)
+	require.NoError(t, err)
+	assert.NotEqual(t, first.UID, again.UID)
+	assert.Equal(t, models.BookingStatusActive, again.Status)
+}
+
+func TestIdempotency_PendingRefundKeepsTheKey(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	const (
+		email = "pending-refund@example.com"
+		key   = "cancel-2"
+	)
+
+	_, _, err := st.Payments.AddFunds(ctx, email, funds, "")
+	require.NoError(t, err)
+
+	startsAt := time.Now().Add(96 * time.Hour).Truncate(time.Hour)
+
+	booking, _, _, err := st.Service.Book(ctx, email, boxName, startsAt, time.Hour, 1, "", "")
+	require.NoError(t, err)
+
+	st.Payments.LoseReply(errLostReply)
+
+	_, _, err = st.Service.CancelBooking(ctx, email, booking.UID, models.CancelByUser, key)
+	require.ErrorIs(t, err, book.ErrRefundPending)
+
+	_, _, err = st.Service.CancelBooking(ctx, email, booking.UID, models.CancelByUser, key)
+	require.ErrorIs(t, err, book.ErrRequestInProgress)
+
+	require.NoError(t, st.Service.RecoverSagas(ctx, -time.Minute))
+
+	refund, _, err := st.Service.CancelBooking(ctx, email, booking.UID, models.CancelByUser, key)
+	require.NoError(t, err)
+	assert.Equal(t, booking.PricePaid, refund.Amount)
+
+	wallet, err := st.Payments.Balance(ctx, email)
+	require.NoError(t, err)
+	assert.EqualValues(t, funds, wallet)
+}
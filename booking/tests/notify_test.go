@@ -0,0 +1,222 @@
+package tests
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/services/book"
+	"booking/tests/suite"
+	"database/sql"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotify_ConfirmationAndReminders(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	booking := bookSlot(t, st, "remind@example.com", time.Now().Add(48*time.Hour).Truncate(time.Hour))
+
+	require.NoError(t, st.Notifier.Dispatch(ctx))
+
+	messages := st.Channel.Messages()
+	require.Len(t, messages, 1)
+	assert.Equal(t, models.NotificationBooked, messages[0].Kind)
+	assert.Equal(t, "remind@example.com", messages[0].To)
+	assert.Equal(t, models.LocaleRU, messages[0].Locale)
+	assert.Contains(t, messages[0].Subject, "Бронирование подтверждено: "+boxName)
+	assert.Contains(t, messages[0].Body, "Оплачено: "+strconv.FormatInt(booking.PricePaid, 10)+" ₽")
+
+	// Dispatching again sends nothing, the reminders are not due yet.
+	require.NoError(t, st.Notifier.Dispatch(ctx))
+	require.Len(t, st.Channel.Messages(), 1)
+
+	makeDue(t, st, models.NotificationReminder)
+	require.NoError(t, st.Notifier.Dispatch(ctx))
+
+	messages = st.Channel.Messages()[1:]
+	require.Len(t, messages, 2)
+
+	var subjects []string
+	for _, msg := range messages {
+		assert.Equal(t, models.NotificationReminder, msg.Kind)
+		assert.Equal(t, booking.UID, msg.BookingUID)
+		subjects = append(subjects, msg.Subject)
+	}
+	assert.ElementsMatch(t, []string{
+		"Напоминание: " + boxName + " через 24 ч",
+		"Напоминание: " + boxName + " через 1 ч",
+	}, subjects)
+}
+
+func TestNotify_RescheduleReplacesReminders(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	const email = "moved@example.com"
+
+	startsAt := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+	booking := bookSlot(t, st, email, startsAt)
+
+	_, _, _, err := st.Service.RescheduleBooking(ctx, email, booking.UID, boxName, startsAt.Add(24*time.Hour), time.Hour)
+	require.NoError(t, err)
+
+	require.NoError(t, st.Notifier.Dispatch(ctx))
+
+	messages := st.Channel.Messages()
+	require.Len(t, messages, 2)
+	assert.Equal(t, models.NotificationBooked, messages[0].Kind)
+	assert.Equal(t, models.NotificationRescheduled, messages[1].Kind)
+
+	makeDue(t, st, models.NotificationReminder)
+	require.NoError(t, st.Notifier.Dispatch(ctx))
+
+	// Only the reminders of the new time go out.
+	assert.Len(t, st.Channel.Messages(), 4)
+	assert.Equal(t, map[models.NotificationStatus]int{
+		models.NotificationStatusSent:    2,
+		models.NotificationStatusSkipped: 2,
+	}, notificationStatuses(t, st, models.NotificationReminder))
+}
+
+func TestNotify_CancellationInEnglish(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	const email = "english@example.com"
+
+	err := st.Service.SetNotificationLocale(ctx, email, "de")
+	require.ErrorIs(t, err, book.ErrInvalidLocale)
+
+	require.NoError(t, st.Service.SetNotificationLocale(ctx, email, models.LocaleEN))
+
+	booking := bookSlot(t, st, email, time.Now().Add(48*time.Hour).Truncate(time.Hour))
+
+	refund, _, err := st.Service.CancelBooking(ctx, email, booking.UID, models.CancelByUser, "")
+	require.NoError(t, err)
+	require.Positive(t, refund.Amount)
+
+	require.NoError(t, st.Notifier.Dispatch(ctx))
+
+	messages := st.Channel.Messages()
+	require.Len(t, messages, 2)
+
+	assert.Equal(t, models.LocaleEN, messages[0].Locale)
+	assert.Contains(t, messages[0].Subject, "Booking confirmed: "+boxName)
+
+	assert.Equal(t, models.NotificationCancelled, messages[1].Kind)
+	assert.Contains(t, messages[1].Subject, "Booking cancelled: "+boxName)
+	assert.Contains(t, messages[1].Body, "Refund: "+strconv.FormatInt(refund.Amount, 10)+" ₽")
+
+	// The reminders of the cancelled booking are dropped.
+	makeDue(t, st, models.NotificationReminder)
+	require.NoError(t, st.Notifier.Dispatch(ctx))
+
+	assert.Len(t, st.Channel.Messages(), 2)
+}
+
+func TestNotify_RetriesFailedDeliveries(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	st.Channel.Fail(errors.New("mail server is down"))
+
+	bookSlot(t, st, "retry@example.com", time.Now().Add(48*time.Hour).Truncate(time.Hour))
+
+	require.NoError(t, st.Notifier.Dispatch(ctx))
+
+	state := notificationState(t, st, models.NotificationBooked)
+	assert.Equal(t, models.NotificationStatusPending, state.Status)
+	assert.Equal(t, 1, state.Attempts)
+	assert.Equal(t, "mail server is down", state.Error)
+	assert.WithinDuration(t, time.Now().Add(suite.NotifyBackoff), state.NextAttemptAt, 2*time.Second)
+
+	// The retry waits for its time.
+	require.NoError(t, st.Notifier.Dispatch(ctx))
+	assert.Equal(t, 1, notificationState(t, st, models.NotificationBooked).Attempts)
+
+	makeDue(t, st, models.NotificationBooked)
+	require.NoError(t, st.Notifier.Dispatch(ctx))
+
+	state = notificationState(t, st, models.NotificationBooked)
+	assert.Equal(t, 2, state.Attempts)
+	assert.WithinDuration(t, time.Now().Add(2*suite.NotifyBackoff), state.NextAttemptAt, 2*time.Second)
+
+	makeDue(t, st, models.NotificationBooked)
+	require.NoError(t, st.Notifier.Dispatch(ctx))
+
+	state = notificationState(t, st, models.NotificationBooked)
+	assert.Equal(t, models.NotificationStatusFailed, state.Status)
+	assert.Equal(t, suite.NotifyAttempts, state.Attempts)
+
+	// A working channel gets the next notifications through.
+	st.Channel.Fail(nil)
+
+	bookSlot(t, st, "retry@example.com", time.Now().Add(72*time.Hour).Truncate(time.Hour))
+
+	require.NoError(t, st.Notifier.Dispatch(ctx))
+	assert.Len(t, st.Channel.Messages(), 1)
+}
+
+// makeDue moves the next attempt of the pending notifications of the kind
+// to now.
+func makeDue(t *testing.T, st *suite.Suite, kind models.NotificationKind) {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", st.StoragePath)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec("UPDATE notifications SET nextAttemptAt = ? WHERE kind = ? AND status = ?",
+		time.Now().Add(-time.Second).Unix(), kind, models.NotificationStatusPending)
+	require.NoError(t, err)
+}
+
+func notificationStatuses(t *testing.T, st *suite.Suite, kind models.NotificationKind) map[models.NotificationStatus]int {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", st.StoragePath)
+	require.NoError(t, err)
+	defer db.Close()
+
+	rows, err := db.Query("SELECT status, COUNT(*) FROM notifications WHERE kind = ? GROUP BY status", kind)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	statuses := make(map[models.NotificationStatus]int)
+
+	for rows.Next() {
+		var (
+			status models.NotificationStatus
+			count  int
+		)
+
+		require.NoError(t, rows.Scan(&status, &count))
+		statuses[status] = count
+	}
+	require.NoError(t, rows.Err())
+
+	return statuses
+}
+
+// notificationState returns the delivery state of the only notification of
+// the kind.
+func notificationState(t *testing.T, st *suite.Suite, kind models.NotificationKind) models.Notification {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", st.StoragePath)
+	require.NoError(t, err)
+	defer db.Close()
+
+	var (
+		notification  models.Notification
+		nextAttemptAt int64
+	)
+
+	err = db.QueryRow("SELECT status, attempts, nextAttemptAt, error FROM notifications WHERE kind = ?", kind).
+		Scan(&notification.Status, &notification.Attempts, &nextAttemptAt, &notification.Error)
+	require.NoError(t, err)
+
+	notification.NextAttemptAt = time.Unix(nextAttemptAt, 0)
+
+	return notification
+}
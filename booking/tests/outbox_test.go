@@ -0,0 +1,202 @@
+package tests
+
+import (
+	"booking/internal/clients/publisher"
+	"booking/internal/domain/models"
+	"booking/internal/services/outbox"
+	"booking/tests/suite"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const relayBackoff = time.Minute
+
+func TestOutbox_LifecycleEventsInOrder(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	const email = "events@example.com"
+
+	relay, events := newRelay(st)
+
+	booking := bookSlot(t, st, email, time.Now().Add(48*time.Hour).Truncate(time.Hour))
+
+	refund, _, err := st.Service.CancelBooking(ctx, email, booking.UID, models.CancelByUser, "")
+	require.NoError(t, err)
+
+	require.NoError(t, relay.Relay(ctx))
+
+	received := events.received()
+	require.Len(t, received, 3)
+	assert.Equal(t, models.EventBookingCreated, received[0].Type)
+	assert.Equal(t, models.EventBookingRefunded, received[1].Type)
+	assert.Equal(t, models.EventBookingCancelled, received[2].Type)
+
+	for _, event := range received {
+		assert.Equal(t, booking.UID, event.BookingUID)
+		assert.NotEmpty(t, event.UID)
+	}
+
+	var created, cancelled struct {
+		BookingUID string `json:"bookingId"`
+		Email      string `json:"email"`
+		Status     string `json:"status"`
+		Amount     int64  `json:"amount"`
+	}
+	require.NoError(t, json.Unmarshal(received[0].Payload, &created))
+	require.NoError(t, json.Unmarshal(received[2].Payload, &cancelled))
+
+	assert.Equal(t, booking.UID, created.BookingUID)
+	assert.Equal(t, email, created.Email)
+	assert.Equal(t, string(models.BookingStatusActive), created.Status)
+	assert.Equal(t, booking.PricePaid, created.Amount)
+	assert.Equal(t, string(models.BookingStatusCancelled), cancelled.Status)
+	assert.Equal(t, refund.Amount, cancelled.Amount)
+
+	// Published events are not published again.
+	require.NoError(t, relay.Relay(ctx))
+	assert.Len(t, events.received(), 3)
+}
+
+func TestOutbox_CompletedBookings(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	relay, events := newRelay(st)
+
+	booking := bookSlot(t, st, "done@example.com", time.Now().Add(48*time.Hour).Truncate(time.Hour))
+
+	completed, err := st.Storage.CompleteExpired(ctx, booking.ExpiresAt.Add(time.Minute).Unix())
+	require.NoError(t, err)
+	require.EqualValues(t, 1, completed)
+
+	require.NoError(t, relay.Relay(ctx))
+
+	received := events.received()
+	require.Len(t, received, 2)
+	assert.Equal(t, models.EventBookingCompleted, received[1].Type)
+	assert.Equal(t, booking.UID, received[1].BookingUID)
+}
+
+func TestOutbox_FailedEventHoldsUpTheRest(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	relay, events := newRelay(st)
+	events.fail(errors.New("consumer is down"))
+
+	first := bookSlot(t, st, "first@example.com", time.Now().Add(48*time.Hour).Truncate(time.Hour))
+	second := bookSlot(t, st, "second@example.com", time.Now().Add(72*time.Hour).Truncate(time.Hour))
+
+	require.NoError(t, relay.Relay(ctx))
+	assert.Empty(t, events.received())
+
+	events.fail(nil)
+
+	// The retry waits for its time and keeps the second event behind.
+	require.NoError(t, relay.Relay(ctx))
+	assert.Empty(t, events.received())
+
+	db, err := sql.Open("sqlite3", st.StoragePath)
+	require.NoError(t, err)
+	defer db.Close()
+
+	var (
+		attempts int
+		reason   string
+	)
+	require.NoError(t, db.QueryRow("SELECT attempts, error FROM outbox WHERE bookingUid = ?", first.UID).Scan(&attempts, &reason))
+	assert.Equal(t, 1, attempts)
+	assert.Contains(t, reason, "consumer is down")
+
+	_, err = db.Exec("UPDATE outbox SET nextAttemptAt = ?", time.Now().Add(-time.Second).Unix())
+	require.NoError(t, err)
+
+	require.NoError(t, relay.Relay(ctx))
+
+	received := events.received()
+	require.Len(t, received, 2)
+	assert.Equal(t, first.UID, received[0].BookingUID)
+	assert.Equal(t, second.UID, received[1].BookingUID)
+}
+
+func TestOutbox_Replay(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	const email = "replay@example.com"
+
+	relay, events := newRelay(st)
+
+	booking := bookSlot(t, st, email, time.Now().Add(48*time.Hour).Truncate(time.Hour))
+	_, _, err := st.Service.CancelBooking(ctx, email, booking.UID, models.CancelByUser, "")
+	require.NoError(t, err)
+
+	require.NoError(t, relay.Relay(ctx))
+	require.Len(t, events.received(), 3)
+
+	replayed, err := st.Storage.ReplayEvents(ctx, models.EventFilter{Type: models.EventBookingCancelled})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, replayed)
+
+	require.NoError(t, relay.Relay(ctx))
+
+	received := events.received()
+	require.Len(t, received, 4)
+	assert.Equal(t, received[2].UID, received[3].UID)
+
+	replayed, err = st.Storage.ReplayEvents(ctx, models.EventFilter{BookingUID: "no-such-booking"})
+	require.NoError(t, err)
+	assert.Zero(t, replayed)
+}
+
+// newRelay returns a relay of the outbox of the suite publishing to an
+// in-process bus and the consumer of the bus.
+func newRelay(st *suite.Suite) (*outbox.Relay, *consumer) {
+	c := &consumer{}
+
+	bus := publisher.NewBus()
+	bus.Subscribe(c.handle)
+
+	return outbox.New(slog.New(slog.NewTextHandler(io.Discard, nil)), st.Storage, bus, relayBackoff), c
+}
+
+// consumer keeps the events it gets, it fails them while err is set.
+type consumer struct {
+	mu     sync.Mutex
+	err    error
+	events []models.Event
+}
+
+func (c *consumer) handle(_ context.Context, event models.Event) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.err != nil {
+		return c.err
+	}
+
+	c.events = append(c.events, event)
+
+	return nil
+}
+
+func (c *consumer) fail(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.err = err
+}
+
+func (c *consumer) received() []models.Event {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]models.Event(nil), c.events...)
+}
@@ -0,0 +1,218 @@
+package tests
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/services/book"
+	"booking/tests/suite"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParticipants_AcceptedSeeTheBooking(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	const (
+		owner  = "owner@example.com"
+		friend = "friend@example.com"
+	)
+
+	booking := groupBooking(t, st, owner, 3)
+
+	participant, err := st.Service.InviteParticipant(ctx, owner, booking.UID, " Friend@Example.com ")
+	require.NoError(t, err)
+	assert.Equal(t, friend, participant.Email)
+	assert.Equal(t, models.ParticipantStatusInvited, participant.Status)
+
+	// The invitee is notified.
+	require.NoError(t, st.Notifier.Dispatch(ctx))
+
+	messages := st.Channel.Messages()
+	require.Len(t, messages, 2)
+	assert.Equal(t, models.NotificationInvited, messages[1].Kind)
+	assert.Equal(t, friend, messages[1].To)
+	assert.Equal(t, booking.UID, messages[1].BookingUID)
+
+	invitations, err := st.Service.Invitations(ctx, friend)
+	require.NoError(t, err)
+	require.Len(t, invitations, 1)
+	assert.Equal(t, booking.UID, invitations[0].Booking.UID)
+
+	// Only accepted invitations show up in the bookings.
+	bookings, _, err := st.Service.Bookings(ctx, friend, models.BookingFilter{}, "")
+	require.NoError(t, err)
+	assert.Empty(t, bookings)
+
+	participant, err = st.Service.RespondToInvitation(ctx, friend, booking.UID, true)
+	require.NoError(t, err)
+	assert.Equal(t, models.ParticipantStatusAccepted, participant.Status)
+	assert.False(t, participant.RespondedAt.IsZero())
+
+	bookings, _, err = st.Service.Bookings(ctx, friend, models.BookingFilter{}, "")
+	require.NoError(t, err)
+	require.Len(t, bookings, 1)
+	assert.Equal(t, booking.UID, bookings[0].UID)
+	assert.Equal(t, owner, bookings[0].Email)
+
+	invitations, err = st.Service.Invitations(ctx, friend)
+	require.NoError(t, err)
+	assert.Empty(t, invitations)
+
+	participants, err := st.Service.Participants(ctx, friend, booking.UID)
+	require.NoError(t, err)
+	require.Len(t, participants, 1)
+
+	_, err = st.Service.Participants(ctx, "stranger@example.com", booking.UID)
+	require.ErrorIs(t, err, book.ErrNotYourBooking)
+
+	// A participant can't cancel the booking of the owner.
+	_, _, err = st.Service.CancelBooking(ctx, friend, booking.UID, models.CancelByUser, "")
+	require.ErrorIs(t, err, book.ErrNotYourBooking)
+
+	// Declining later takes the booking away again.
+	_, err = st.Service.RespondToInvitation(ctx, friend, booking.UID, false)
+	require.NoError(t, err)
+
+	bookings, _, err = st.Service.Bookings(ctx, friend, models.BookingFilter{}, "")
+	require.NoError(t, err)
+	assert.Empty(t, bookings)
+
+	_, err = st.Service.RespondToInvitation(ctx, friend, booking.UID, true)
+	require.ErrorIs(t, err, book.ErrInvitationDeclined)
+
+	// The owner can invite them again.
+	_, err = st.Service.InviteParticipant(ctx, owner, booking.UID, friend)
+	require.NoError(t, err)
+
+	_, err = st.Service.RespondToInvitation(ctx, friend, booking.UID, true)
+	require.NoError(t, err)
+}
+
+func TestParticipants_InvitationRules(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	const owner = "owner@example.com"
+
+	booking := groupBooking(t, st, owner, 3)
+
+	_, err := st.Service.InviteParticipant(ctx, "friend@example.com", booking.UID, "other@example.com")
+	require.ErrorIs(t, err, book.ErrNotYourBooking)
+
+	_, err = st.Service.InviteParticipant(ctx, owner, booking.UID, owner)
+	require.ErrorIs(t, err, book.ErrInviteOwner)
+
+	_, err = st.Service.InviteParticipant(ctx, owner, "missing", "friend@example.com")
+	require.ErrorIs(t, err, book.ErrBookingNotFound)
+
+	_, err = st.Service.InviteParticipant(ctx, owner, booking.UID, "first@example.com")
+	require.NoError(t, err)
+
+	_, err = st.Service.InviteParticipant(ctx, owner, booking.UID, "first@example.com")
+	require.ErrorIs(t, err, book.ErrAlreadyInvited)
+
+	_, err = st.Service.InviteParticipant(ctx, owner, booking.UID, "second@example.com")
+	require.NoError(t, err)
+
+	// The owner and two participants fill a booking for three.
+	_, err = st.Service.InviteParticipant(ctx, owner, booking.UID, "third@example.com")
+	require.ErrorIs(t, err, book.ErrTooManyParticipants)
+
+	// A declined invitation makes room.
+	_, err = st.Service.RespondToInvitation(ctx, "second@example.com", booking.UID, false)
+	require.NoError(t, err)
+
+	_, err = st.Service.InviteParticipant(ctx, owner, booking.UID, "third@example.com")
+	require.NoError(t, err)
+
+	_, err = st.Service.RespondToInvitation(ctx, "stranger@example.com", booking.UID, true)
+	require.ErrorIs(t, err, book.ErrInvitationNotFound)
+
+	participants, err := st.Service.Participants(ctx, owner, booking.UID)
+	require.NoError(t, err)
+	assert.Len(t, participants, 3)
+
+	// Nobody joins a cancelled booking.
+	_, _, err = st.Service.CancelBooking(ctx, owner, booking.UID, models.CancelByUser, "")
+	require.NoError(t, err)
+
+	_, err = st.Service.RespondToInvitation(ctx, "first@example.com", booking.UID, true)
+	require.ErrorIs(t, err, book.ErrBookingNotActive)
+
+	_, err = st.Service.InviteParticipant(ctx, owner, booking.UID, "fourth@example.com")
+	require.ErrorIs(t, err, book.ErrBookingNotActive)
+
+	invitations, err := st.Service.Invitations(ctx, "first@example.com")
+	require.NoError(t, err)
+	assert.Empty(t, invitations)
+}
+
+func TestParticipants_EmailCase(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	const (
+		owner = "owner@example.com"
+		// The account of the invitee is spelled with capitals.
+		account = "Friend@Example.com"
+	)
+
+	booking := groupBooking(t, st, owner, 2)
+
+	_, err := st.Service.InviteParticipant(ctx, owner, booking.UID, "friend@example.com")
+	require.NoError(t, err)
+
+	invitations, err := st.Service.Invitations(ctx, account)
+	require.NoError(t, err)
+	require.Len(t, invitations, 1)
+
+	_, err = st.Service.RespondToInvitation(ctx, account, booking.UID, true)
+	require.NoError(t, err)
+
+	bookings, _, err := st.Service.Bookings(ctx, account, models.BookingFilter{}, "")
+	require.NoError(t, err)
+	require.Len(t, bookings, 1)
+	assert.Equal(t, booking.UID, bookings[0].UID)
+
+	_, err = st.Service.Participants(ctx, account, booking.UID)
+	require.NoError(t, err)
+}
+
+func TestParticipants_OwnerEmailCase(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	booking := groupBooking(t, st, "Owner@Example.com", 2)
+
+	bookings, _, err := st.Service.Bookings(ctx, "owner@example.com", models.BookingFilter{}, "")
+	require.NoError(t, err)
+	require.Len(t, bookings, 1)
+	assert.Equal(t, booking.UID, bookings[0].UID)
+
+	// The owner manages the booking they see whatever the case of the email.
+	_, err = st.Service.InviteParticipant(ctx, "owner@example.com", booking.UID, "friend@example.com")
+	require.NoError(t, err)
+
+	participants, err := st.Service.Participants(ctx, "owner@example.com", booking.UID)
+	require.NoError(t, err)
+	assert.Len(t, participants, 1)
+
+	_, _, err = st.Service.CancelBooking(ctx, "owner@example.com", booking.UID, models.CancelByUser, "")
+	require.NoError(t, err)
+}
+
+// groupBooking books the box for peopleAmount people.
+func groupBooking(t *testing.T, st *suite.Suite, email string, peopleAmount int64) models.Booking {
+	t.Helper()
+
+	ctx := t.Context()
+
+	shareBox(t, st, boxName, peopleAmount)
+
+	_, _, err := st.Payments.AddFunds(ctx, email, funds, "")
+	require.NoError(t, err)
+
+	booking, _, _, err := st.Service.Book(ctx, email, boxName, time.Now().Add(48*time.Hour).Truncate(time.Hour), time.Hour, peopleAmount, "", "")
+	require.NoError(t, err)
+
+	return booking
+}
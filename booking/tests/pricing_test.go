@@ -0,0 +1,104 @@
+package tests
+
+import (
+	"booking/internal/services/pricing"
+	"booking/tests/suite"
+	"database/sql"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuote_PeakAndBaseHours(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	p, loc := newPricing(t, st)
+	box, err := st.Storage.Box(ctx, boxName)
+	require.NoError(t, err)
+
+	// Monday, the evening peak starts at 18:00.
+	startsAt := time.Date(2030, time.January, 7, 17, 0, 0, 0, loc)
+
+	price, err := p.Quote(ctx, box, startsAt, 2*time.Hour, 1)
+	require.NoError(t, err)
+
+	require.Len(t, price.Lines, 2)
+	assert.Equal(t, "base", price.Lines[0].Name)
+	assert.Equal(t, int64(780), price.Lines[0].Amount)
+	assert.Equal(t, "evening peak", price.Lines[1].Name)
+	assert.Equal(t, int64(125), price.Lines[1].Percent)
+	assert.Equal(t, int64(975), price.Lines[1].Amount)
+	assert.Equal(t, int64(1755), price.Total)
+}
+
+func TestQuote_WeekendHolidayAndMinCharge(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	p, loc := newPricing(t, st)
+	box, err := st.Storage.Box(ctx, boxName)
+	require.NoError(t, err)
+
+	saturday := time.Date(2030, time.January, 12, 10, 0, 0, 0, loc)
+
+	price, err := p.Quote(ctx, box, saturday, time.Hour, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(897), price.Total)
+
+	db, err := sql.Open("sqlite3", st.StoragePath)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec("INSERT INTO holiday_rates (name, date, percent) VALUES ('holiday', '2030-01-12', 200)")
+	require.NoError(t, err)
+
+	price, err = p.Quote(ctx, box, saturday, time.Hour, 1)
+	require.NoError(t, err)
+	require.Len(t, price.Lines, 1)
+	assert.Equal(t, "holiday", price.Lines[0].Name)
+	assert.Equal(t, int64(1560), price.Total)
+
+	monday := time.Date(2030, time.January, 7, 10, 0, 0, 0, loc)
+
+	price, err = p.Quote(ctx, box, monday, 15*time.Minute, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(195), price.Subtotal)
+	assert.Equal(t, box.MinCharge, price.Total)
+}
+
+func TestQuote_WholeDayAcrossMidnight(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	p, loc := newPricing(t, st)
+	box, err := st.Storage.Box(ctx, boxName)
+	require.NoError(t, err)
+
+	// Friday 17:00 to Saturday 17:00.
+	friday := time.Date(2030, time.January, 11, 17, 0, 0, 0, loc)
+
+	price, err := p.Quote(ctx, box, friday, 24*time.Hour, 1)
+	require.NoError(t, err)
+
+	require.Len(t, price.Lines, 4)
+	assert.Equal(t, []string{"base", "evening peak", "base", "weekend"}, []string{
+		price.Lines[0].Name, price.Lines[1].Name, price.Lines[2].Name, price.Lines[3].Name,
+	})
+	assert.Equal(t, friday.Add(5*time.Hour), price.Lines[2].StartsAt)
+	assert.Equal(t, friday.Add(7*time.Hour), price.Lines[3].StartsAt)
+	assert.Equal(t, friday.Add(24*time.Hour), price.Lines[3].ExpiresAt)
+	assert.Equal(t, int64(780+4*975+2*780+17*897), price.Total)
+}
+
+func newPricing(t *testing.T, st *suite.Suite) (*pricing.Pricing, *time.Location) {
+	t.Helper()
+
+	loc, err := time.LoadLocation("Asia/Novosibirsk")
+	require.NoError(t, err)
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	return pricing.New(log, st.Storage, st.Storage), loc
+}
@@ -0,0 +1,160 @@
+package tests
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/services/book"
+	"booking/tests/suite"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPromo_DiscountIsCharged(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	addPromoCode(t, st, "INSERT INTO promo_codes (code, kind, value) VALUES ('MINUS100', 'fixed', 100)")
+
+	const email = "promo@example.com"
+
+	_, _, err := st.Payments.AddFunds(ctx, email, funds, "")
+	require.NoError(t, err)
+
+	startsAt := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+
+	quote, err := st.Service.Quote(ctx, email, boxName, startsAt, time.Hour, 1, "first50")
+	require.NoError(t, err)
+	assert.Equal(t, "FIRST50", quote.Price.PromoCode)
+
+	full := quote.Price.Total + quote.Price.Discount
+	assert.EqualValues(t, full/2, quote.Price.Discount)
+
+	booking, bookPrice, balance, err := st.Service.Book(ctx, email, boxName, startsAt, time.Hour, 1, "first50", "")
+	require.NoError(t, err)
+	assert.Equal(t, quote.Price.Total, bookPrice.Total)
+	assert.Equal(t, quote.Price.Total, booking.PricePaid)
+	assert.EqualValues(t, funds-bookPrice.Total, balance)
+
+	// A rescheduled booking keeps its discount.
+	moved, movedPrice, _, err := st.Service.RescheduleBooking(ctx, email, booking.UID, boxName, startsAt.Add(time.Hour), time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, quote.Price.Discount, movedPrice.Discount)
+	assert.Equal(t, booking.PricePaid, moved.PricePaid)
+
+	_, bookPrice, _, err = st.Service.Book(ctx, email, boxName, startsAt.Add(2*time.Hour), time.Hour, 1, "MINUS100", "")
+	require.NoError(t, err)
+	assert.EqualValues(t, 100, bookPrice.Discount)
+	assert.Equal(t, full-100, bookPrice.Total)
+
+	_, _, _, err = st.Service.Book(ctx, email, boxName, startsAt.Add(4*time.Hour), time.Hour, 1, "NOPE", "")
+	require.ErrorIs(t, err, book.ErrPromoCodeNotFound)
+}
+
+func TestPromo_LimitsAndCancellation(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	addPromoCode(t, st, "INSERT INTO promo_codes (code, kind, value, maxRedemptions) VALUES ('TWICE', 'percent', 10, 2)")
+
+	const email = "first@example.com"
+
+	for _, user := range []string{email, "second@example.com", "third@example.com"} {
+		_, _, err := st.Payments.AddFunds(ctx, user, funds, "")
+		require.NoError(t, err)
+	}
+
+	startsAt := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+
+	booking, _, _, err := st.Service.Book(ctx, email, boxName, startsAt, time.Hour, 1, "FIRST50", "")
+	require.NoError(t, err)
+
+	_, _, _, err = st.Service.Book(ctx, email, boxName, startsAt.Add(2*time.Hour), time.Hour, 1, "FIRST50", "")
+	require.ErrorIs(t, err, book.ErrPromoCodeExhausted)
+
+	// A cancellation gives the use of the code back.
+	_, _, err = st.Service.CancelBooking(ctx, email, booking.UID, models.CancelByUser, "")
+	require.NoError(t, err)
+
+	_, _, _, err = st.Service.Book(ctx, email, boxName, startsAt.Add(2*time.Hour), time.Hour, 1, "FIRST50", "")
+	require.NoError(t, err)
+
+	// The global limit counts every user.
+	_, _, _, err = st.Service.Book(ctx, email, boxName, startsAt.Add(4*time.Hour), time.Hour, 1, "TWICE", "")
+	require.NoError(t, err)
+
+	_, _, _, err = st.Service.Book(ctx, "second@example.com", boxName, startsAt.Add(6*time.Hour), time.Hour, 1, "TWICE", "")
+	require.NoError(t, err)
+
+	_, _, _, err = st.Service.Book(ctx, "third@example.com", boxName, startsAt.Add(8*time.Hour), time.Hour, 1, "TWICE", "")
+	require.ErrorIs(t, err, book.ErrPromoCodeExhausted)
+
+	// The slot of a refused booking stays free.
+	bookSlot(t, st, "third@example.com", startsAt.Add(8*time.Hour))
+}
+
+func TestPromo_FailedPaymentReturnsTheCode(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	const email = "broke@example.com"
+
+	startsAt := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+
+	_, _, _, err := st.Service.Book(ctx, email, boxName, startsAt, time.Hour, 1, "FIRST50", "")
+	require.ErrorIs(t, err, book.ErrNotEnoughFunds)
+
+	_, _, err = st.Payments.AddFunds(ctx, email, funds, "")
+	require.NoError(t, err)
+
+	_, price, _, err := st.Service.Book(ctx, email, boxName, startsAt, time.Hour, 1, "FIRST50", "")
+	require.NoError(t, err)
+	assert.Positive(t, price.Discount)
+}
+
+func TestPromo_Restrictions(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	addPromoCode(t, st, "INSERT INTO promo_codes (code, kind, value, boxName) VALUES ('ELSEWHERE', 'percent', 10, 'OtherBox')")
+	addPromoCode(t, st, "INSERT INTO promo_codes (code, kind, value, validUntil) VALUES ('OLD', 'percent', 10, ?)", time.Now().Add(-time.Hour).Unix())
+	addPromoCode(t, st, "INSERT INTO promo_codes (code, kind, value, validFrom) VALUES ('SOON', 'percent', 10, ?)", time.Now().Add(time.Hour).Unix())
+
+	const email = "picky@example.com"
+
+	_, _, err := st.Payments.AddFunds(ctx, email, funds, "")
+	require.NoError(t, err)
+
+	loc := boxLocation(t, st)
+	day := time.Date(2030, time.January, 7, 0, 0, 0, 0, loc)
+
+	_, _, _, err = st.Service.Book(ctx, email, boxName, day.Add(9*time.Hour), time.Hour, 1, "ELSEWHERE", "")
+	assert.ErrorIs(t, err, book.ErrPromoCodeNotApplicable)
+
+	_, _, _, err = st.Service.Book(ctx, email, boxName, day.Add(9*time.Hour), time.Hour, 1, "OLD", "")
+	assert.ErrorIs(t, err, book.ErrPromoCodeExpired)
+
+	_, _, _, err = st.Service.Book(ctx, email, boxName, day.Add(9*time.Hour), time.Hour, 1, "SOON", "")
+	assert.ErrorIs(t, err, book.ErrPromoCodeExpired)
+
+	// MORNING20 covers 06:00 to 12:00 local to the box.
+	_, _, _, err = st.Service.Book(ctx, email, boxName, day.Add(11*time.Hour), 2*time.Hour, 1, "MORNING20", "")
+	assert.ErrorIs(t, err, book.ErrPromoCodeNotApplicable)
+
+	_, _, _, err = st.Service.Book(ctx, email, boxName, day.Add(5*time.Hour), time.Hour, 1, "MORNING20", "")
+	assert.ErrorIs(t, err, book.ErrPromoCodeNotApplicable)
+
+	_, price, _, err := st.Service.Book(ctx, email, boxName, day.Add(10*time.Hour), 2*time.Hour, 1, "MORNING20", "")
+	require.NoError(t, err)
+	assert.Equal(t, "MORNING20", price.PromoCode)
+	assert.Positive(t, price.Discount)
+}
+
+func addPromoCode(t *testing.T, st *suite.Suite, query string, args ...any) {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", st.StoragePath)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(query, args...)
+	require.NoError(t, err)
+}
@@ -0,0 +1,75 @@
+package tests
+
+import (
+	"booking/internal/lib/rrule"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRRule_Occurrences(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Novosibirsk")
+	require.NoError(t, err)
+
+	// A Tuesday.
+	start := time.Date(2026, time.March, 3, 19, 0, 0, 0, loc)
+
+	tests := []struct {
+		name string
+		rule string
+		want []string
+	}{
+		{
+			name: "weekly on the start day by count",
+			rule: "FREQ=WEEKLY;COUNT=3",
+			want: []string{"2026-03-03", "2026-03-10", "2026-03-17"},
+		},
+		{
+			name: "every other week on two days until a date",
+			rule: "RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH;UNTIL=20260320",
+			want: []string{"2026-03-03", "2026-03-05", "2026-03-17", "2026-03-19"},
+		},
+		{
+			name: "daily with an interval",
+			rule: "FREQ=DAILY;INTERVAL=3;UNTIL=2026-03-12",
+			want: []string{"2026-03-03", "2026-03-06", "2026-03-09", "2026-03-12"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := rrule.Parse(tt.rule, loc)
+			require.NoError(t, err)
+
+			starts, err := rule.Occurrences(start, 52)
+			require.NoError(t, err)
+
+			got := make([]string, 0, len(starts))
+			for _, s := range starts {
+				assert.Equal(t, 19, s.Hour())
+				got = append(got, s.Format("2006-01-02"))
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRRule_Invalid(t *testing.T) {
+	for rule, want := range map[string]error{
+		"FREQ=MONTHLY;COUNT=2":         rrule.ErrInvalidRule,
+		"FREQ=WEEKLY;BYDAY=XX;COUNT=2": rrule.ErrInvalidRule,
+		"FREQ=DAILY;BYDAY=MO;COUNT=2":  rrule.ErrInvalidRule,
+		"FREQ=WEEKLY":                  rrule.ErrNoEnd,
+	} {
+		_, err := rrule.Parse(rule, time.UTC)
+		assert.ErrorIs(t, err, want, rule)
+	}
+
+	rule, err := rrule.Parse("FREQ=DAILY;COUNT=100", time.UTC)
+	require.NoError(t, err)
+
+	_, err = rule.Occurrences(time.Now(), 52)
+	assert.ErrorIs(t, err, rrule.ErrTooManyEvents)
+}
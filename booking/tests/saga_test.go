@@ -0,0 +1,173 @@
+package tests
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/services/book"
+	"booking/tests/suite"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errLostReply = errors.New("connection reset by peer")
+
+func TestSaga_UnknownPaymentKeepsTheReservation(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	const email = "lost-pay@example.com"
+
+	_, _, err := st.Payments.AddFunds(ctx, email, funds, "")
+	require.NoError(t, err)
+
+	startsAt := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+
+	st.Payments.LoseReply(errLostReply)
+
+	_, _, _, err = st.Service.Book(ctx, email, boxName, startsAt, time.Hour, 1, "", "")
+	require.ErrorIs(t, err, book.ErrPaymentPending)
+
+	// The wallet may have been charged, so the slot is not given away.
+	assert.Equal(t, models.SagaStateReserved, bookingSaga(t, st, email))
+
+	_, _, _, err = st.Service.Book(ctx, "other@example.com", boxName, startsAt, time.Hour, 1, "", "")
+	require.ErrorIs(t, err, book.ErrAlreadyBooked)
+}
+
+func TestSaga_DeclinedPaymentReleasesTheSlot(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	startsAt := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+
+	_, _, _, err := st.Service.Book(ctx, "broke@example.com", boxName, startsAt, time.Hour, 1, "", "")
+	require.ErrorIs(t, err, book.ErrNotEnoughFunds)
+
+	assert.Equal(t, models.SagaStateCompensated, bookingSaga(t, st, "broke@example.com"))
+
+	bookSlot(t, st, "other@example.com", startsAt)
+}
+
+func TestSaga_UnknownRefundKeepsCancelling(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	const email = "lost-refund@example.com"
+
+	_, _, err := st.Payments.AddFunds(ctx, email, funds, "")
+	require.NoError(t, err)
+
+	startsAt := time.Now().Add(96 * time.Hour).Truncate(time.Hour)
+
+	booking, _, _, err := st.Service.Book(ctx, email, boxName, startsAt, time.Hour, 1, "", "")
+	require.NoError(t, err)
+
+	st.Payments.LoseReply(errLostReply)
+
+	_, _, err = st.Service.CancelBooking(ctx, email, booking.UID, models.CancelByUser, "")
+	require.ErrorIs(t, err, book.ErrRefundPending)
+
+	// The refund may have been paid, so the booking is not made active again.
+	stored, err := st.Service.Booking(ctx, booking.UID)
+	require.NoError(t, err)
+	assert.Equal(t, models.BookingStatusCancelling, stored.Status)
+}
+
+// bookingSaga returns the state of the latest saga of the user.
+func bookingSaga(t *testing.T, st *suite.Suite, email string) models.SagaState {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", st.StoragePath)
+	require.NoError(t, err)
+	defer db.Close()
+
+	var state models.SagaState
+	err = db.QueryRow("SELECT state FROM sagas WHERE email = ? ORDER BY id DESC LIMIT 1", email).Scan(&state)
+	require.NoError(t, err)
+
+	return state
+}
+
+func TestRecoverSagas_RepeatsTheCharge(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	const email = "recover-pay@example.com"
+
+	_, _, err := st.Payments.AddFunds(ctx, email, funds, "")
+	require.NoError(t, err)
+
+	startsAt := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+
+	st.Payments.LoseReply(errLostReply)
+
+	_, _, _, err = st.Service.Book(ctx, email, boxName, startsAt, time.Hour, 1, "", "")
+	require.ErrorIs(t, err, book.ErrPaymentPending)
+
+	require.NoError(t, st.Service.RecoverSagas(ctx, -time.Minute))
+
+	// The repeated charge is answered by the idempotency key, the booking is
+	// confirmed and the wallet charged once.
+	assert.Equal(t, models.SagaStateCompleted, bookingSaga(t, st, email))
+
+	bookings, _, err := st.Service.Bookings(ctx, email, models.BookingFilter{}, "")
+	require.NoError(t, err)
+	require.Len(t, bookings, 1)
+	assert.Equal(t, models.BookingStatusActive, bookings[0].Status)
+
+	wallet, err := st.Payments.Balance(ctx, email)
+	require.NoError(t, err)
+	assert.Equal(t, funds-bookings[0].PricePaid, wallet)
+}
+
+func TestRecoverSagas_DeclinedChargeReleasesTheSlot(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	startsAt := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+
+	// A reservation whose charge never reached payments.
+	_, err := st.Storage.BookABox(ctx, "broke@example.com", boxName, startsAt, startsAt.Add(time.Hour), 1, price)
+	require.NoError(t, err)
+
+	require.NoError(t, st.Service.RecoverSagas(ctx, -time.Minute))
+
+	assert.Equal(t, models.SagaStateCompensated, bookingSaga(t, st, "broke@example.com"))
+
+	bookSlot(t, st, "other@example.com", startsAt)
+}
+
+func TestRecoverSagas_RepeatsTheSharedChargeOfASeries(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	const email = "recover-series@example.com"
+
+	_, _, err := st.Payments.AddFunds(ctx, email, funds, "")
+	require.NoError(t, err)
+
+	startsAt := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+
+	st.Payments.LoseReply(errLostReply)
+
+	_, occurrences, _, err := st.Service.BookSeries(ctx, email, boxName, startsAt, time.Hour, 1, weeklyRule, models.SeriesPaymentUpfront)
+	require.NoError(t, err)
+
+	var total int64
+	for _, occurrence := range occurrences {
+		require.Equal(t, models.OccurrencePaymentPending, occurrence.Status)
+		require.NotEmpty(t, occurrence.BookingUID)
+		total += occurrence.Price.Total
+	}
+
+	require.NoError(t, st.Service.RecoverSagas(ctx, -time.Minute))
+
+	bookings, _, err := st.Service.Bookings(ctx, email, models.BookingFilter{}, "")
+	require.NoError(t, err)
+	require.Len(t, bookings, len(occurrences))
+	for _, booking := range bookings {
+		assert.Equal(t, models.BookingStatusActive, booking.Status)
+	}
+
+	wallet, err := st.Payments.Balance(ctx, email)
+	require.NoError(t, err)
+	assert.Equal(t, funds-total, wallet)
+}
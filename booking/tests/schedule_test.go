@@ -0,0 +1,165 @@
+package tests
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/services/book"
+	"booking/tests/suite"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedule_WeeklyHours(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	loc := boxLocation(t, st)
+
+	// Monday.
+	day := time.Date(2030, time.January, 7, 0, 0, 0, 0, loc)
+
+	err := st.Service.SetOpeningHours(ctx, boxName, []models.WeeklyHours{
+		{Weekday: time.Monday, OpensAt: "10:00", ClosesAt: "18:00"},
+		{Weekday: time.Tuesday, Closed: true},
+	})
+	require.NoError(t, err)
+
+	const email = "hours@example.com"
+
+	_, _, err = st.Payments.AddFunds(ctx, email, funds, "")
+	require.NoError(t, err)
+
+	_, _, _, err = st.Service.Book(ctx, email, boxName, day.Add(9*time.Hour), time.Hour, 1, "", "")
+	assert.ErrorIs(t, err, book.ErrOutsideOpeningHours)
+
+	_, _, _, err = st.Service.Book(ctx, email, boxName, day.Add(17*time.Hour), 2*time.Hour, 1, "", "")
+	assert.ErrorIs(t, err, book.ErrOutsideOpeningHours)
+
+	_, _, _, err = st.Service.Book(ctx, email, boxName, day.Add(34*time.Hour), time.Hour, 1, "", "")
+	assert.ErrorIs(t, err, book.ErrOutsideOpeningHours)
+
+	booking, _, _, err := st.Service.Book(ctx, email, boxName, day.Add(10*time.Hour), time.Hour, 1, "", "")
+	require.NoError(t, err)
+
+	_, _, _, err = st.Service.RescheduleBooking(ctx, email, booking.UID, boxName, day.Add(20*time.Hour), time.Hour)
+	assert.ErrorIs(t, err, book.ErrOutsideOpeningHours)
+
+	availability, err := st.Service.Availability(ctx, boxName, day, time.Hour)
+	require.NoError(t, err)
+	assert.True(t, day.Add(10*time.Hour).Equal(availability.OpensAt))
+	assert.True(t, day.Add(18*time.Hour).Equal(availability.ClosesAt))
+	assert.Len(t, availability.Slots, 7)
+
+	availability, err = st.Service.Availability(ctx, boxName, day.AddDate(0, 0, 1), time.Hour)
+	require.NoError(t, err)
+	assert.Empty(t, availability.Slots)
+}
+
+func TestSchedule_InvalidWeeklyHours(t *testing.T) {
+	tests := []struct {
+		name  string
+		hours []models.WeeklyHours
+	}{
+		{name: "unknown weekday", hours: []models.WeeklyHours{{Weekday: 7, OpensAt: "08:00", ClosesAt: "20:00"}}},
+		{name: "same weekday twice", hours: []models.WeeklyHours{
+			{Weekday: time.Monday, OpensAt: "08:00", ClosesAt: "12:00"},
+			{Weekday: time.Monday, OpensAt: "14:00", ClosesAt: "20:00"},
+		}},
+		{name: "closes before it opens", hours: []models.WeeklyHours{{Weekday: time.Monday, OpensAt: "20:00", ClosesAt: "08:00"}}},
+		{name: "invalid time", hours: []models.WeeklyHours{{Weekday: time.Monday, OpensAt: "8am", ClosesAt: "20:00"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, st := suite.New(t)
+
+			err := st.Service.SetOpeningHours(ctx, boxName, tt.hours)
+			assert.ErrorIs(t, err, book.ErrInvalidSchedule)
+		})
+	}
+}
+
+func TestSchedule_Closure(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	loc := boxLocation(t, st)
+	startsAt := time.Date(2030, time.January, 8, 12, 0, 0, 0, loc)
+
+	// A closure without a box closes every box.
+	closure, err := st.Service.AddClosure(ctx, models.Closure{Date: "2030-01-08", Kind: models.ClosureKindHoliday, Name: "Christmas"})
+	require.NoError(t, err)
+
+	const email = "closure@example.com"
+
+	_, _, err = st.Payments.AddFunds(ctx, email, funds, "")
+	require.NoError(t, err)
+
+	_, _, _, err = st.Service.Book(ctx, email, boxName, startsAt, time.Hour, 1, "", "")
+	assert.ErrorIs(t, err, book.ErrOutsideOpeningHours)
+
+	availability, err := st.Service.Availability(ctx, boxName, startsAt, time.Hour)
+	require.NoError(t, err)
+	assert.Empty(t, availability.Slots)
+
+	schedule, err := st.Service.Schedule(ctx, boxName, startsAt.AddDate(0, 0, -1), startsAt.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	require.Len(t, schedule.Closures, 1)
+	assert.Equal(t, closure.UID, schedule.Closures[0].UID)
+
+	require.NoError(t, st.Service.RemoveClosure(ctx, closure.UID))
+	assert.ErrorIs(t, st.Service.RemoveClosure(ctx, closure.UID), book.ErrClosureNotFound)
+
+	_, _, _, err = st.Service.Book(ctx, email, boxName, startsAt, time.Hour, 1, "", "")
+	require.NoError(t, err)
+}
+
+func TestSchedule_Blackout(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	loc := boxLocation(t, st)
+	noon := time.Date(2030, time.January, 9, 12, 0, 0, 0, loc)
+
+	blackout, err := st.Service.AddBlackout(ctx, models.Blackout{
+		BoxName:  boxName,
+		StartsAt: noon,
+		EndsAt:   noon.Add(2 * time.Hour),
+		Reason:   "floor repair",
+	})
+	require.NoError(t, err)
+
+	const email = "blackout@example.com"
+
+	_, _, err = st.Payments.AddFunds(ctx, email, funds, "")
+	require.NoError(t, err)
+
+	_, _, _, err = st.Service.Book(ctx, email, boxName, noon.Add(time.Hour), time.Hour, 1, "", "")
+	assert.ErrorIs(t, err, book.ErrMaintenance)
+
+	_, _, _, err = st.Service.Book(ctx, email, boxName, noon.Add(-30*time.Minute), time.Hour, 1, "", "")
+	assert.ErrorIs(t, err, book.ErrMaintenance)
+
+	availability, err := st.Service.Availability(ctx, boxName, noon, time.Hour)
+	require.NoError(t, err)
+	require.Len(t, availability.Busy, 1)
+	assert.True(t, noon.Equal(availability.Busy[0].StartsAt))
+	assert.True(t, noon.Add(2*time.Hour).Equal(availability.Busy[0].ExpiresAt))
+
+	require.NoError(t, st.Service.RemoveBlackout(ctx, blackout.UID))
+	assert.ErrorIs(t, st.Service.RemoveBlackout(ctx, blackout.UID), book.ErrBlackoutNotFound)
+
+	_, _, _, err = st.Service.Book(ctx, email, boxName, noon.Add(time.Hour), time.Hour, 1, "", "")
+	require.NoError(t, err)
+}
+
+func boxLocation(t *testing.T, st *suite.Suite) *time.Location {
+	t.Helper()
+
+	box, err := st.Storage.Box(t.Context(), boxName)
+	require.NoError(t, err)
+
+	loc, err := box.Location()
+	require.NoError(t, err)
+
+	return loc
+}
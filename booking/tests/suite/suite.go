@@ -0,0 +1,325 @@
+package suite
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/services/access"
+	"booking/internal/services/book"
+	"booking/internal/services/notify"
+	"booking/internal/services/pricing"
+	"booking/internal/services/refund"
+	"booking/internal/storage/sqlite"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/sqlite3"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+)
+
+const (
+	migrationsPath = "../migrations"
+	testTimeout    = 30 * time.Second
+
+	// FullRefundBefore and PartialPercent are the refund policy of the service.
+	FullRefundBefore = 24 * time.Hour
+	PartialPercent   = 50
+	// OfferTTL is how long a waitlist offer holds the slot.
+	OfferTTL = 15 * time.Minute
+	// HoldTTL is how long a checkout hold keeps the slot, a user has at most
+	// MaxHolds of them.
+	HoldTTL  = 10 * time.Minute
+	MaxHolds = 2
+	// AccessSecret signs door tokens, EarlyEntry opens the door before the start.
+	AccessSecret = "test-access-secret"
+	EarlyEntry   = 15 * time.Minute
+	// MaxPINAttempts wrong PINs lock PIN entry at a door for PINLockout.
+	MaxPINAttempts = 3
+	PINLockout     = 15 * time.Minute
+	// Reminders go out ReminderBefore and LastReminderBefore the start. A
+	// failed notification is retried after NotifyBackoff, doubled every
+	// time, NotifyAttempts times at most.
+	ReminderBefore     = 24 * time.Hour
+	LastReminderBefore = time.Hour
+	NotifyAttempts     = 3
+	NotifyBackoff      = time.Minute
+	// ClaimTimeout is how long an idempotency key claimed by a request that
+	// never finished stays taken.
+	ClaimTimeout = 2 * time.Minute
+)
+
+type Suite struct {
+	*testing.T
+	// StoragePath lets tests prepare data the service has no API for.
+	StoragePath string
+	Storage     *sqlite.Storage
+	Service     *book.Book
+	Payments    *Payments
+	Notifier    *notify.Notifier
+	Channel     *Channel
+}
+
+// New prepares a fresh migrated database and a booking service on top of it.
+// Payments are served by an in-memory wallet.
+func New(t *testing.T) (context.Context, *Suite) {
+	t.Helper()
+	t.Parallel()
+
+	storagePath := filepath.Join(t.TempDir(), "booking.db")
+
+	m, err := migrate.New("file://"+migrationsPath, fmt.Sprintf("sqlite3://%s", storagePath))
+	if err != nil {
+		t.Fatalf("failed to prepare migrations: %v", err)
+	}
+
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+	m.Close()
+
+	storage, err := sqlite.New(storagePath)
+	if err != nil {
+		t.Fatalf("failed to open storage: %v", err)
+	}
+
+	ctx, cancelCtx := context.WithTimeout(context.Background(), testTimeout)
+
+	t.Cleanup(func() {
+		t.Helper()
+		cancelCtx()
+	})
+
+	openAllDay(ctx, t, storage)
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	payments := &Payments{balances: make(map[string]int64)}
+	channel := &Channel{}
+	notifier := notify.New(log, storage, []notify.Channel{channel}, []time.Duration{ReminderBefore, LastReminderBefore}, models.LocaleRU, NotifyAttempts, NotifyBackoff)
+
+	service := book.NewBooker(log, book.Deps{
+		Booker:       storage,
+		Boxes:        storage,
+		Sagas:        storage,
+		Payments:     payments,
+		Pricer:       pricing.New(log, storage, storage),
+		Refunds:      refund.New(FullRefundBefore, PartialPercent),
+		Series:       storage,
+		Waitlist:     storage,
+		Rescheduler:  storage,
+		Schedule:     storage,
+		Access:       access.New(AccessSecret, EarlyEntry),
+		CheckIns:     storage,
+		Calendar:     storage,
+		Notifier:     notifier,
+		Idempotency:  storage,
+		Holds:        storage,
+		Promos:       storage,
+		Venues:       storage,
+		Participants: storage,
+	}, book.Config{
+		OfferTTL:                OfferTTL,
+		HoldTTL:                 HoldTTL,
+		MaxHolds:                MaxHolds,
+		IdempotencyClaimTimeout: ClaimTimeout,
+		MaxPINAttempts:          MaxPINAttempts,
+		PINLockout:              PINLockout,
+	})
+
+	return ctx, &Suite{
+		T:           t,
+		StoragePath: storagePath,
+		Storage:     storage,
+		Service:     service,
+		Payments:    payments,
+		Notifier:    notifier,
+		Channel:     channel,
+	}
+}
+
+// openAllDay opens every box around the clock, so tests booking relative to
+// now don't depend on the time they run at. Schedule tests set their own hours.
+func openAllDay(ctx context.Context, t *testing.T, storage *sqlite.Storage) {
+	t.Helper()
+
+	boxes, err := storage.Boxes(ctx, true)
+	if err != nil {
+		t.Fatalf("failed to get boxes: %v", err)
+	}
+
+	hours := make([]models.WeeklyHours, 0, 7)
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		hours = append(hours, models.WeeklyHours{Weekday: day, OpensAt: "00:00", ClosesAt: models.EndOfDay})
+	}
+
+	for _, box := range boxes {
+		if err := storage.SetWeeklyHours(ctx, box.Name, hours); err != nil {
+			t.Fatalf("failed to open the boxes: %v", err)
+		}
+	}
+}
+
+// Payments is an in-memory stand-in for the payments service. Like the
+// service it moves the money once per idempotency key.
+type Payments struct {
+	mu       sync.Mutex
+	balances map[string]int64
+	// keys are the calls made with an idempotency key.
+	keys map[string]keyedCall
+	// lostReply fails the next call after the money has moved.
+	lostReply error
+	// unreachable fails the next call before the money has moved.
+	unreachable error
+}
+
+func (p *Payments) Pay(_ context.Context, email string, amount int64, idempotencyKey string) (int64, bool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.unreachable; err != nil {
+		p.unreachable = nil
+		return -1, false, err
+	}
+
+	if call, ok := p.keys[email+"|"+idempotencyKey]; ok && idempotencyKey != "" {
+		if call.amount != amount {
+			return -1, false, errors.New("idempotency key was used for another request")
+		}
+		return call.balance, true, nil
+	}
+
+	if p.balances[email] < amount {
+		return -1, false, errors.New("not enough funds to pay")
+	}
+
+	p.balances[email] -= amount
+	p.remember(email, amount, idempotencyKey)
+
+	if err := p.takeLostReply(); err != nil {
+		return -1, false, err
+	}
+
+	return p.balances[email], true, nil
+}
+
+func (p *Payments) AddFunds(_ context.Context, email string, amount int64, idempotencyKey string) (int64, bool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.unreachable; err != nil {
+		p.unreachable = nil
+		return -1, false, err
+	}
+
+	if call, ok := p.keys[email+"|"+idempotencyKey]; ok && idempotencyKey != "" {
+		if call.amount != amount {
+			return -1, false, errors.New("idempotency key was used for another request")
+		}
+		return call.balance, true, nil
+	}
+
+	p.balances[email] += amount
+	p.remember(email, amount, idempotencyKey)
+
+	if err := p.takeLostReply(); err != nil {
+		return -1, false, err
+	}
+
+	return p.balances[email], true, nil
+}
+
+// keyedCall is the amount of a call with an idempotency key and the balance
+// it left.
+type keyedCall struct {
+	amount  int64
+	balance int64
+}
+
+func (p *Payments) remember(email string, amount int64, idempotencyKey string) {
+	if idempotencyKey == "" {
+		return
+	}
+
+	if p.keys == nil {
+		p.keys = make(map[string]keyedCall)
+	}
+
+	p.keys[email+"|"+idempotencyKey] = keyedCall{amount: amount, balance: p.balances[email]}
+}
+
+// LoseReply makes the next Pay or AddFunds move the money and still fail with
+// err, as if the reply of the payments service got lost.
+func (p *Payments) LoseReply(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.lostReply = err
+}
+
+// FailNext makes the next Pay or AddFunds fail with err without moving the
+// money, as if the payments service could not be reached.
+func (p *Payments) FailNext(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.unreachable = err
+}
+
+func (p *Payments) takeLostReply() error {
+	err := p.lostReply
+	p.lostReply = nil
+
+	return err
+}
+
+func (p *Payments) Balance(_ context.Context, email string) (int64, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.balances[email], nil
+}
+
+// Channel is a notification channel keeping the messages it is given.
+type Channel struct {
+	mu       sync.Mutex
+	fail     error
+	messages []models.NotificationMessage
+}
+
+func (c *Channel) Name() string {
+	return "test"
+}
+
+func (c *Channel) Send(_ context.Context, msg models.NotificationMessage) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.fail != nil {
+		return c.fail
+	}
+
+	c.messages = append(c.messages, msg)
+
+	return nil
+}
+
+// Fail makes the channel fail with err, nil makes it work again.
+func (c *Channel) Fail(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.fail = err
+}
+
+// Messages returns the messages sent so far.
+func (c *Channel) Messages() []models.NotificationMessage {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]models.NotificationMessage(nil), c.messages...)
+}
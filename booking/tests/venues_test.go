@@ -0,0 +1,106 @@
+package tests
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/lib/geo"
+	"booking/tests/suite"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lenina is where the venue of LeninaBox stands.
+var lenina = geo.Point{Latitude: 55.0302, Longitude: 82.9135}
+
+func TestVenues_NearestFirst(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	venues, err := st.Service.NearbyVenues(ctx, lenina, 5, 20, time.Hour)
+	require.NoError(t, err)
+	require.Len(t, venues, 3)
+
+	assert.Equal(t, "Lenina", venues[0].Venue.Name)
+	assert.InDelta(t, 0, venues[0].DistanceKm, 0.01)
+
+	for i := 1; i < len(venues); i++ {
+		assert.GreaterOrEqual(t, venues[i].DistanceKm, venues[i-1].DistanceKm)
+	}
+
+	require.Len(t, venues[0].Boxes, 1)
+	assert.Equal(t, boxName, venues[0].Boxes[0].Box.Name)
+	assert.Equal(t, venues[0].Venue.ID, venues[0].Boxes[0].Box.VenueID)
+
+	// The radius and the limit cut the list.
+	venues, err = st.Service.NearbyVenues(ctx, lenina, 1.5, 20, time.Hour)
+	require.NoError(t, err)
+	assert.Len(t, venues, 2)
+
+	venues, err = st.Service.NearbyVenues(ctx, lenina, 5, 1, time.Hour)
+	require.NoError(t, err)
+	require.Len(t, venues, 1)
+	assert.Equal(t, "Lenina", venues[0].Venue.Name)
+
+	venues, err = st.Service.NearbyVenues(ctx, geo.Point{Latitude: 55.7558, Longitude: 37.6173}, 10, 20, time.Hour)
+	require.NoError(t, err)
+	assert.Empty(t, venues)
+}
+
+func TestVenues_TodaysAvailability(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	loc := boxLocation(t, st)
+	today := time.Now().In(loc)
+
+	// The box is closed for the whole day.
+	err := st.Service.SetOpeningHours(ctx, boxName, []models.WeeklyHours{
+		{Weekday: today.Weekday(), Closed: true},
+	})
+	require.NoError(t, err)
+
+	venues, err := st.Service.NearbyVenues(ctx, lenina, 5, 20, time.Hour)
+	require.NoError(t, err)
+
+	for _, venue := range venues {
+		for _, summary := range venue.Boxes {
+			assert.Equal(t, today.Format("2006-01-02"), summary.Date.Format("2006-01-02"))
+
+			if summary.Box.Name == boxName {
+				assert.Zero(t, summary.FreeMinutes)
+				assert.Zero(t, summary.FreeSlots)
+				assert.True(t, summary.NextFree.IsZero())
+				continue
+			}
+
+			availability, err := st.Service.Availability(ctx, summary.Box.Name, today, time.Hour)
+			require.NoError(t, err)
+			assert.EqualValues(t, len(availability.Slots), summary.FreeSlots)
+		}
+	}
+}
+
+func TestVenues_BoxesTakeTheVenueTimeZone(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	db, err := sql.Open("sqlite3", st.StoragePath)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec("UPDATE venues SET timeZone = 'Europe/Moscow' WHERE name = 'Lenina'")
+	require.NoError(t, err)
+
+	box, err := st.Service.Box(ctx, boxName)
+	require.NoError(t, err)
+	assert.Equal(t, "Europe/Moscow", box.TimeZone)
+
+	// A box without a venue keeps its own.
+	_, err = db.Exec("UPDATE boxes SET venueId = 0 WHERE name = ?", boxName)
+	require.NoError(t, err)
+
+	box, err = st.Service.Box(ctx, boxName)
+	require.NoError(t, err)
+	assert.Equal(t, "Asia/Novosibirsk", box.TimeZone)
+	assert.Zero(t, box.VenueID)
+}
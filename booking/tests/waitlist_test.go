@@ -0,0 +1,278 @@
+package tests
+
+import (
+	"booking/internal/domain/models"
+	"booking/internal/services/book"
+	"booking/tests/suite"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJoinWaitlist_SlotFree(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	startsAt := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+
+	_, err := st.Service.JoinWaitlist(ctx, "waiter@example.com", boxName, startsAt, time.Hour, 1, models.WaitlistModeOffer)
+	assert.ErrorIs(t, err, book.ErrSlotFree)
+}
+
+func TestWaitlist_OfferAccepted(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	const (
+		owner  = "owner@example.com"
+		waiter = "waiter@example.com"
+	)
+
+	startsAt := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+	booking := bookSlot(t, st, owner, startsAt)
+
+	entry, err := st.Service.JoinWaitlist(ctx, waiter, boxName, startsAt, time.Hour, 1, models.WaitlistModeOffer)
+	require.NoError(t, err)
+	assert.Equal(t, models.WaitlistStatusWaiting, entry.Status)
+
+	_, _, _, err = st.Service.AcceptOffer(ctx, waiter, entry.UID)
+	assert.ErrorIs(t, err, book.ErrNoOffer)
+
+	_, _, err = st.Service.CancelBooking(ctx, owner, booking.UID, models.CancelByUser, "")
+	require.NoError(t, err)
+
+	entry = waitlistEntry(t, st, waiter, entry.UID)
+	require.Equal(t, models.WaitlistStatusOffered, entry.Status)
+	assert.NotEmpty(t, entry.BookingUID)
+	assert.WithinDuration(t, time.Now().Add(suite.OfferTTL), entry.OfferExpiresAt, time.Minute)
+
+	// The slot is held for the waiter.
+	_, _, err = st.Payments.AddFunds(ctx, "other@example.com", funds, "")
+	require.NoError(t, err)
+	_, _, _, err = st.Service.Book(ctx, "other@example.com", boxName, startsAt, time.Hour, 1, "", "")
+	assert.ErrorIs(t, err, book.ErrAlreadyBooked)
+
+	_, _, _, err = st.Service.AcceptOffer(ctx, "other@example.com", entry.UID)
+	assert.ErrorIs(t, err, book.ErrNotYourWaitlistEntry)
+
+	_, _, err = st.Payments.AddFunds(ctx, waiter, funds, "")
+	require.NoError(t, err)
+
+	accepted, price, balance, err := st.Service.AcceptOffer(ctx, waiter, entry.UID)
+	require.NoError(t, err)
+	assert.Equal(t, entry.BookingUID, accepted.UID)
+	assert.Equal(t, models.BookingStatusActive, accepted.Status)
+	assert.Equal(t, funds-price.Total, balance)
+
+	entry = waitlistEntry(t, st, waiter, entry.UID)
+	assert.Equal(t, models.WaitlistStatusBooked, entry.Status)
+}
+
+func TestWaitlist_AutoBook(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	const (
+		owner  = "owner@example.com"
+		waiter = "auto@example.com"
+	)
+
+	startsAt := time.Now().Add(72 * time.Hour).Truncate(time.Hour)
+	booking := bookSlot(t, st, owner, startsAt)
+
+	_, _, err := st.Payments.AddFunds(ctx, waiter, funds, "")
+	require.NoError(t, err)
+
+	entry, err := st.Service.JoinWaitlist(ctx, waiter, boxName, startsAt, time.Hour, 1, models.WaitlistModeAutoBook)
+	require.NoError(t, err)
+
+	_, _, err = st.Service.CancelBooking(ctx, owner, booking.UID, models.CancelByUser, "")
+	require.NoError(t, err)
+
+	entry = waitlistEntry(t, st, waiter, entry.UID)
+	require.Equal(t, models.WaitlistStatusBooked, entry.Status)
+	assert.NotEmpty(t, entry.BookingUID)
+
+	balance, err := st.Payments.Balance(ctx, waiter)
+	require.NoError(t, err)
+	assert.Less(t, balance, int64(funds))
+}
+
+func TestWaitlist_AutoBookFailsWhenTheBoxCloses(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	const (
+		owner  = "owner@example.com"
+		waiter = "closed@example.com"
+	)
+
+	startsAt := time.Now().Add(72 * time.Hour).Truncate(time.Hour)
+	booking := bookSlot(t, st, owner, startsAt)
+
+	_, _, err := st.Payments.AddFunds(ctx, waiter, funds, "")
+	require.NoError(t, err)
+
+	entry, err := st.Service.JoinWaitlist(ctx, waiter, boxName, startsAt, time.Hour, 1, models.WaitlistModeAutoBook)
+	require.NoError(t, err)
+
+	_, err = st.Service.AddBlackout(ctx, models.Blackout{
+		BoxName:  boxName,
+		StartsAt: startsAt,
+		EndsAt:   startsAt.Add(time.Hour),
+		Reason:   "floor repair",
+	})
+	require.NoError(t, err)
+
+	_, _, err = st.Service.CancelBooking(ctx, owner, booking.UID, models.CancelByUser, "")
+	require.NoError(t, err)
+
+	// The entry is not retried on every promotion.
+	entry = waitlistEntry(t, st, waiter, entry.UID)
+	assert.Equal(t, models.WaitlistStatusFailed, entry.Status)
+	assert.Equal(t, book.ErrMaintenance.Error(), entry.Error)
+
+	require.NoError(t, st.Service.PromoteWaitlist(ctx))
+
+	entries, err := st.Storage.WaitingEntries(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestWaitlist_AutoBookWithPendingPayment(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	const waiter = "pending-auto@example.com"
+
+	startsAt := time.Now().Add(72 * time.Hour).Truncate(time.Hour)
+
+	saga, err := st.Storage.BookABox(ctx, "owner@example.com", boxName, startsAt, startsAt.Add(time.Hour), 1, price)
+	require.NoError(t, err)
+
+	_, _, err = st.Payments.AddFunds(ctx, waiter, funds, "")
+	require.NoError(t, err)
+
+	entry, err := st.Service.JoinWaitlist(ctx, waiter, boxName, startsAt, time.Hour, 1, models.WaitlistModeAutoBook)
+	require.NoError(t, err)
+
+	require.NoError(t, st.Storage.ReleaseBooking(ctx, saga.ID, models.SagaStateCompensated, "payment declined"))
+
+	st.Payments.FailNext(errLostReply)
+
+	require.NoError(t, st.Service.PromoteWaitlist(ctx))
+
+	// The entry has its booking while the payment is settled, it isn't booked
+	// again by the next promotion.
+	entry = waitlistEntry(t, st, waiter, entry.UID)
+	require.Equal(t, models.WaitlistStatusBooked, entry.Status)
+	require.NotEmpty(t, entry.BookingUID)
+
+	require.NoError(t, st.Service.PromoteWaitlist(ctx))
+
+	bookings, _, err := st.Service.Bookings(ctx, waiter, models.BookingFilter{}, "")
+	require.NoError(t, err)
+	assert.Len(t, bookings, 1)
+
+	// The wallet is emptied meanwhile, so the recovery rolls the booking back
+	// and the entry fails with it.
+	_, _, err = st.Payments.Pay(ctx, waiter, funds, "")
+	require.NoError(t, err)
+
+	require.NoError(t, st.Service.RecoverSagas(ctx, -time.Minute))
+
+	entry = waitlistEntry(t, st, waiter, entry.UID)
+	assert.Equal(t, models.WaitlistStatusFailed, entry.Status)
+	assert.Equal(t, book.ErrNotEnoughFunds.Error(), entry.Error)
+}
+
+func TestWaitlist_OfferExpires(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	const (
+		owner  = "owner@example.com"
+		waiter = "late@example.com"
+	)
+
+	startsAt := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+	booking := bookSlot(t, st, owner, startsAt)
+
+	entry, err := st.Service.JoinWaitlist(ctx, waiter, boxName, startsAt, time.Hour, 1, models.WaitlistModeOffer)
+	require.NoError(t, err)
+
+	_, _, err = st.Service.CancelBooking(ctx, owner, booking.UID, models.CancelByUser, "")
+	require.NoError(t, err)
+
+	expired, err := st.Storage.ExpireWaitlist(ctx, time.Now().Add(2*suite.OfferTTL))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), expired)
+
+	entry = waitlistEntry(t, st, waiter, entry.UID)
+	assert.Equal(t, models.WaitlistStatusExpired, entry.Status)
+
+	_, _, _, err = st.Service.AcceptOffer(ctx, waiter, entry.UID)
+	assert.ErrorIs(t, err, book.ErrNoOffer)
+
+	bookSlot(t, st, "other@example.com", startsAt)
+}
+
+func TestWaitlist_LeavePassesOfferOn(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	const (
+		owner  = "owner@example.com"
+		first  = "first@example.com"
+		second = "second@example.com"
+	)
+
+	startsAt := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+	booking := bookSlot(t, st, owner, startsAt)
+
+	firstEntry, err := st.Service.JoinWaitlist(ctx, first, boxName, startsAt, time.Hour, 1, models.WaitlistModeOffer)
+	require.NoError(t, err)
+
+	secondEntry, err := st.Service.JoinWaitlist(ctx, second, boxName, startsAt, time.Hour, 1, models.WaitlistModeOffer)
+	require.NoError(t, err)
+
+	_, _, err = st.Service.CancelBooking(ctx, owner, booking.UID, models.CancelByUser, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, models.WaitlistStatusOffered, waitlistEntry(t, st, first, firstEntry.UID).Status)
+	assert.Equal(t, models.WaitlistStatusWaiting, waitlistEntry(t, st, second, secondEntry.UID).Status)
+
+	require.NoError(t, st.Service.LeaveWaitlist(ctx, first, firstEntry.UID))
+
+	assert.Equal(t, models.WaitlistStatusCancelled, waitlistEntry(t, st, first, firstEntry.UID).Status)
+	assert.Equal(t, models.WaitlistStatusOffered, waitlistEntry(t, st, second, secondEntry.UID).Status)
+
+	err = st.Service.LeaveWaitlist(ctx, first, firstEntry.UID)
+	assert.ErrorIs(t, err, book.ErrWaitlistEntryNotFound)
+}
+
+func bookSlot(t *testing.T, st *suite.Suite, email string, startsAt time.Time) models.Booking {
+	t.Helper()
+
+	ctx := t.Context()
+
+	_, _, err := st.Payments.AddFunds(ctx, email, funds, "")
+	require.NoError(t, err)
+
+	booking, _, _, err := st.Service.Book(ctx, email, boxName, startsAt, time.Hour, 1, "", "")
+	require.NoError(t, err)
+
+	return booking
+}
+
+func waitlistEntry(t *testing.T, st *suite.Suite, email string, entryUID string) models.WaitlistEntry {
+	t.Helper()
+
+	entries, err := st.Service.Waitlist(t.Context(), email)
+	require.NoError(t, err)
+
+	for _, entry := range entries {
+		if entry.UID == entryUID {
+			return entry
+		}
+	}
+
+	t.Fatalf("waitlist entry %s not found", entryUID)
+
+	return models.WaitlistEntry{}
+}
@@ -10,6 +10,12 @@ require (
 	google.golang.org/grpc v1.76.0
 )
 
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/stretchr/testify v1.11.1
+)
+
 require (
 	github.com/BurntSushi/toml v1.2.1 // indirect
 	github.com/MKode312/protos v0.0.12
@@ -30,3 +36,5 @@ require (
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20250804133106-a7a43d27e69b // indirect
 	google.golang.org/protobuf v1.36.10 // indirect
 )
+
+replace github.com/MKode312/protos => ../protos
@@ -14,9 +14,10 @@ import (
 
 type Payment interface {
 	AddCard(ctx context.Context, email string, cardNumber string, cvc string, phoneNumber string) (success bool, err error)
-	AddFunds(ctx context.Context, email string, amount int64) (balance int64, success bool, err error)
-	Pay(ctx context.Context, email string, amount int64) (balance int64, success bool, err error)
+	AddFunds(ctx context.Context, email string, amount int64, idempotencyKey string) (balance int64, success bool, err error)
+	Pay(ctx context.Context, email string, amount int64, idempotencyKey string) (balance int64, success bool, err error)
 	GetCard(ctx context.Context, email string) (cardNumber string, phoneNumber string, err error)
+	GetBalance(ctx context.Context, email string) (balance int64, err error)
 }
 
 type serverAPI struct {
@@ -53,8 +54,11 @@ func (s *serverAPI) Pay(ctx context.Context, req *paymentsv1.PayRequest) (*payme
 		return nil, status.Error(codes.InvalidArgument, "invalid amount")
 	}
 
-	balance, success, err := s.payment.Pay(ctx, req.GetEmail(), req.GetAmount())
+	balance, success, err := s.payment.Pay(ctx, req.GetEmail(), req.GetAmount(), req.GetIdempotencyKey())
 	if err != nil {
+		if errors.Is(err, payment.ErrIdempotencyKeyReused) {
+			return nil, status.Error(codes.FailedPrecondition, "idempotency key was used for another request")
+		}
 		if errors.Is(err, payment.ErrNotEnoughFundsToPay) {
 			return nil, status.Error(codes.OutOfRange, "not enough funds to pay")
 		}
@@ -77,8 +81,11 @@ func (s *serverAPI) AddFunds(ctx context.Context, req *paymentsv1.AddFundsReques
 		return nil, status.Error(codes.InvalidArgument, "invalid amount")
 	}
 
-	balance, success, err := s.payment.AddFunds(ctx, req.GetEmail(), req.GetAmount())
+	balance, success, err := s.payment.AddFunds(ctx, req.GetEmail(), req.GetAmount(), req.GetIdempotencyKey())
 	if err != nil {
+		if errors.Is(err, payment.ErrIdempotencyKeyReused) {
+			return nil, status.Error(codes.FailedPrecondition, "idempotency key was used for another request")
+		}
 		if errors.Is(err, payment.ErrNotFound) {
 			return nil, status.Error(codes.NotFound, "card not found")
 		}
@@ -112,6 +119,24 @@ func (s *serverAPI) GetCard(ctx context.Context, req *paymentsv1.GetCardRequest)
 	}, nil
 }
 
+func (s *serverAPI) GetBalance(ctx context.Context, req *paymentsv1.GetBalanceRequest) (*paymentsv1.GetBalanceResponse, error) {
+	if err := validateEmail(req.GetEmail()); err != nil {
+		return nil, err
+	}
+
+	balance, err := s.payment.GetBalance(ctx, req.GetEmail())
+	if err != nil {
+		if errors.Is(err, payment.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "card not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to get balance")
+	}
+
+	return &paymentsv1.GetBalanceResponse{
+		Balance: balance,
+	}, nil
+}
+
 func validateEmail(email string) error {
 	if email == "" {
 		return status.Error(codes.InvalidArgument, "email is required")
@@ -15,24 +15,31 @@ type CardGetter interface {
 	GetCard(ctx context.Context, email string) (cardNumber string, phoneNumber string, err error)
 }
 
+type BalanceProvider interface {
+	Balance(ctx context.Context, email string) (balance int64, err error)
+}
+
 type Payment struct {
 	log             *slog.Logger
 	cardAdder       CardAdder
 	fundsAdder      FundsAdder
 	paymentProvider PaymentProvider
 	cardGetter      CardGetter
+	balanceProvider BalanceProvider
 }
 
 type CardAdder interface {
 	AddCard(ctx context.Context, email string, cardNumber []byte, cvc []byte, phoneNumber []byte) (success bool, err error)
 }
 
+// FundsAdder and PaymentProvider remember the result under a non-empty
+// idempotency key and return it again for a repeated key.
 type FundsAdder interface {
-	AddFunds(ctx context.Context, email string, amount int64) (balance int64, success bool, err error)
+	AddFunds(ctx context.Context, email string, amount int64, idempotencyKey string) (balance int64, success bool, err error)
 }
 
 type PaymentProvider interface {
-	Pay(ctx context.Context, email string, amount int64) (balance int64, success bool, err error)
+	Pay(ctx context.Context, email string, amount int64, idempotencyKey string) (balance int64, success bool, err error)
 }
 
 const (
@@ -43,15 +50,18 @@ var (
 	ErrInvalidCredentials  = errors.New("invalid credentials")
 	ErrNotEnoughFundsToPay = errors.New("not enough funds to pay")
 	ErrNotFound            = errors.New("card not found")
+	// ErrIdempotencyKeyReused: the key was used for another operation or amount.
+	ErrIdempotencyKeyReused = errors.New("idempotency key was used for another request")
 )
 
-func New(log *slog.Logger, cardAdder CardAdder, fundsAdder FundsAdder, paymentProvider PaymentProvider, cardGetter CardGetter) *Payment {
+func New(log *slog.Logger, cardAdder CardAdder, fundsAdder FundsAdder, paymentProvider PaymentProvider, cardGetter CardGetter, balanceProvider BalanceProvider) *Payment {
 	return &Payment{
 		log:             log,
 		cardAdder:       cardAdder,
 		fundsAdder:      fundsAdder,
 		paymentProvider: paymentProvider,
 		cardGetter:      cardGetter,
+		balanceProvider: balanceProvider,
 	}
 }
 
@@ -80,6 +90,27 @@ func (p *Payment) GetCard(ctx context.Context, email string) (string, string, er
 	return cardNumber, phoneNumber, nil
 }
 
+func (p *Payment) GetBalance(ctx context.Context, email string) (int64, error) {
+	const op = "payment.GetBalance"
+
+	log := p.log.With(
+		slog.String("op", op),
+	)
+
+	balance, err := p.balanceProvider.Balance(ctx, email)
+	if err != nil {
+		if errors.Is(err, storage.ErrCardNotFound) {
+			log.Error("card not found", sl.Err(err))
+			return emptyBalanceValue, fmt.Errorf("%s: %w", op, ErrNotFound)
+		}
+
+		log.Error("failed to get balance", sl.Err(err))
+		return emptyBalanceValue, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return balance, nil
+}
+
 func (p *Payment) AddCard(ctx context.Context, email string, cardNumber string, cvc string, phoneNumber string) (bool, error) {
 	const op = "payment.AddCard"
 
@@ -127,7 +158,7 @@ func (p *Payment) AddCard(ctx context.Context, email string, cardNumber string,
 	return success, nil
 }
 
-func (p *Payment) AddFunds(ctx context.Context, email string, amount int64) (int64, bool, error) {
+func (p *Payment) AddFunds(ctx context.Context, email string, amount int64, idempotencyKey string) (int64, bool, error) {
 	const op = "payment.AddFunds"
 
 	log := p.log.With(
@@ -136,7 +167,7 @@ func (p *Payment) AddFunds(ctx context.Context, email string, amount int64) (int
 
 	log.Info("attempting to add some funds")
 
-	balance, success, err := p.fundsAdder.AddFunds(ctx, email, amount)
+	balance, success, err := p.fundsAdder.AddFunds(ctx, email, amount, idempotencyKey)
 	if err != nil {
 		if errors.Is(err, storage.ErrCardNotFound) {
 			log.Error("card not found", sl.Err(err))
@@ -144,6 +175,12 @@ func (p *Payment) AddFunds(ctx context.Context, email string, amount int64) (int
 			return emptyBalanceValue, false, fmt.Errorf("%s: %w", op, ErrNotFound)
 		}
 
+		if errors.Is(err, storage.ErrIdempotencyKeyReused) {
+			log.Error("idempotency key reused", sl.Err(err))
+
+			return emptyBalanceValue, false, fmt.Errorf("%s: %w", op, ErrIdempotencyKeyReused)
+		}
+
 		log.Error("failed to add some funds", sl.Err(err))
 
 		return emptyBalanceValue, false, fmt.Errorf("%s: %w", op, err)
@@ -154,7 +191,7 @@ func (p *Payment) AddFunds(ctx context.Context, email string, amount int64) (int
 	return balance, success, nil
 }
 
-func (p *Payment) Pay(ctx context.Context, email string, amount int64) (int64, bool, error) {
+func (p *Payment) Pay(ctx context.Context, email string, amount int64, idempotencyKey string) (int64, bool, error) {
 	const op = "payment.Pay"
 
 	log := p.log.With(
@@ -163,7 +200,7 @@ func (p *Payment) Pay(ctx context.Context, email string, amount int64) (int64, b
 
 	log.Info("attempting to provide a payment")
 
-	balance, success, err := p.paymentProvider.Pay(ctx, email, amount)
+	balance, success, err := p.paymentProvider.Pay(ctx, email, amount, idempotencyKey)
 	if err != nil {
 		if errors.Is(err, storage.ErrCardNotFound) {
 			log.Error("card not found", sl.Err(err))
@@ -177,6 +214,12 @@ func (p *Payment) Pay(ctx context.Context, email string, amount int64) (int64, b
 			return emptyBalanceValue, false, fmt.Errorf("%s: %w", op, ErrNotEnoughFundsToPay)
 		}
 
+		if errors.Is(err, storage.ErrIdempotencyKeyReused) {
+			log.Error("idempotency key reused", sl.Err(err))
+
+			return emptyBalanceValue, false, fmt.Errorf("%s: %w", op, ErrIdempotencyKeyReused)
+		}
+
 		log.Error("failed to provide a payment", sl.Err(err))
 
 		return emptyBalanceValue, false, fmt.Errorf("%s: %w", op, err)
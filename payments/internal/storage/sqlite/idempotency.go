@@ -0,0 +1,70 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"payments/internal/storage"
+	"time"
+)
+
+// Operations remembered under idempotency keys.
+const (
+	operationAddFunds = "add_funds"
+	operationPay      = "pay"
+)
+
+// claimKey takes the idempotency key of the user for an operation. When the
+// key was taken before, claimed is false and balance is the result of that
+// operation. An empty key is always claimed and nothing is remembered.
+//
+// The key is written before the balance changes, so a concurrent request with
+// the same key waits for the transaction and finds the result.
+func claimKey(ctx context.Context, tx *sql.Tx, email string, key string, operation string, amount int64) (balance int64, claimed bool, err error) {
+	if key == "" {
+		return emptyBalanceValue, true, nil
+	}
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO idempotency_keys(email, key, operation, amount, balance, createdAt) VALUES(?, ?, ?, ?, ?, ?)
+		ON CONFLICT(email, key) DO NOTHING
+	`, email, key, operation, amount, emptyBalanceValue, time.Now().Unix())
+	if err != nil {
+		return emptyBalanceValue, false, err
+	}
+
+	inserted, err := res.RowsAffected()
+	if err != nil {
+		return emptyBalanceValue, false, err
+	}
+	if inserted == 1 {
+		return emptyBalanceValue, true, nil
+	}
+
+	var (
+		storedOperation string
+		storedAmount    int64
+	)
+
+	err = tx.QueryRowContext(ctx, "SELECT operation, amount, balance FROM idempotency_keys WHERE email = ? AND key = ?", email, key).
+		Scan(&storedOperation, &storedAmount, &balance)
+	if err != nil {
+		return emptyBalanceValue, false, err
+	}
+
+	if storedOperation != operation || storedAmount != amount {
+		return emptyBalanceValue, false, storage.ErrIdempotencyKeyReused
+	}
+
+	return balance, false, nil
+}
+
+// saveKey remembers the balance left by the operation of a claimed key.
+func saveKey(ctx context.Context, tx *sql.Tx, email string, key string, balance int64) error {
+	if key == "" {
+		return nil
+	}
+
+	_, err := tx.ExecContext(ctx, "UPDATE idempotency_keys SET balance = ? WHERE email = ? AND key = ?", balance, email, key)
+
+	return err
+}
@@ -51,18 +51,24 @@ func (s *Storage) AddCard(ctx context.Context, email string, phoneNumberHash []b
 	return true, nil
 }
 
-func (s *Storage) AddFunds(ctx context.Context, email string, amount int64) (int64, bool, error) {
+func (s *Storage) AddFunds(ctx context.Context, email string, amount int64, idempotencyKey string) (int64, bool, error) {
 	const op = "storage,sqlite.AddFunds"
 
-	stmt, err := s.db.Prepare("SELECT balance FROM cards WHERE email = ?")
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return emptyBalanceValue, false, fmt.Errorf("%s: %w", op, err)
 	}
+	defer tx.Rollback()
 
-	row := stmt.QueryRowContext(ctx, email)
+	balance, claimed, err := claimKey(ctx, tx, email, idempotencyKey, operationAddFunds, amount)
+	if err != nil {
+		return emptyBalanceValue, false, fmt.Errorf("%s: %w", op, err)
+	}
+	if !claimed {
+		return balance, true, nil
+	}
 
-	var balance int64
-	err = row.Scan(&balance)
+	err = tx.QueryRowContext(ctx, "UPDATE cards SET balance = balance + ? WHERE email = ? RETURNING balance", amount, email).Scan(&balance)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return emptyBalanceValue, false, fmt.Errorf("%s: %w", op, storage.ErrCardNotFound)
@@ -71,17 +77,12 @@ func (s *Storage) AddFunds(ctx context.Context, email string, amount int64) (int
 		return emptyBalanceValue, false, fmt.Errorf("%s: %w", op, err)
 	}
 
-	balance += amount
-
-	stmt2, err := s.db.Prepare("UPDATE cards SET balance = ? WHERE email = ?")
-	if err != nil {
-		return 0, false, fmt.Errorf("%s: %w", op, err)
+	if err := saveKey(ctx, tx, email, idempotencyKey, balance); err != nil {
+		return emptyBalanceValue, false, fmt.Errorf("%s: %w", op, err)
 	}
-	defer stmt2.Close()
 
-	_, err = stmt2.ExecContext(ctx, balance, email)
-	if err != nil {
-		return 0, false, fmt.Errorf("%s: %w", op, err)
+	if err := tx.Commit(); err != nil {
+		return emptyBalanceValue, false, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return balance, true, nil
@@ -109,40 +110,65 @@ func (s *Storage) GetCard(ctx context.Context, email string) (string, string, er
 	return string(cardNumberHash), string(phoneNumberHash), nil
 }
 
-func (s *Storage) Pay(ctx context.Context, email string, amount int64) (int64, bool, error) {
+func (s *Storage) Balance(ctx context.Context, email string) (int64, error) {
+	const op = "storage.sqlite.Balance"
+
+	var balance int64
+
+	err := s.db.QueryRowContext(ctx, "SELECT balance FROM cards WHERE email = ?", email).Scan(&balance)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return emptyBalanceValue, fmt.Errorf("%s: %w", op, storage.ErrCardNotFound)
+		}
+		return emptyBalanceValue, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return balance, nil
+}
+
+func (s *Storage) Pay(ctx context.Context, email string, amount int64, idempotencyKey string) (int64, bool, error) {
 	const op = "storage.sqlite.Pay"
 
-	stmt, err := s.db.Prepare("SELECT balance FROM cards WHERE email = ?")
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return emptyBalanceValue, false, fmt.Errorf("%s: %w", op, err)
 	}
-	defer stmt.Close()
+	defer tx.Rollback()
 
-	var balance int64
-	err = stmt.QueryRowContext(ctx, email).Scan(&balance)
+	balance, claimed, err := claimKey(ctx, tx, email, idempotencyKey, operationPay, amount)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return emptyBalanceValue, false, fmt.Errorf("%s: %w", op, storage.ErrCardNotFound)
-		}
 		return emptyBalanceValue, false, fmt.Errorf("%s: %w", op, err)
 	}
+	if !claimed {
+		return balance, true, nil
+	}
 
-	if balance >= amount {
-		balance -= amount
-
-		stmt, err = s.db.Prepare("UPDATE cards SET balance = ? WHERE email = ?")
-		if err != nil {
+	err = tx.QueryRowContext(ctx, `
+		UPDATE cards SET balance = balance - ? WHERE email = ? AND balance >= ? RETURNING balance
+	`, amount, email, amount).Scan(&balance)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Either there is no card or not enough money on it.
+		var exists bool
+		if err := tx.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM cards WHERE email = ?)", email).Scan(&exists); err != nil {
 			return emptyBalanceValue, false, fmt.Errorf("%s: %w", op, err)
 		}
-		defer stmt.Close()
-
-		_, err = stmt.ExecContext(ctx, balance, email)
-		if err != nil {
-			return emptyBalanceValue, false, fmt.Errorf("%s: %w", op, err)
+		if !exists {
+			return emptyBalanceValue, false, fmt.Errorf("%s: %w", op, storage.ErrCardNotFound)
 		}
 
-		return balance, true, nil
-	} else {
 		return emptyBalanceValue, false, fmt.Errorf("%s: %w", op, storage.ErrNotEnoughFundsToPay)
 	}
+	if err != nil {
+		return emptyBalanceValue, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := saveKey(ctx, tx, email, idempotencyKey, balance); err != nil {
+		return emptyBalanceValue, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return emptyBalanceValue, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return balance, true, nil
 }
@@ -6,4 +6,5 @@ var (
 	ErrCardExists = errors.New("card already exists")
 	ErrCardNotFound = errors.New("card not found")
 	ErrNotEnoughFundsToPay = errors.New("not enough funds to pay")
+	ErrIdempotencyKeyReused = errors.New("idempotency key reused")
 )
\ No newline at end of file
@@ -0,0 +1,159 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"payments/internal/storage"
+	"payments/internal/storage/sqlite"
+	"testing"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/sqlite3"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	migrationsPath = "../migrations"
+	email          = "wallet@example.com"
+	funds          = 1000
+	price          = 300
+)
+
+func TestPay_SameKeyChargesOnce(t *testing.T) {
+	ctx, st := newStorage(t)
+
+	for range 3 {
+		balance, success, err := st.Pay(ctx, email, price, "booking:1:pay")
+		require.NoError(t, err)
+		assert.True(t, success)
+		assert.Equal(t, int64(funds-price), balance)
+	}
+
+	balance, err := st.Balance(ctx, email)
+	require.NoError(t, err)
+	assert.Equal(t, int64(funds-price), balance)
+
+	// Without a key every call is charged.
+	_, _, err = st.Pay(ctx, email, price, "")
+	require.NoError(t, err)
+	_, _, err = st.Pay(ctx, email, price, "")
+	require.NoError(t, err)
+
+	balance, err = st.Balance(ctx, email)
+	require.NoError(t, err)
+	assert.Equal(t, int64(funds-3*price), balance)
+}
+
+func TestAddFunds_SameKeyCreditsOnce(t *testing.T) {
+	ctx, st := newStorage(t)
+
+	for range 3 {
+		balance, success, err := st.AddFunds(ctx, email, price, "booking:1:refund")
+		require.NoError(t, err)
+		assert.True(t, success)
+		assert.Equal(t, int64(funds+price), balance)
+	}
+
+	balance, err := st.Balance(ctx, email)
+	require.NoError(t, err)
+	assert.Equal(t, int64(funds+price), balance)
+}
+
+func TestPay_KeyReusedForAnotherRequest(t *testing.T) {
+	ctx, st := newStorage(t)
+
+	_, _, err := st.Pay(ctx, email, price, "booking:1:pay")
+	require.NoError(t, err)
+
+	// Another amount or another operation can't use the key.
+	_, _, err = st.Pay(ctx, email, price+1, "booking:1:pay")
+	assert.ErrorIs(t, err, storage.ErrIdempotencyKeyReused)
+
+	_, _, err = st.AddFunds(ctx, email, price, "booking:1:pay")
+	assert.ErrorIs(t, err, storage.ErrIdempotencyKeyReused)
+
+	balance, err := st.Balance(ctx, email)
+	require.NoError(t, err)
+	assert.Equal(t, int64(funds-price), balance)
+}
+
+func TestPay_KeysAreScopedToTheUser(t *testing.T) {
+	ctx, st := newStorage(t)
+
+	const other = "other@example.com"
+
+	addCard(ctx, t, st, other)
+
+	// The same key of another user is another request: it neither takes the
+	// key away from the first user nor gets the result of their charge.
+	_, _, err := st.Pay(ctx, other, price+1, "booking:1:refund")
+	require.NoError(t, err)
+
+	balance, success, err := st.AddFunds(ctx, email, price, "booking:1:refund")
+	require.NoError(t, err)
+	assert.True(t, success)
+	assert.Equal(t, int64(funds+price), balance)
+
+	balance, err = st.Balance(ctx, other)
+	require.NoError(t, err)
+	assert.Equal(t, int64(funds-price-1), balance)
+}
+
+func TestPay_FailedChargeKeepsTheKey(t *testing.T) {
+	ctx, st := newStorage(t)
+
+	_, _, err := st.Pay(ctx, email, 2*funds, "booking:1:pay")
+	require.ErrorIs(t, err, storage.ErrNotEnoughFundsToPay)
+
+	_, _, err = st.Pay(ctx, "nocard@example.com", price, "booking:2:pay")
+	require.ErrorIs(t, err, storage.ErrCardNotFound)
+
+	// The declined charge did not take the key, a retry is charged.
+	_, _, err = st.AddFunds(ctx, email, funds, "")
+	require.NoError(t, err)
+
+	balance, success, err := st.Pay(ctx, email, 2*funds, "booking:1:pay")
+	require.NoError(t, err)
+	assert.True(t, success)
+	assert.Equal(t, int64(0), balance)
+}
+
+// newStorage opens a fresh migrated database with a card of email holding funds.
+func newStorage(t *testing.T) (context.Context, *sqlite.Storage) {
+	t.Helper()
+	t.Parallel()
+
+	storagePath := filepath.Join(t.TempDir(), "payments.db")
+
+	m, err := migrate.New("file://"+migrationsPath, fmt.Sprintf("sqlite3://%s", storagePath))
+	require.NoError(t, err)
+
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+	m.Close()
+
+	st, err := sqlite.New(storagePath)
+	require.NoError(t, err)
+
+	ctx := t.Context()
+
+	addCard(ctx, t, st, email)
+
+	return ctx, st
+}
+
+// addCard adds a card of the user holding funds.
+func addCard(ctx context.Context, t *testing.T, st *sqlite.Storage, email string) {
+	t.Helper()
+
+	_, err := st.AddCard(ctx, email, []byte("phone"), []byte("card-"+email), []byte("cvc"))
+	require.NoError(t, err)
+
+	_, _, err = st.AddFunds(ctx, email, funds, "")
+	require.NoError(t, err)
+}
@@ -18,6 +18,7 @@ import (
 	"sport-box-api/internal/http-server/handlers/paym/getcard"
 	authMW "sport-box-api/internal/http-server/middleware/auth"
 	mwLogger "sport-box-api/internal/http-server/middleware/logger"
+	"sport-box-api/internal/lib/api/idempotency"
 	"sport-box-api/internal/lib/logger/handlers/slogpretty"
 	"sport-box-api/internal/lib/logger/sl"
 
@@ -85,8 +86,8 @@ func main() {
 	// Configure CORS
 	router.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"http://localhost:8080", "http://localhost:8082", "http://localhost:3000"},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Content-Type", "Authorization", "Accept", "X-Requested-With"},
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Content-Type", "Authorization", "Accept", "X-Requested-With", idempotency.Header},
 		ExposedHeaders:   []string{"Set-Cookie"},
 		AllowCredentials: true,
 		MaxAge:           300,
@@ -106,6 +107,12 @@ func main() {
 		// Public routes
 		r.Post("/auth/register", register.New(context.Background(), log, *ssoClient))
 		r.Post("/auth/login", login.New(context.Background(), log, *ssoClient))
+		r.Get("/boxes/{name}/schedule", book.BoxCalendar(context.Background(), log, *bookingClient))
+		// GET /bookings.ics is authorized by the feed token instead of a JWT.
+		r.Get("/bookings", book.CalendarOr(
+			book.BookingsCalendar(context.Background(), log, *bookingClient),
+			authMW.AuthorizeJWTToken(book.GetBookings(context.Background(), log, *bookingClient)),
+		))
 
 		// Protected routes
 		r.Group(func(r chi.Router) {
@@ -114,9 +121,28 @@ func main() {
 			r.Post("/payments/add-funds", addfunds.New(context.Background(), log, *paymentsClient))
 			r.Get("/payments/cards", getcard.New(context.Background(), log, *paymentsClient))
 			r.Post("/book", book.New(context.Background(), log, *bookingClient))
+			r.Post("/book/quote", book.Quote(context.Background(), log, *bookingClient))
+			r.Post("/book/hold", book.Hold(context.Background(), log, *bookingClient))
+			r.Post("/book/series", book.NewSeries(context.Background(), log, *bookingClient))
 			r.Get("/boxes", book.GetBoxes(context.Background(), log, *bookingClient))
-			r.Get("/bookings", book.GetBookings(context.Background(), log, *bookingClient))
+			r.Get("/boxes/{name}", book.GetBox(context.Background(), log, *bookingClient))
+			r.Get("/boxes/{name}/availability", book.GetAvailability(context.Background(), log, *bookingClient))
+			r.Get("/venues", book.GetVenues(context.Background(), log, *bookingClient))
+			r.Get("/bookings/calendar", book.GetCalendarFeed(context.Background(), log, *bookingClient))
+			r.Post("/bookings/calendar", book.RotateCalendarFeed(context.Background(), log, *bookingClient))
 			r.Delete("/bookings/{id}", book.Cancel(bookingClient))
+			r.Patch("/bookings/{id}", book.Reschedule(context.Background(), log, *bookingClient))
+			r.Delete("/series/{id}", book.CancelSeries(bookingClient))
+			r.Post("/bookings/{id}/participants", book.InviteParticipant(context.Background(), log, *bookingClient))
+			r.Get("/bookings/{id}/participants", book.GetParticipants(context.Background(), log, *bookingClient))
+			r.Get("/invitations", book.GetInvitations(context.Background(), log, *bookingClient))
+			r.Post("/invitations/{id}/accept", book.AcceptInvitation(context.Background(), log, *bookingClient))
+			r.Post("/invitations/{id}/decline", book.DeclineInvitation(context.Background(), log, *bookingClient))
+			r.Post("/waitlist", book.JoinWaitlist(context.Background(), log, *bookingClient))
+			r.Get("/waitlist", book.GetWaitlist(context.Background(), log, *bookingClient))
+			r.Post("/waitlist/{id}/accept", book.AcceptWaitlistOffer(context.Background(), log, *bookingClient))
+			r.Delete("/waitlist/{id}", book.LeaveWaitlist(context.Background(), log, *bookingClient))
+			r.Put("/notifications/settings", book.SetNotificationSettings(context.Background(), log, *bookingClient))
 		})
 	})
 
@@ -52,25 +52,63 @@ func New(ctx context.Context, log *slog.Logger, addr string, timeout time.Durati
 	}, nil
 }
 
-type Box struct {
-	Name         string
-	PricePerHour float64
-	Available    bool
-}
+// Book books a box, or the slot of a hold when holdID is set. A call retried
+// with the same idempotency key returns the booking made by the first one.
+func (c *Client) Book(ctx context.Context, email string, boxName string, peopleAmount int64, timeStart string, timeHrs int64, timeMins int64, holdID string, promoCode string, idempotencyKey string) (balance int64, bookingID string, reserveID int64, price *bookingv1.Price, access *bookingv1.AccessCredentials, success bool, err error) {
+	const op = "bookgrpc.Book"
 
-type Booking struct {
-	Id           int64
-	BoxName      string
-	TimeStart    string
-	TimeHrs      int64
-	TimeMins     int64
-	PeopleAmount int64
+	resp, err := c.api.Book(ctx, &bookingv1.BookRequest{
+		Email:          email,
+		BoxName:        boxName,
+		PeopleAmount:   peopleAmount,
+		TimeStart:      timeStart,
+		TimeHrs:        timeHrs,
+		TimeMins:       timeMins,
+		IdempotencyKey: idempotencyKey,
+		HoldId:         holdID,
+		PromoCode:      promoCode,
+	})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if ok {
+			switch st.Code() {
+			case codes.Canceled:
+				return emptyBalanceValue, "", 0, nil, nil, false, fmt.Errorf("%s", st.Message())
+			case codes.AlreadyExists:
+				return emptyBalanceValue, "", 0, nil, nil, false, fmt.Errorf("%s", st.Message())
+			case codes.NotFound:
+				return emptyBalanceValue, "", 0, nil, nil, false, fmt.Errorf("%s", st.Message())
+			case codes.PermissionDenied:
+				return emptyBalanceValue, "", 0, nil, nil, false, fmt.Errorf("%s", st.Message())
+			case codes.InvalidArgument:
+				return emptyBalanceValue, "", 0, nil, nil, false, fmt.Errorf("%s", st.Message())
+			case codes.OutOfRange:
+				return emptyBalanceValue, "", 0, nil, nil, false, fmt.Errorf("%s", st.Message())
+			case codes.FailedPrecondition:
+				return emptyBalanceValue, "", 0, nil, nil, false, fmt.Errorf("%s", st.Message())
+			case codes.Aborted:
+				return emptyBalanceValue, "", 0, nil, nil, false, fmt.Errorf("%s", st.Message())
+			case codes.ResourceExhausted:
+				return emptyBalanceValue, "", 0, nil, nil, false, fmt.Errorf("%s", st.Message())
+			case codes.Unavailable:
+				return emptyBalanceValue, "", 0, nil, nil, false, fmt.Errorf("%s", st.Message())
+			case codes.Internal:
+				return emptyBalanceValue, "", 0, nil, nil, false, fmt.Errorf("%s", st.Message())
+			}
+		}
+
+		return emptyBalanceValue, "", 0, nil, nil, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp.Balance, resp.BookingUid, resp.ReserveId, resp.Price, resp.Access, resp.Success, nil
 }
 
-func (c *Client) Book(ctx context.Context, email string, boxName string, peopleAmount int64, timeStart string, timeHrs int64, timeMins int64) (balance int64, resID int64, success bool, err error) {
-	const op = "bookgrpc.Book"
+// HoldSlot reserves a slot for the user while they check out. The hold is
+// booked with Book.
+func (c *Client) HoldSlot(ctx context.Context, email string, boxName string, peopleAmount int64, timeStart string, timeHrs int64, timeMins int64) (*bookingv1.HoldSlotResponse, error) {
+	const op = "bookgrpc.HoldSlot"
 
-	resp, err := c.api.Book(ctx, &bookingv1.BookRequest{
+	resp, err := c.api.HoldSlot(ctx, &bookingv1.HoldSlotRequest{
 		Email:        email,
 		BoxName:      boxName,
 		PeopleAmount: peopleAmount,
@@ -82,51 +120,57 @@ func (c *Client) Book(ctx context.Context, email string, boxName string, peopleA
 		st, ok := status.FromError(err)
 		if ok {
 			switch st.Code() {
-			case codes.Canceled:
-				return emptyBalanceValue,  0, false, fmt.Errorf("%s", st.Message())
 			case codes.AlreadyExists:
-				return emptyBalanceValue, 0, false, fmt.Errorf("%s", st.Message())
+				return nil, fmt.Errorf("%s", st.Message())
 			case codes.NotFound:
-				return emptyBalanceValue, 0, false, fmt.Errorf("%s", st.Message())
+				return nil, fmt.Errorf("%s", st.Message())
 			case codes.InvalidArgument:
-				return emptyBalanceValue,  0, false, fmt.Errorf("%s", st.Message())
-			case codes.OutOfRange:
-				return emptyBalanceValue, 0, false, fmt.Errorf("%s", st.Message())
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.FailedPrecondition:
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.ResourceExhausted:
+				return nil, fmt.Errorf("%s", st.Message())
 			case codes.Internal:
-				return emptyBalanceValue, 0, false, fmt.Errorf("%s", st.Message())
+				return nil, fmt.Errorf("%s", st.Message())
 			}
 		}
-
-		return emptyBalanceValue, 0, false, fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return resp.Balance, resp.ReserveId, resp.Success, nil
+	return resp, nil
 }
 
-func (c *Client) CancelBooking(ctx context.Context, email string, bookingID int64) (refundedAmount int64, balance int64, success bool, err error) {
+func (c *Client) CancelBooking(ctx context.Context, email string, bookingID string, idempotencyKey string) (refundedAmount int64, balance int64, policy *bookingv1.RefundPolicy, success bool, err error) {
 	const op = "bookgrpc.CancelBooking"
 
 	resp, err := c.api.CancelBooking(ctx, &bookingv1.CancelBookingRequest{
-		Email:     email,
-		BookingId: bookingID,
+		Email:          email,
+		BookingUid:     bookingID,
+		IdempotencyKey: idempotencyKey,
 	})
 	if err != nil {
 		st, ok := status.FromError(err)
 		if ok {
 			switch st.Code() {
 			case codes.NotFound:
-				return 0, emptyBalanceValue, false, fmt.Errorf("%s", st.Message())
+				return 0, emptyBalanceValue, nil, false, fmt.Errorf("%s", st.Message())
 			case codes.PermissionDenied:
-				return 0, emptyBalanceValue, false, fmt.Errorf("%s", st.Message())
+				return 0, emptyBalanceValue, nil, false, fmt.Errorf("%s", st.Message())
+			case codes.FailedPrecondition:
+				return 0, emptyBalanceValue, nil, false, fmt.Errorf("%s", st.Message())
+			case codes.Aborted:
+				return 0, emptyBalanceValue, nil, false, fmt.Errorf("%s", st.Message())
+			case codes.Unavailable:
+				return 0, emptyBalanceValue, nil, false, fmt.Errorf("%s", st.Message())
 			case codes.Internal:
-				return 0, emptyBalanceValue, false, fmt.Errorf("%s", st.Message())
+				return 0, emptyBalanceValue, nil, false, fmt.Errorf("%s", st.Message())
 			}
 		}
 
-		return 0, emptyBalanceValue, false, fmt.Errorf("%s: %w", op, err)
+		return 0, emptyBalanceValue, nil, false, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return resp.RefundedAmount, resp.Balance, resp.Success, nil
+	return resp.RefundedAmount, resp.Balance, resp.RefundPolicy, resp.Success, nil
 }
 
 func (c *Client) GetBoxes(ctx context.Context) ([]*bookingv1.Box, error) {
@@ -146,11 +190,11 @@ func (c *Client) GetBoxes(ctx context.Context) ([]*bookingv1.Box, error) {
 	return resp.Boxes, nil
 }
 
-func (c *Client) GetBookings(ctx context.Context, email string) ([]*bookingv1.Booking, error) {
-	const op = "bookgrpc.GetBookings"
+func (c *Client) GetBox(ctx context.Context, name string) (*bookingv1.Box, error) {
+	const op = "bookgrpc.GetBox"
 
-	resp, err := c.api.GetBookings(ctx, &bookingv1.GetBookingsRequest{
-		Email: email,
+	resp, err := c.api.GetBox(ctx, &bookingv1.GetBoxRequest{
+		Name: name,
 	})
 	if err != nil {
 		st, ok := status.FromError(err)
@@ -165,7 +209,437 @@ func (c *Client) GetBookings(ctx context.Context, email string) ([]*bookingv1.Bo
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return resp.Bookings, nil
+	return resp.Box, nil
+}
+
+func (c *Client) GetAvailability(ctx context.Context, boxName string, date string, slotMinutes int64) (*bookingv1.GetAvailabilityResponse, error) {
+	const op = "bookgrpc.GetAvailability"
+
+	resp, err := c.api.GetAvailability(ctx, &bookingv1.GetAvailabilityRequest{
+		BoxName:     boxName,
+		Date:        date,
+		SlotMinutes: slotMinutes,
+	})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if ok {
+			switch st.Code() {
+			case codes.NotFound:
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.InvalidArgument:
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.Internal:
+				return nil, fmt.Errorf("%s", st.Message())
+			}
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp, nil
+}
+
+// GetNearbyVenues returns the venues within radiusKm of the point, the
+// nearest first. Zero radiusKm and limit take the defaults of the service.
+func (c *Client) GetNearbyVenues(ctx context.Context, latitude float64, longitude float64, radiusKm float64, limit int64) ([]*bookingv1.NearbyVenue, error) {
+	const op = "bookgrpc.GetNearbyVenues"
+
+	resp, err := c.api.GetNearbyVenues(ctx, &bookingv1.GetNearbyVenuesRequest{
+		Latitude:  latitude,
+		Longitude: longitude,
+		RadiusKm:  radiusKm,
+		Limit:     limit,
+	})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if ok {
+			switch st.Code() {
+			case codes.InvalidArgument:
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.Internal:
+				return nil, fmt.Errorf("%s", st.Message())
+			}
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp.Venues, nil
+}
+
+func (c *Client) QuotePrice(ctx context.Context, email string, boxName string, peopleAmount int64, timeStart string, timeHrs int64, timeMins int64, promoCode string) (*bookingv1.QuotePriceResponse, error) {
+	const op = "bookgrpc.QuotePrice"
+
+	resp, err := c.api.QuotePrice(ctx, &bookingv1.QuotePriceRequest{
+		Email:        email,
+		BoxName:      boxName,
+		PeopleAmount: peopleAmount,
+		TimeStart:    timeStart,
+		TimeHrs:      timeHrs,
+		TimeMins:     timeMins,
+		PromoCode:    promoCode,
+	})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if ok {
+			switch st.Code() {
+			case codes.NotFound:
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.InvalidArgument:
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.FailedPrecondition:
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.ResourceExhausted:
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.Internal:
+				return nil, fmt.Errorf("%s", st.Message())
+			}
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp, nil
+}
+
+func (c *Client) BookSeries(ctx context.Context, email string, boxName string, peopleAmount int64, timeStart string, timeHrs int64, timeMins int64, rrule string, payment string) (*bookingv1.BookSeriesResponse, error) {
+	const op = "bookgrpc.BookSeries"
+
+	resp, err := c.api.BookSeries(ctx, &bookingv1.BookSeriesRequest{
+		Email:        email,
+		BoxName:      boxName,
+		PeopleAmount: peopleAmount,
+		TimeStart:    timeStart,
+		TimeHrs:      timeHrs,
+		TimeMins:     timeMins,
+		Rrule:        rrule,
+		Payment:      payment,
+	})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if ok {
+			switch st.Code() {
+			case codes.NotFound:
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.InvalidArgument:
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.FailedPrecondition:
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.Internal:
+				return nil, fmt.Errorf("%s", st.Message())
+			}
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp, nil
+}
+
+func (c *Client) CancelSeries(ctx context.Context, email string, seriesID string) (*bookingv1.CancelSeriesResponse, error) {
+	const op = "bookgrpc.CancelSeries"
+
+	resp, err := c.api.CancelSeries(ctx, &bookingv1.CancelSeriesRequest{
+		Email:     email,
+		SeriesUid: seriesID,
+	})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if ok {
+			switch st.Code() {
+			case codes.NotFound:
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.PermissionDenied:
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.FailedPrecondition:
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.InvalidArgument:
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.Internal:
+				return nil, fmt.Errorf("%s", st.Message())
+			}
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp, nil
+}
+
+func (c *Client) JoinWaitlist(ctx context.Context, email string, boxName string, peopleAmount int64, timeStart string, timeHrs int64, timeMins int64, mode string) (*bookingv1.WaitlistEntry, error) {
+	const op = "bookgrpc.JoinWaitlist"
+
+	resp, err := c.api.JoinWaitlist(ctx, &bookingv1.JoinWaitlistRequest{
+		Email:        email,
+		BoxName:      boxName,
+		PeopleAmount: peopleAmount,
+		TimeStart:    timeStart,
+		TimeHrs:      timeHrs,
+		TimeMins:     timeMins,
+		Mode:         mode,
+	})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if ok {
+			switch st.Code() {
+			case codes.NotFound:
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.InvalidArgument:
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.FailedPrecondition:
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.Internal:
+				return nil, fmt.Errorf("%s", st.Message())
+			}
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp.Entry, nil
+}
+
+func (c *Client) GetWaitlist(ctx context.Context, email string) ([]*bookingv1.WaitlistEntry, error) {
+	const op = "bookgrpc.GetWaitlist"
+
+	resp, err := c.api.GetWaitlist(ctx, &bookingv1.GetWaitlistRequest{
+		Email: email,
+	})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if ok {
+			switch st.Code() {
+			case codes.InvalidArgument:
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.Internal:
+				return nil, fmt.Errorf("%s", st.Message())
+			}
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp.Entries, nil
+}
+
+func (c *Client) AcceptWaitlistOffer(ctx context.Context, email string, entryID string) (*bookingv1.BookResponse, error) {
+	const op = "bookgrpc.AcceptWaitlistOffer"
+
+	resp, err := c.api.AcceptWaitlistOffer(ctx, &bookingv1.AcceptWaitlistOfferRequest{
+		Email:    email,
+		EntryUid: entryID,
+	})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if ok {
+			switch st.Code() {
+			case codes.NotFound:
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.PermissionDenied:
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.FailedPrecondition:
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.InvalidArgument:
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.OutOfRange:
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.Canceled:
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.Unavailable:
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.Internal:
+				return nil, fmt.Errorf("%s", st.Message())
+			}
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp, nil
+}
+
+func (c *Client) LeaveWaitlist(ctx context.Context, email string, entryID string) error {
+	const op = "bookgrpc.LeaveWaitlist"
+
+	_, err := c.api.LeaveWaitlist(ctx, &bookingv1.LeaveWaitlistRequest{
+		Email:    email,
+		EntryUid: entryID,
+	})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if ok {
+			switch st.Code() {
+			case codes.NotFound:
+				return fmt.Errorf("%s", st.Message())
+			case codes.PermissionDenied:
+				return fmt.Errorf("%s", st.Message())
+			case codes.InvalidArgument:
+				return fmt.Errorf("%s", st.Message())
+			case codes.Internal:
+				return fmt.Errorf("%s", st.Message())
+			}
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (c *Client) RescheduleBooking(ctx context.Context, email string, bookingID string, boxName string, timeStart string, timeHrs int64, timeMins int64) (*bookingv1.RescheduleBookingResponse, error) {
+	const op = "bookgrpc.RescheduleBooking"
+
+	resp, err := c.api.RescheduleBooking(ctx, &bookingv1.RescheduleBookingRequest{
+		Email:      email,
+		BookingUid: bookingID,
+		BoxName:    boxName,
+		TimeStart:  timeStart,
+		TimeHrs:    timeHrs,
+		TimeMins:   timeMins,
+	})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if ok {
+			switch st.Code() {
+			case codes.NotFound:
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.PermissionDenied:
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.FailedPrecondition:
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.InvalidArgument:
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.AlreadyExists:
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.OutOfRange:
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.Canceled:
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.Unavailable:
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.Internal:
+				return nil, fmt.Errorf("%s", st.Message())
+			}
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp, nil
+}
+
+func (c *Client) GetCalendarFeed(ctx context.Context, email string, rotate bool) (string, error) {
+	const op = "bookgrpc.GetCalendarFeed"
+
+	resp, err := c.api.GetCalendarFeed(ctx, &bookingv1.GetCalendarFeedRequest{
+		Email:  email,
+		Rotate: rotate,
+	})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if ok {
+			switch st.Code() {
+			case codes.InvalidArgument:
+				return "", fmt.Errorf("%s", st.Message())
+			case codes.Internal:
+				return "", fmt.Errorf("%s", st.Message())
+			}
+		}
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp.Token, nil
+}
+
+func (c *Client) GetBookingsCalendar(ctx context.Context, token string) (string, error) {
+	const op = "bookgrpc.GetBookingsCalendar"
+
+	resp, err := c.api.GetBookingsCalendar(ctx, &bookingv1.GetBookingsCalendarRequest{
+		Token: token,
+	})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if ok {
+			switch st.Code() {
+			case codes.NotFound:
+				return "", fmt.Errorf("%s", st.Message())
+			case codes.InvalidArgument:
+				return "", fmt.Errorf("%s", st.Message())
+			case codes.Internal:
+				return "", fmt.Errorf("%s", st.Message())
+			}
+		}
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp.Calendar, nil
+}
+
+func (c *Client) GetBoxCalendar(ctx context.Context, boxName string) (string, error) {
+	const op = "bookgrpc.GetBoxCalendar"
+
+	resp, err := c.api.GetBoxCalendar(ctx, &bookingv1.GetBoxCalendarRequest{
+		BoxName: boxName,
+	})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if ok {
+			switch st.Code() {
+			case codes.NotFound:
+				return "", fmt.Errorf("%s", st.Message())
+			case codes.InvalidArgument:
+				return "", fmt.Errorf("%s", st.Message())
+			case codes.Internal:
+				return "", fmt.Errorf("%s", st.Message())
+			}
+		}
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp.Calendar, nil
+}
+
+func (c *Client) SetNotificationSettings(ctx context.Context, email string, locale string) (string, error) {
+	const op = "bookgrpc.SetNotificationSettings"
+
+	resp, err := c.api.SetNotificationSettings(ctx, &bookingv1.SetNotificationSettingsRequest{
+		Email:  email,
+		Locale: locale,
+	})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if ok {
+			switch st.Code() {
+			case codes.InvalidArgument:
+				return "", fmt.Errorf("%s", st.Message())
+			case codes.Internal:
+				return "", fmt.Errorf("%s", st.Message())
+			}
+		}
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp.Locale, nil
+}
+
+func (c *Client) GetBookings(ctx context.Context, email string, bookingStatus string, from string, to string, limit int32, cursor string) ([]*bookingv1.Booking, string, error) {
+	const op = "bookgrpc.GetBookings"
+
+	resp, err := c.api.GetBookings(ctx, &bookingv1.GetBookingsRequest{
+		Email:  email,
+		Status: bookingStatus,
+		From:   from,
+		To:     to,
+		Limit:  limit,
+		Cursor: cursor,
+	})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if ok {
+			if st.Code() == codes.NotFound {
+				return nil, "", fmt.Errorf("%s", st.Message())
+			}
+			if st.Code() == codes.InvalidArgument {
+				return nil, "", fmt.Errorf("%s", st.Message())
+			}
+			if st.Code() == codes.Internal {
+				return nil, "", fmt.Errorf("%s", st.Message())
+			}
+		}
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp.Bookings, resp.NextCursor, nil
 }
 
 
@@ -174,3 +648,114 @@ func InterceptorLogger(l *slog.Logger) grpclog.Logger {
 		l.Log(ctx, slog.Level(lvl), msg, fields...)
 	})
 }
+
+func (c *Client) InviteParticipant(ctx context.Context, email string, bookingID string, participantEmail string) (*bookingv1.Participant, error) {
+	const op = "bookgrpc.InviteParticipant"
+
+	resp, err := c.api.InviteParticipant(ctx, &bookingv1.InviteParticipantRequest{
+		Email:            email,
+		BookingUid:       bookingID,
+		ParticipantEmail: participantEmail,
+	})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if ok {
+			switch st.Code() {
+			case codes.NotFound:
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.PermissionDenied:
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.InvalidArgument:
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.AlreadyExists:
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.ResourceExhausted:
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.FailedPrecondition:
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.Internal:
+				return nil, fmt.Errorf("%s", st.Message())
+			}
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp.Participant, nil
+}
+
+func (c *Client) RespondToInvitation(ctx context.Context, email string, bookingID string, accept bool) (*bookingv1.Participant, error) {
+	const op = "bookgrpc.RespondToInvitation"
+
+	resp, err := c.api.RespondToInvitation(ctx, &bookingv1.RespondToInvitationRequest{
+		Email:      email,
+		BookingUid: bookingID,
+		Accept:     accept,
+	})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if ok {
+			switch st.Code() {
+			case codes.NotFound:
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.InvalidArgument:
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.FailedPrecondition:
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.Internal:
+				return nil, fmt.Errorf("%s", st.Message())
+			}
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp.Participant, nil
+}
+
+func (c *Client) GetParticipants(ctx context.Context, email string, bookingID string) ([]*bookingv1.Participant, error) {
+	const op = "bookgrpc.GetParticipants"
+
+	resp, err := c.api.GetParticipants(ctx, &bookingv1.GetParticipantsRequest{
+		Email:      email,
+		BookingUid: bookingID,
+	})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if ok {
+			switch st.Code() {
+			case codes.NotFound:
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.PermissionDenied:
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.InvalidArgument:
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.Internal:
+				return nil, fmt.Errorf("%s", st.Message())
+			}
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp.Participants, nil
+}
+
+func (c *Client) GetInvitations(ctx context.Context, email string) ([]*bookingv1.Invitation, error) {
+	const op = "bookgrpc.GetInvitations"
+
+	resp, err := c.api.GetInvitations(ctx, &bookingv1.GetInvitationsRequest{
+		Email: email,
+	})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if ok {
+			switch st.Code() {
+			case codes.InvalidArgument:
+				return nil, fmt.Errorf("%s", st.Message())
+			case codes.Internal:
+				return nil, fmt.Errorf("%s", st.Message())
+			}
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp.Invitations, nil
+}
\ No newline at end of file
@@ -92,12 +92,15 @@ func (c *Client) AddCard(ctx context.Context, email string, cardNumber int64, cv
 	return resp.Success, nil
 }
 
-func (c *Client) AddFunds(ctx context.Context, email string, amount int64) (int64, bool, error) {
+// AddFunds credits the wallet. A call retried with the same idempotency key
+// credits it once.
+func (c *Client) AddFunds(ctx context.Context, email string, amount int64, idempotencyKey string) (int64, bool, error) {
 	const op = "clients.payments.grpc.AddFunds"
 
 	resp, err := c.client.AddFunds(ctx, &paymentsv1.AddFundsRequest{
-		Email:  email,
-		Amount: amount,
+		Email:          email,
+		Amount:         amount,
+		IdempotencyKey: idempotencyKey,
 	})
 	if err != nil {
 		st, ok := status.FromError(err)
@@ -105,6 +108,9 @@ func (c *Client) AddFunds(ctx context.Context, email string, amount int64) (int6
 			if st.Code() == codes.NotFound {
 				return defaultEmptyBalance, false, fmt.Errorf("%s: card not found", op)
 			}
+			if st.Code() == codes.FailedPrecondition {
+				return defaultEmptyBalance, false, fmt.Errorf("%s", st.Message())
+			}
 		}
 		return defaultEmptyBalance, false, fmt.Errorf("%s: %w", op, err)
 	}
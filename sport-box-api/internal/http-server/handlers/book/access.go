@@ -0,0 +1,25 @@
+package book
+
+import bookingv1 "github.com/MKode312/protos/gen/go/booking"
+
+// Access opens the door of the box from ValidFrom until ValidUntil: the PIN
+// is entered at the door, the token can be shown as a QR code.
+type Access struct {
+	PIN        string `json:"pin,omitempty"`
+	Token      string `json:"token"`
+	ValidFrom  string `json:"validFrom"`
+	ValidUntil string `json:"validUntil"`
+}
+
+func toAccess(access *bookingv1.AccessCredentials) *Access {
+	if access == nil {
+		return nil
+	}
+
+	return &Access{
+		PIN:        access.GetPin(),
+		Token:      access.GetToken(),
+		ValidFrom:  access.GetValidFrom(),
+		ValidUntil: access.GetValidUntil(),
+	}
+}
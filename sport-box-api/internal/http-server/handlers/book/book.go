@@ -5,6 +5,8 @@ import (
 	"log/slog"
 	"net/http"
 	bookgrpc "sport-box-api/internal/clients/booking/grpc"
+	authMW "sport-box-api/internal/http-server/middleware/auth"
+	"sport-box-api/internal/lib/api/idempotency"
 	"sport-box-api/internal/lib/api/response"
 	bookerrors "sport-box-api/internal/lib/errors/booking"
 	"sport-box-api/internal/lib/logger/sl"
@@ -14,8 +16,24 @@ import (
 	"github.com/go-playground/validator/v10"
 )
 
-type Request struct {
-	Email        string `json:"email" validate:"required"`
+// Friendly messages for slots outside the schedule of the box.
+const (
+	closedMessage      = "The sport box is closed at this time, check its opening hours"
+	maintenanceMessage = "The sport box is closed for maintenance at this time, choose another time"
+)
+
+// Friendly messages for payments whose outcome is not known yet. The booking
+// service settles them in the background.
+const (
+	paymentPendingMessage = "The payment is being processed, check GET /api/bookings for the booking"
+	refundPendingMessage  = "The refund is being processed, check GET /api/bookings for the booking"
+)
+
+// Slot is the box and the time of a booking, the user comes from the token.
+// TimeStart is either an RFC 3339 timestamp ("2025-11-08T10:00:00+07:00") or a
+// local date and time ("2025-11-08T10:00") which the booking service resolves
+// in the box's own time zone.
+type Slot struct {
 	BoxName      string `json:"boxName" validate:"required"`
 	PeopleAmount int64  `json:"peopleAmount" validate:"required"`
 	TimeStart    string `json:"timeStart" validate:"required"`
@@ -23,10 +41,23 @@ type Request struct {
 	TimeMins     int64  `json:"timeMins" validate:"required"`
 }
 
+// BookRequest books the slot for the caller, or the slot of a hold made with
+// POST /book/hold when HoldID is set. The slot fields aren't needed then.
+// PromoCode is optional and discounts the price.
+type BookRequest struct {
+	Slot
+	HoldID    string `json:"holdId"`
+	PromoCode string `json:"promoCode"`
+}
+
 type Response struct {
-	Success bool  `json:"success"`
-	Balance int64 `json:"balance"`
-	ResID   int64 `json:"reserveID"`
+	Success   bool   `json:"success"`
+	Balance   int64  `json:"balance"`
+	BookingID string `json:"bookingId"`
+	// ResID is the legacy numeric ID, kept while clients move to BookingID.
+	ResID  int64   `json:"reserveID"`
+	Price  *Price  `json:"price,omitempty"`
+	Access *Access `json:"access,omitempty"`
 	response.Response
 }
 
@@ -39,7 +70,14 @@ func New(ctx context.Context, log *slog.Logger, bookingclient bookgrpc.Client) h
 			slog.String("request_id", middleware.GetReqID(r.Context())),
 		)
 
-		var req Request
+		email, ok := authMW.UserEmail(r.Context())
+		if !ok {
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, response.Error("Unauthorized"))
+			return
+		}
+
+		var req BookRequest
 
 		err := render.DecodeJSON(r.Body, &req)
 		if err != nil {
@@ -52,7 +90,10 @@ func New(ctx context.Context, log *slog.Logger, bookingclient bookgrpc.Client) h
 
 		log.Info("request body decoded", slog.Any("request", req))
 
-		if err := validator.New().Struct(req); err != nil {
+		if req.HoldID == "" {
+			err = validator.New().Struct(req.Slot)
+		}
+		if err != nil {
 			validateErr := err.(validator.ValidationErrors)
 
 			log.Error("invalid request", sl.Err(err))
@@ -63,8 +104,81 @@ func New(ctx context.Context, log *slog.Logger, bookingclient bookgrpc.Client) h
 			return
 		}
 
-		balance, resID, success, err := bookingclient.Book(ctx, req.Email, req.BoxName, req.PeopleAmount, req.TimeStart, req.TimeHrs, req.TimeMins)
+		idempotencyKey, err := idempotency.Key(r)
 		if err != nil {
+			log.Error("invalid idempotency key", sl.Err(err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error(err.Error()))
+
+			return
+		}
+
+		balance, bookingID, resID, price, access, success, err := bookingclient.Book(ctx, email, req.BoxName, req.PeopleAmount, req.TimeStart, req.TimeHrs, req.TimeMins, req.HoldID, req.PromoCode, idempotencyKey)
+		if err != nil {
+			if err.Error() == bookerrors.ErrIdempotencyKeyReused.Error() {
+				log.Error("idempotency key reused")
+				render.Status(r, http.StatusUnprocessableEntity)
+				render.JSON(w, r, response.Error("This Idempotency-Key was already used for another request"))
+				return
+			}
+
+			if err.Error() == bookerrors.ErrRequestInProgress.Error() {
+				log.Error("request with the idempotency key in progress")
+				render.Status(r, http.StatusConflict)
+				render.JSON(w, r, response.Error("The request with this Idempotency-Key is still in progress, try again later"))
+				return
+			}
+
+			if err.Error() == bookerrors.ErrHoldNotFound.Error() {
+				log.Error("hold not found")
+				render.Status(r, http.StatusNotFound)
+				render.JSON(w, r, response.Error("The hold was not found, hold the slot again"))
+				return
+			}
+
+			if err.Error() == bookerrors.ErrNotYourHold.Error() {
+				log.Error("hold belongs to another user")
+				render.Status(r, http.StatusForbidden)
+				render.JSON(w, r, response.Error("This hold belongs to another user"))
+				return
+			}
+
+			if err.Error() == bookerrors.ErrHoldExpired.Error() {
+				log.Error("hold has expired")
+				render.Status(r, http.StatusGone)
+				render.JSON(w, r, response.Error("The hold has expired, hold the slot again"))
+				return
+			}
+
+			if err.Error() == bookerrors.ErrPromoCodeNotFound.Error() {
+				log.Error("promo code not found")
+				render.Status(r, http.StatusNotFound)
+				render.JSON(w, r, response.Error("The promo code was not found"))
+				return
+			}
+
+			if err.Error() == bookerrors.ErrPromoCodeExpired.Error() {
+				log.Error("promo code is not valid now")
+				render.Status(r, http.StatusUnprocessableEntity)
+				render.JSON(w, r, response.Error("The promo code is not valid at this time"))
+				return
+			}
+
+			if err.Error() == bookerrors.ErrPromoCodeNotApplicable.Error() {
+				log.Error("promo code does not apply")
+				render.Status(r, http.StatusUnprocessableEntity)
+				render.JSON(w, r, response.Error("The promo code does not apply to this sport box or time"))
+				return
+			}
+
+			if err.Error() == bookerrors.ErrPromoCodeExhausted.Error() {
+				log.Error("promo code used up")
+				render.Status(r, http.StatusConflict)
+				render.JSON(w, r, response.Error("The promo code has already been used up"))
+				return
+			}
+
 			if err.Error() == bookerrors.ErrInvalidCredentials.Error() {
 				log.Error("invalid credentials")
 				render.Status(r, http.StatusBadRequest)
@@ -72,6 +186,34 @@ func New(ctx context.Context, log *slog.Logger, bookingclient bookgrpc.Client) h
 				return
 			}
 
+			if err.Error() == bookerrors.ErrBookingInPast.Error() {
+				log.Error("booking in the past")
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.Error("You can't book a box for a time that has already passed"))
+				return
+			}
+
+			if err.Error() == bookerrors.ErrInvalidTimeStart.Error() {
+				log.Error("invalid start time", sl.Err(err))
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.Error("Start time must be a date and time, e.g. 2025-11-08T10:00"))
+				return
+			}
+
+			if err.Error() == bookerrors.ErrCapacityExceeded.Error() {
+				log.Error("too many people for the box")
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.Error("Too many people for this sport box"))
+				return
+			}
+
+			if err.Error() == bookerrors.ErrBookingTooLong.Error() {
+				log.Error("booking is too long")
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.Error("A sport box can't be booked for more than 24 hours"))
+				return
+			}
+
 			if err.Error() == bookerrors.ErrAlreadyBooked.Error() {
 				log.Error("already booked")
 				render.Status(r, http.StatusBadRequest)
@@ -79,6 +221,20 @@ func New(ctx context.Context, log *slog.Logger, bookingclient bookgrpc.Client) h
 				return
 			}
 
+			if err.Error() == bookerrors.ErrOutsideOpeningHours.Error() {
+				log.Error("the box is closed")
+				render.Status(r, http.StatusConflict)
+				render.JSON(w, r, response.Error(closedMessage))
+				return
+			}
+
+			if err.Error() == bookerrors.ErrMaintenance.Error() {
+				log.Error("the box is under maintenance")
+				render.Status(r, http.StatusConflict)
+				render.JSON(w, r, response.Error(maintenanceMessage))
+				return
+			}
+
 			if err.Error() == bookerrors.ErrBookingNotFound.Error() {
 				log.Error("booking not found")
 				render.Status(r, http.StatusInternalServerError)
@@ -100,6 +256,13 @@ func New(ctx context.Context, log *slog.Logger, bookingclient bookgrpc.Client) h
 				return
 			}
 
+			if err.Error() == bookerrors.ErrPaymentPending.Error() {
+				log.Warn("payment is being processed")
+				render.Status(r, http.StatusAccepted)
+				render.JSON(w, r, response.Error(paymentPendingMessage))
+				return
+			}
+
 			log.Error("failed to book a box and pay for it", sl.Err(err))
 
 			render.Status(r, http.StatusUnprocessableEntity)
@@ -108,13 +271,16 @@ func New(ctx context.Context, log *slog.Logger, bookingclient bookgrpc.Client) h
 			return
 		}
 
-		log.Info("successfully booked a box and paid for it", slog.Int64("reservationID", resID))
+		log.Info("successfully booked a box and paid for it", slog.String("bookingID", bookingID))
 
 		render.JSON(w, r, Response{
-			Balance:  balance,
-			Success:  success,
-			ResID:    resID,
-			Response: response.OK(),
+			Balance:   balance,
+			Success:   success,
+			BookingID: bookingID,
+			ResID:     resID,
+			Price:     toPrice(price),
+			Access:    toAccess(access),
+			Response:  response.OK(),
 		})
 	}
 }
@@ -0,0 +1,205 @@
+package book
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/url"
+	bookgrpc "sport-box-api/internal/clients/booking/grpc"
+	authMW "sport-box-api/internal/http-server/middleware/auth"
+	"sport-box-api/internal/lib/api/response"
+	bookerrors "sport-box-api/internal/lib/errors/booking"
+	"sport-box-api/internal/lib/logger/sl"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+// calendarFormat is the URL extension of calendar feeds, the URLFormat
+// middleware strips it from the route path.
+const calendarFormat = "ics"
+
+type CalendarFeedResponse struct {
+	// URL is the private feed URL, anyone knowing it can read the bookings.
+	URL string `json:"url"`
+	// WebcalURL subscribes to the feed in calendar apps.
+	WebcalURL string `json:"webcalUrl"`
+	response.Response
+}
+
+// @Summary Calendar feed URL
+// @Description Private iCalendar feed URL of the caller's bookings
+// @Tags calendar
+// @Produce json
+// @Success 200 {object} CalendarFeedResponse
+// @Failure 401 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /bookings/calendar [get]
+func GetCalendarFeed(ctx context.Context, log *slog.Logger, client bookgrpc.Client) http.HandlerFunc {
+	return calendarFeed(ctx, log, client, false)
+}
+
+// @Summary Rotate calendar feed URL
+// @Description Replace the private calendar feed URL, the old one stops working
+// @Tags calendar
+// @Produce json
+// @Success 200 {object} CalendarFeedResponse
+// @Failure 401 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /bookings/calendar [post]
+func RotateCalendarFeed(ctx context.Context, log *slog.Logger, client bookgrpc.Client) http.HandlerFunc {
+	return calendarFeed(ctx, log, client, true)
+}
+
+func calendarFeed(ctx context.Context, log *slog.Logger, client bookgrpc.Client, rotate bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.book.CalendarFeed"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		email, ok := authMW.UserEmail(r.Context())
+		if !ok {
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, response.Error("Unauthorized"))
+			return
+		}
+
+		token, err := client.GetCalendarFeed(ctx, email, rotate)
+		if err != nil {
+			log.Error("failed to get calendar feed", sl.Err(err))
+
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("Failed to get calendar feed"))
+
+			return
+		}
+
+		path := r.Host + "/api/bookings." + calendarFormat + "?token=" + url.QueryEscape(token)
+
+		scheme := "http"
+		if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+			scheme = "https"
+		}
+
+		render.JSON(w, r, CalendarFeedResponse{
+			URL:       scheme + "://" + path,
+			WebcalURL: "webcal://" + path,
+			Response:  response.OK(),
+		})
+	}
+}
+
+// @Summary Bookings calendar
+// @Description iCalendar feed of the bookings of the owner of the token
+// @Tags calendar
+// @Produce text/calendar
+// @Param token query string true "Feed token"
+// @Success 200 {string} string
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /bookings.ics [get]
+func BookingsCalendar(ctx context.Context, log *slog.Logger, client bookgrpc.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.book.BookingsCalendar"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		token := r.URL.Query().Get("token")
+		if token == "" {
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.Error(bookerrors.ErrCalendarNotFound.Error()))
+			return
+		}
+
+		calendar, err := client.GetBookingsCalendar(ctx, token)
+		if err != nil {
+			log.Error("failed to get bookings calendar", sl.Err(err))
+
+			if err.Error() == bookerrors.ErrCalendarNotFound.Error() {
+				render.Status(r, http.StatusNotFound)
+				render.JSON(w, r, response.Error(err.Error()))
+				return
+			}
+
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("Failed to get calendar"))
+
+			return
+		}
+
+		writeCalendar(w, "bookings", calendar, "private, no-store")
+	}
+}
+
+// @Summary Box calendar
+// @Description iCalendar feed of the schedule of a box, without personal data
+// @Tags calendar
+// @Produce text/calendar
+// @Param name path string true "Box name"
+// @Success 200 {string} string
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /boxes/{name}/schedule.ics [get]
+func BoxCalendar(ctx context.Context, log *slog.Logger, client bookgrpc.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.book.BoxCalendar"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		if format, _ := r.Context().Value(middleware.URLFormatCtxKey).(string); format != calendarFormat {
+			http.NotFound(w, r)
+			return
+		}
+
+		name := chi.URLParam(r, "name")
+
+		calendar, err := client.GetBoxCalendar(ctx, name)
+		if err != nil {
+			log.Error("failed to get box calendar", sl.Err(err))
+
+			if err.Error() == bookerrors.ErrBoxNotFound.Error() {
+				render.Status(r, http.StatusNotFound)
+				render.JSON(w, r, response.Error(err.Error()))
+				return
+			}
+
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("Failed to get calendar"))
+
+			return
+		}
+
+		writeCalendar(w, name, calendar, "public, max-age=300")
+	}
+}
+
+// CalendarOr serves the calendar feed for .ics URLs and next for the rest.
+func CalendarOr(calendar http.Handler, next http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if format, _ := r.Context().Value(middleware.URLFormatCtxKey).(string); format == calendarFormat {
+			calendar.ServeHTTP(w, r)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	}
+}
+
+func writeCalendar(w http.ResponseWriter, name string, calendar string, cacheControl string) {
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="`+url.PathEscape(name)+`.ics"`)
+	w.Header().Set("Cache-Control", cacheControl)
+	w.WriteHeader(http.StatusOK)
+
+	_, _ = w.Write([]byte(calendar))
+}
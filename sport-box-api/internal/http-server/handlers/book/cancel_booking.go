@@ -1,66 +1,85 @@
 package book
 
 import (
-	"encoding/json"
 	"net/http"
 	bookgrpc "sport-box-api/internal/clients/booking/grpc"
+	authMW "sport-box-api/internal/http-server/middleware/auth"
+	"sport-box-api/internal/lib/api/idempotency"
 	"sport-box-api/internal/lib/api/response"
-	"strconv"
+	bookerrors "sport-box-api/internal/lib/errors/booking"
 	"strings"
 
 	"github.com/go-chi/chi/v5"
 )
 
-type CancelBookingRequest struct {
-	Email string `json:"email"`
+type CancelResponse struct {
+	Success        bool          `json:"success"`
+	RefundedAmount int64         `json:"refundedAmount"`
+	Balance        int64         `json:"balance"`
+	RefundPolicy   *RefundPolicy `json:"refundPolicy,omitempty"`
 }
 
-type CancelResponse struct {
-	Success        bool  `json:"success"`
-	RefundedAmount int64 `json:"refundedAmount"`
-	Balance        int64 `json:"balance"`
+// RefundPolicy is the rule applied to the refund: full, partial, none or operator.
+type RefundPolicy struct {
+	Name            string `json:"name"`
+	Percent         int64  `json:"percent"`
+	FullRefundUntil string `json:"fullRefundUntil"`
 }
 
 // @Summary Cancel booking
 // @Description Cancel a booking by ID
 // @Tags booking
-// @Accept json
 // @Produce json
-// @Param id path int true "Booking ID"
-// @Param request body CancelBookingRequest true "Cancel booking request"
+// @Param id path string true "Booking ID, legacy numeric IDs are accepted too"
+// @Param Idempotency-Key header string false "Repeating the key returns the refund of the first request"
 // @Success 200 {object} CancelResponse
+// @Success 202 {object} response.Response
 // @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
 // @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 422 {object} response.Response
 // @Failure 500 {object} response.Response
 // @Router /bookings/{id} [delete]
 func Cancel(booker *bookgrpc.Client) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 
-		bookingIDStr := chi.URLParam(r, "id")
-		bookingID, err := strconv.ParseInt(bookingIDStr, 10, 64)
-		if err != nil {
+		bookingID := strings.TrimSpace(chi.URLParam(r, "id"))
+		if bookingID == "" {
 			response.JSON(w, http.StatusBadRequest, response.Response{Error: "invalid booking ID"})
 			return
 		}
 
-		var req CancelBookingRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			response.JSON(w, http.StatusBadRequest, response.Response{Error: "invalid request"})
+		email, ok := authMW.UserEmail(r.Context())
+		if !ok {
+			response.JSON(w, http.StatusUnauthorized, response.Response{Error: "Unauthorized"})
 			return
 		}
 
-		if strings.TrimSpace(req.Email) == "" {
-			response.JSON(w, http.StatusBadRequest, response.Response{Error: "email is required"})
+		idempotencyKey, err := idempotency.Key(r)
+		if err != nil {
+			response.JSON(w, http.StatusBadRequest, response.Response{Error: err.Error()})
 			return
 		}
 
-		refundedAmount, balance, success, err := booker.CancelBooking(r.Context(), req.Email, bookingID)
+		refundedAmount, balance, policy, success, err := booker.CancelBooking(r.Context(), email, bookingID, idempotencyKey)
 		if err != nil {
 			switch {
+			case err.Error() == bookerrors.ErrIdempotencyKeyReused.Error():
+				response.JSON(w, http.StatusUnprocessableEntity, response.Response{Error: "this Idempotency-Key was already used for another request"})
+			case err.Error() == bookerrors.ErrRequestInProgress.Error():
+				response.JSON(w, http.StatusConflict, response.Response{Error: "the request with this Idempotency-Key is still in progress, try again later"})
 			case strings.Contains(err.Error(), "booking not found"):
 				response.JSON(w, http.StatusNotFound, response.Response{Error: "booking not found"})
 			case strings.Contains(err.Error(), "belongs to another user"):
 				response.JSON(w, http.StatusForbidden, response.Response{Error: "this booking belongs to another user"})
+			case strings.Contains(err.Error(), "not active"):
+				response.JSON(w, http.StatusConflict, response.Response{Error: "this booking has already been completed or cancelled"})
+			case err.Error() == bookerrors.ErrRefundPending.Error():
+				response.JSON(w, http.StatusAccepted, response.Response{Error: refundPendingMessage})
+			case strings.Contains(err.Error(), "failed to process refund"):
+				response.JSON(w, http.StatusServiceUnavailable, response.Response{Error: "failed to process refund, the booking is kept, try again later"})
 			default:
 				response.JSON(w, http.StatusInternalServerError, response.Response{Error: "failed to cancel booking"})
 			}
@@ -71,6 +90,7 @@ func Cancel(booker *bookgrpc.Client) http.HandlerFunc {
 			Success:        success,
 			RefundedAmount: refundedAmount,
 			Balance:        balance,
+			RefundPolicy:   toRefundPolicy(policy),
 		})
 	}
 }
\ No newline at end of file
@@ -0,0 +1,117 @@
+package book
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	bookgrpc "sport-box-api/internal/clients/booking/grpc"
+	"sport-box-api/internal/lib/api/response"
+	bookerrors "sport-box-api/internal/lib/errors/booking"
+	"sport-box-api/internal/lib/logger/sl"
+	"strconv"
+
+	bookingv1 "github.com/MKode312/protos/gen/go/booking"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+type Interval struct {
+	StartsAt  string `json:"startsAt"`
+	ExpiresAt string `json:"expiresAt"`
+}
+
+type AvailabilityResponse struct {
+	BoxName  string     `json:"boxName"`
+	Date     string     `json:"date"`
+	TimeZone string     `json:"timeZone"`
+	OpensAt  string     `json:"opensAt"`
+	ClosesAt string     `json:"closesAt"`
+	Free     []Interval `json:"free"`
+	Busy     []Interval `json:"busy"`
+	Slots    []Interval `json:"slots"`
+	response.Response
+}
+
+// @Summary Box availability
+// @Description Free and busy intervals of a box on one day
+// @Tags booking
+// @Produce json
+// @Param name path string true "Box name"
+// @Param date query string true "Day in the box's time zone, YYYY-MM-DD"
+// @Param slot query int false "Slot length in minutes, 60 by default"
+// @Success 200 {object} AvailabilityResponse
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /boxes/{name}/availability [get]
+func GetAvailability(ctx context.Context, log *slog.Logger, client bookgrpc.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.book.GetAvailability"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		date := r.URL.Query().Get("date")
+		if date == "" {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("date is required"))
+			return
+		}
+
+		var slot int64
+		if s := r.URL.Query().Get("slot"); s != "" {
+			var err error
+			slot, err = strconv.ParseInt(s, 10, 64)
+			if err != nil || slot <= 0 {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.Error("slot must be a positive number of minutes"))
+				return
+			}
+		}
+
+		availability, err := client.GetAvailability(ctx, chi.URLParam(r, "name"), date, slot)
+		if err != nil {
+			switch err.Error() {
+			case bookerrors.ErrBoxNotFound.Error():
+				render.Status(r, http.StatusNotFound)
+				render.JSON(w, r, response.Error("Box not found"))
+			case "date must be in YYYY-MM-DD format", "invalid slot length":
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.Error(err.Error()))
+			default:
+				log.Error("failed to get availability", sl.Err(err))
+				render.Status(r, http.StatusInternalServerError)
+				render.JSON(w, r, response.Error("Failed to get availability"))
+			}
+			return
+		}
+
+		render.JSON(w, r, AvailabilityResponse{
+			BoxName:  availability.GetBoxName(),
+			Date:     availability.GetDate(),
+			TimeZone: availability.GetTimeZone(),
+			OpensAt:  availability.GetOpensAt(),
+			ClosesAt: availability.GetClosesAt(),
+			Free:     toIntervals(availability.GetFree()),
+			Busy:     toIntervals(availability.GetBusy()),
+			Slots:    toIntervals(availability.GetSlots()),
+			Response: response.OK(),
+		})
+	}
+}
+
+func toIntervals(intervals []*bookingv1.TimeInterval) []Interval {
+	result := make([]Interval, 0, len(intervals))
+
+	for _, in := range intervals {
+		result = append(result, Interval{
+			StartsAt:  in.GetStartsAt(),
+			ExpiresAt: in.GetExpiresAt(),
+		})
+	}
+
+	return result
+}
@@ -5,36 +5,134 @@ import (
 	"log/slog"
 	"net/http"
 	bookgrpc "sport-box-api/internal/clients/booking/grpc"
+	authMW "sport-box-api/internal/http-server/middleware/auth"
 	"sport-box-api/internal/lib/api/response"
+	"sport-box-api/internal/lib/logger/sl"
+	"strconv"
+	"strings"
+
+	bookingv1 "github.com/MKode312/protos/gen/go/booking"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
 )
 
 type BookingsResponse struct {
-	Bookings []Booking `json:"bookings"`
+	Bookings   []Booking `json:"bookings"`
+	NextCursor string    `json:"nextCursor,omitempty"`
+	response.Response
 }
 
 type Booking struct {
-	ID           int64  `json:"id"`
+	ID           string `json:"id"`
+	LegacyID     int64  `json:"legacyId,omitempty"`
 	BoxName      string `json:"boxName"`
-	TimeStart    string `json:"timeStart"`
-	TimeHrs      int64  `json:"timeHrs"`
-	TimeMins     int64  `json:"timeMins"`
+	StartsAt     string `json:"startsAt"`
+	ExpiresAt    string `json:"expiresAt"`
 	PeopleAmount int64  `json:"peopleAmount"`
+	PricePaid    int64  `json:"pricePaid"`
+	Status       string `json:"status"`
+	CancelledAt  string `json:"cancelledAt,omitempty"`
+	SeriesID     string `json:"seriesId,omitempty"`
+	CheckedInAt  string `json:"checkedInAt,omitempty"`
+	// Owner is the email of whoever booked, set when the caller is a
+	// participant of someone else's booking.
+	Owner string `json:"owner,omitempty"`
+	// Access is only set on active bookings.
+	Access *Access `json:"access,omitempty"`
 }
 
+// @Summary List bookings
+// @Description List the caller's bookings, newest first
+// @Tags booking
+// @Produce json
+// @Param status query string false "active, completed, cancelled or no_show"
+// @Param from query string false "RFC 3339, bookings starting at or after"
+// @Param to query string false "RFC 3339, bookings starting before"
+// @Param limit query int false "Page size"
+// @Param cursor query string false "nextCursor of the previous page"
+// @Success 200 {object} BookingsResponse
+// @Failure 400 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /bookings [get]
 func GetBookings(ctx context.Context, log *slog.Logger, client bookgrpc.Client) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		// Temporary mock data until backend service is ready
-		bookings := []Booking{
-			{
-				ID:           1,
-				BoxName:      "LeninaBox",
-				TimeStart:    "14:00",
-				TimeHrs:      2,
-				TimeMins:     0,
-				PeopleAmount: 4,
-			},
+		const op = "handlers.book.GetBookings"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		email, ok := authMW.UserEmail(r.Context())
+		if !ok {
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, response.Error("Unauthorized"))
+			return
+		}
+
+		query := r.URL.Query()
+
+		var limit int64
+		if l := query.Get("limit"); l != "" {
+			var err error
+			limit, err = strconv.ParseInt(l, 10, 32)
+			if err != nil || limit <= 0 {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.Error("limit must be a positive number"))
+				return
+			}
 		}
 
-		response.JSON(w, http.StatusOK, BookingsResponse{Bookings: bookings})
+		bookings, next, err := client.GetBookings(ctx, email, query.Get("status"), query.Get("from"), query.Get("to"), int32(limit), query.Get("cursor"))
+		if err != nil {
+			log.Error("failed to get bookings", sl.Err(err))
+
+			switch err.Error() {
+			case "invalid status", "invalid cursor", "from must be an RFC 3339 timestamp", "to must be an RFC 3339 timestamp":
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.Error(err.Error()))
+			default:
+				render.Status(r, http.StatusInternalServerError)
+				render.JSON(w, r, response.Error("Failed to get bookings"))
+			}
+			return
+		}
+
+		resp := BookingsResponse{
+			Bookings:   make([]Booking, 0, len(bookings)),
+			NextCursor: next,
+			Response:   response.OK(),
+		}
+
+		for _, b := range bookings {
+			resp.Bookings = append(resp.Bookings, toBooking(b, email))
+		}
+
+		render.JSON(w, r, resp)
 	}
 }
+
+// toBooking maps the booking for the caller, the owner is only shown to
+// participants.
+func toBooking(b *bookingv1.Booking, email string) Booking {
+	booking := Booking{
+		ID:           b.GetUid(),
+		LegacyID:     b.GetId(),
+		BoxName:      b.GetBoxName(),
+		StartsAt:     b.GetStartsAt(),
+		ExpiresAt:    b.GetExpiresAt(),
+		PeopleAmount: b.GetPeopleAmount(),
+		PricePaid:    b.GetPricePaid(),
+		Status:       b.GetStatus(),
+		CancelledAt:  b.GetCancelledAt(),
+		SeriesID:     b.GetSeriesUid(),
+		CheckedInAt:  b.GetCheckedInAt(),
+		Access:       toAccess(b.GetAccess()),
+	}
+
+	if !strings.EqualFold(b.GetEmail(), email) {
+		booking.Owner = b.GetEmail()
+	}
+
+	return booking
+}
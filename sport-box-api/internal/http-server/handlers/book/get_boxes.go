@@ -6,39 +6,127 @@ import (
 	"net/http"
 	bookgrpc "sport-box-api/internal/clients/booking/grpc"
 	"sport-box-api/internal/lib/api/response"
+	bookerrors "sport-box-api/internal/lib/errors/booking"
+	"sport-box-api/internal/lib/logger/sl"
+
+	bookingv1 "github.com/MKode312/protos/gen/go/booking"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
 )
 
 type BoxesResponse struct {
 	Boxes []Box `json:"boxes"`
+	response.Response
+}
+
+type BoxResponse struct {
+	Box Box `json:"box"`
+	response.Response
 }
 
 type Box struct {
-	Name         string  `json:"name"`
-	PricePerHour float64 `json:"pricePerHour"`
-	Available    bool    `json:"available"`
+	Name           string `json:"name"`
+	Address        string `json:"address"`
+	Description    string `json:"description"`
+	Capacity       int64  `json:"capacity"`
+	PricePerHour   int64  `json:"pricePerHour"`
+	TimeZone       string `json:"timeZone"`
+	Available      bool   `json:"available"`
+	OpensAt        string `json:"opensAt"`
+	ClosesAt       string `json:"closesAt"`
+	SharedSessions bool   `json:"sharedSessions"`
+	VenueID        int64  `json:"venueId,omitempty"`
 }
 
+// @Summary List boxes
+// @Description List the boxes open for booking
+// @Tags booking
+// @Produce json
+// @Success 200 {object} BoxesResponse
+// @Failure 500 {object} response.Response
+// @Router /boxes [get]
 func GetBoxes(ctx context.Context, log *slog.Logger, client bookgrpc.Client) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		// Temporary mock data until backend service is ready
-		boxes := []Box{
-			{
-				Name:         "LeninaBox",
-				PricePerHour: 600.0,
-				Available:    true,
-			},
-			{
-				Name:         "SibirskayaBox",
-				PricePerHour: 600.0,
-				Available:    true,
-			},
-			{
-				Name:         "LunacharskogoBox",
-				PricePerHour: 600.0,
-				Available:    false,
-			},
+		const op = "handlers.book.GetBoxes"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		boxes, err := client.GetBoxes(ctx)
+		if err != nil {
+			log.Error("failed to get boxes", sl.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("Failed to get boxes"))
+			return
 		}
 
-		response.JSON(w, http.StatusOK, BoxesResponse{Boxes: boxes})
+		resp := BoxesResponse{
+			Boxes:    make([]Box, 0, len(boxes)),
+			Response: response.OK(),
+		}
+
+		for _, box := range boxes {
+			resp.Boxes = append(resp.Boxes, toBox(box))
+		}
+
+		render.JSON(w, r, resp)
+	}
+}
+
+// @Summary Get box
+// @Description Get a single box by name
+// @Tags booking
+// @Produce json
+// @Param name path string true "Box name"
+// @Success 200 {object} BoxResponse
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /boxes/{name} [get]
+func GetBox(ctx context.Context, log *slog.Logger, client bookgrpc.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.book.GetBox"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		box, err := client.GetBox(ctx, chi.URLParam(r, "name"))
+		if err != nil {
+			if err.Error() == bookerrors.ErrBoxNotFound.Error() {
+				render.Status(r, http.StatusNotFound)
+				render.JSON(w, r, response.Error("Box not found"))
+				return
+			}
+
+			log.Error("failed to get box", sl.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("Failed to get box"))
+			return
+		}
+
+		render.JSON(w, r, BoxResponse{
+			Box:      toBox(box),
+			Response: response.OK(),
+		})
+	}
+}
+
+func toBox(box *bookingv1.Box) Box {
+	return Box{
+		Name:           box.GetName(),
+		Address:        box.GetAddress(),
+		Description:    box.GetDescription(),
+		Capacity:       box.GetCapacity(),
+		PricePerHour:   box.GetPricePerHour(),
+		TimeZone:       box.GetTimeZone(),
+		Available:      box.GetActive(),
+		OpensAt:        box.GetOpensAt(),
+		ClosesAt:       box.GetClosesAt(),
+		SharedSessions: box.GetSharedSessions(),
+		VenueID:        box.GetVenueId(),
 	}
 }
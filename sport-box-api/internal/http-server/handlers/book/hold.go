@@ -0,0 +1,119 @@
+package book
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	bookgrpc "sport-box-api/internal/clients/booking/grpc"
+	authMW "sport-box-api/internal/http-server/middleware/auth"
+	"sport-box-api/internal/lib/api/response"
+	bookerrors "sport-box-api/internal/lib/errors/booking"
+	"sport-box-api/internal/lib/logger/sl"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+// HoldResponse is a slot reserved until HeldUntil. POST /book with the HoldID
+// books it, nothing is charged before that.
+type HoldResponse struct {
+	HoldID    string `json:"holdId"`
+	HeldUntil string `json:"heldUntil"`
+	Price     *Price `json:"price"`
+	response.Response
+}
+
+// @Summary Hold a slot
+// @Description Reserve a slot for a few minutes during checkout, book it with the holdId
+// @Tags booking
+// @Accept json
+// @Produce json
+// @Param request body Slot true "Same slot as POST /book"
+// @Success 200 {object} HoldResponse
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /book/hold [post]
+func Hold(ctx context.Context, log *slog.Logger, client bookgrpc.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.book.Hold"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		email, ok := authMW.UserEmail(r.Context())
+		if !ok {
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, response.Error("Unauthorized"))
+			return
+		}
+
+		var req Slot
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("Failed to decode request"))
+
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Error("invalid request", sl.Err(err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ValidationError(validateErr))
+
+			return
+		}
+
+		hold, err := client.HoldSlot(ctx, email, req.BoxName, req.PeopleAmount, req.TimeStart, req.TimeHrs, req.TimeMins)
+		if err != nil {
+			log.Error("failed to hold the slot", sl.Err(err))
+
+			switch err.Error() {
+			case bookerrors.ErrBookingInPast.Error(), bookerrors.ErrInvalidTimeStart.Error(), bookerrors.ErrCapacityExceeded.Error(), bookerrors.ErrBookingTooLong.Error():
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.Error(err.Error()))
+			case bookerrors.ErrAlreadyBooked.Error():
+				render.Status(r, http.StatusConflict)
+				render.JSON(w, r, response.Error("This sport box is already booked for this time, try to book it later"))
+			case bookerrors.ErrOutsideOpeningHours.Error():
+				render.Status(r, http.StatusConflict)
+				render.JSON(w, r, response.Error(closedMessage))
+			case bookerrors.ErrMaintenance.Error():
+				render.Status(r, http.StatusConflict)
+				render.JSON(w, r, response.Error(maintenanceMessage))
+			case bookerrors.ErrTooManyHolds.Error():
+				render.Status(r, http.StatusTooManyRequests)
+				render.JSON(w, r, response.Error("You hold too many slots already, book or wait for one of them to expire"))
+			case "boxName not found":
+				render.Status(r, http.StatusNotFound)
+				render.JSON(w, r, response.Error(bookerrors.ErrBoxNotFound.Error()))
+			default:
+				render.Status(r, http.StatusInternalServerError)
+				render.JSON(w, r, response.Error("Failed to hold the slot"))
+			}
+
+			return
+		}
+
+		log.Info("slot held", slog.String("holdID", hold.GetHoldId()))
+
+		render.JSON(w, r, HoldResponse{
+			HoldID:    hold.GetHoldId(),
+			HeldUntil: hold.GetHeldUntil(),
+			Price:     toPrice(hold.GetPrice()),
+			Response:  response.OK(),
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package book
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	bookgrpc "sport-box-api/internal/clients/booking/grpc"
+	authMW "sport-box-api/internal/http-server/middleware/auth"
+	"sport-box-api/internal/lib/api/response"
+	bookerrors "sport-box-api/internal/lib/errors/booking"
+	"sport-box-api/internal/lib/logger/sl"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+// NotificationSettingsRequest sets the language of booking confirmations and
+// reminders, "ru" or "en".
+type NotificationSettingsRequest struct {
+	Locale string `json:"locale" validate:"required"`
+}
+
+type NotificationSettingsResponse struct {
+	Locale string `json:"locale"`
+	response.Response
+}
+
+// @Summary Notification settings
+// @Description Set the language of the caller's booking notifications
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param request body NotificationSettingsRequest true "Notification settings"
+// @Success 200 {object} NotificationSettingsResponse
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /notifications/settings [put]
+func SetNotificationSettings(ctx context.Context, log *slog.Logger, client bookgrpc.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.book.SetNotificationSettings"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		email, ok := authMW.UserEmail(r.Context())
+		if !ok {
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, response.Error("Unauthorized"))
+			return
+		}
+
+		var req NotificationSettingsRequest
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("Failed to decode request"))
+
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Error("invalid request", sl.Err(err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ValidationError(validateErr))
+
+			return
+		}
+
+		locale, err := client.SetNotificationSettings(ctx, email, req.Locale)
+		if err != nil {
+			log.Error("failed to save notification settings", sl.Err(err))
+
+			if err.Error() == bookerrors.ErrInvalidLocale.Error() {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.Error(err.Error()))
+				return
+			}
+
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("Failed to save notification settings"))
+
+			return
+		}
+
+		render.JSON(w, r, NotificationSettingsResponse{
+			Locale:   locale,
+			Response: response.OK(),
+		})
+	}
+}
@@ -0,0 +1,340 @@
+package book
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	bookgrpc "sport-box-api/internal/clients/booking/grpc"
+	authMW "sport-box-api/internal/http-server/middleware/auth"
+	"sport-box-api/internal/lib/api/response"
+	bookerrors "sport-box-api/internal/lib/errors/booking"
+	"sport-box-api/internal/lib/logger/sl"
+	"strings"
+
+	bookingv1 "github.com/MKode312/protos/gen/go/booking"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type InviteRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type Participant struct {
+	BookingID string `json:"bookingId"`
+	Email     string `json:"email"`
+	// Status is invited, accepted or declined.
+	Status      string `json:"status"`
+	InvitedAt   string `json:"invitedAt"`
+	RespondedAt string `json:"respondedAt,omitempty"`
+}
+
+type ParticipantResponse struct {
+	Participant Participant `json:"participant"`
+	response.Response
+}
+
+type ParticipantsResponse struct {
+	Participants []Participant `json:"participants"`
+	response.Response
+}
+
+type Invitation struct {
+	Booking   Booking `json:"booking"`
+	InvitedAt string  `json:"invitedAt"`
+}
+
+type InvitationsResponse struct {
+	Invitations []Invitation `json:"invitations"`
+	response.Response
+}
+
+// @Summary Invite a participant
+// @Description Invite someone by email to an upcoming booking of the caller, the invitee is notified
+// @Tags participants
+// @Accept json
+// @Produce json
+// @Param id path string true "Booking ID"
+// @Param request body InviteRequest true "Invite request"
+// @Success 200 {object} ParticipantResponse
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /bookings/{id}/participants [post]
+func InviteParticipant(ctx context.Context, log *slog.Logger, client bookgrpc.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.book.InviteParticipant"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		email, ok := authMW.UserEmail(r.Context())
+		if !ok {
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, response.Error("Unauthorized"))
+			return
+		}
+
+		bookingID := strings.TrimSpace(chi.URLParam(r, "id"))
+		if bookingID == "" {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("invalid booking ID"))
+			return
+		}
+
+		var req InviteRequest
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("Failed to decode request"))
+
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Error("invalid request", sl.Err(err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ValidationError(validateErr))
+
+			return
+		}
+
+		participant, err := client.InviteParticipant(ctx, email, bookingID, req.Email)
+		if err != nil {
+			log.Error("failed to invite the participant", sl.Err(err))
+
+			switch err.Error() {
+			case bookerrors.ErrBookingNotFound.Error():
+				render.Status(r, http.StatusNotFound)
+			case bookerrors.ErrNotYourBooking.Error():
+				render.Status(r, http.StatusForbidden)
+			case bookerrors.ErrInviteOwner.Error():
+				render.Status(r, http.StatusBadRequest)
+			case bookerrors.ErrAlreadyInvited.Error(), bookerrors.ErrTooManyParticipants.Error(),
+				bookerrors.ErrBookingNotActive.Error(), bookerrors.ErrBookingStarted.Error():
+				render.Status(r, http.StatusConflict)
+			default:
+				render.Status(r, http.StatusInternalServerError)
+				render.JSON(w, r, response.Error("Failed to invite the participant"))
+				return
+			}
+
+			render.JSON(w, r, response.Error(err.Error()))
+			return
+		}
+
+		render.JSON(w, r, ParticipantResponse{
+			Participant: toParticipant(participant),
+			Response:    response.OK(),
+		})
+	}
+}
+
+// @Summary List participants
+// @Description List the people invited to a booking, for its owner and participants
+// @Tags participants
+// @Produce json
+// @Param id path string true "Booking ID"
+// @Success 200 {object} ParticipantsResponse
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /bookings/{id}/participants [get]
+func GetParticipants(ctx context.Context, log *slog.Logger, client bookgrpc.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.book.GetParticipants"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		email, ok := authMW.UserEmail(r.Context())
+		if !ok {
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, response.Error("Unauthorized"))
+			return
+		}
+
+		bookingID := strings.TrimSpace(chi.URLParam(r, "id"))
+		if bookingID == "" {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("invalid booking ID"))
+			return
+		}
+
+		participants, err := client.GetParticipants(ctx, email, bookingID)
+		if err != nil {
+			log.Error("failed to get participants", sl.Err(err))
+
+			switch err.Error() {
+			case bookerrors.ErrBookingNotFound.Error():
+				render.Status(r, http.StatusNotFound)
+				render.JSON(w, r, response.Error(err.Error()))
+			case bookerrors.ErrNotYourBooking.Error():
+				render.Status(r, http.StatusForbidden)
+				render.JSON(w, r, response.Error(err.Error()))
+			default:
+				render.Status(r, http.StatusInternalServerError)
+				render.JSON(w, r, response.Error("Failed to get participants"))
+			}
+			return
+		}
+
+		resp := ParticipantsResponse{
+			Participants: make([]Participant, 0, len(participants)),
+			Response:     response.OK(),
+		}
+
+		for _, participant := range participants {
+			resp.Participants = append(resp.Participants, toParticipant(participant))
+		}
+
+		render.JSON(w, r, resp)
+	}
+}
+
+// @Summary List invitations
+// @Description List the upcoming bookings the caller is invited to and has not answered yet, the soonest first
+// @Tags participants
+// @Produce json
+// @Success 200 {object} InvitationsResponse
+// @Failure 401 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /invitations [get]
+func GetInvitations(ctx context.Context, log *slog.Logger, client bookgrpc.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.book.GetInvitations"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		email, ok := authMW.UserEmail(r.Context())
+		if !ok {
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, response.Error("Unauthorized"))
+			return
+		}
+
+		invitations, err := client.GetInvitations(ctx, email)
+		if err != nil {
+			log.Error("failed to get invitations", sl.Err(err))
+
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("Failed to get invitations"))
+			return
+		}
+
+		resp := InvitationsResponse{
+			Invitations: make([]Invitation, 0, len(invitations)),
+			Response:    response.OK(),
+		}
+
+		for _, invitation := range invitations {
+			resp.Invitations = append(resp.Invitations, Invitation{
+				Booking:   toBooking(invitation.GetBooking(), email),
+				InvitedAt: invitation.GetInvitedAt(),
+			})
+		}
+
+		render.JSON(w, r, resp)
+	}
+}
+
+// @Summary Accept an invitation
+// @Description Join a booking the caller is invited to, it then shows up in their bookings
+// @Tags participants
+// @Produce json
+// @Param id path string true "Booking ID"
+// @Success 200 {object} ParticipantResponse
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /invitations/{id}/accept [post]
+func AcceptInvitation(ctx context.Context, log *slog.Logger, client bookgrpc.Client) http.HandlerFunc {
+	return respondToInvitation(ctx, log, client, "handlers.book.AcceptInvitation", true)
+}
+
+// @Summary Decline an invitation
+// @Description Decline an invitation or leave a booking joined before
+// @Tags participants
+// @Produce json
+// @Param id path string true "Booking ID"
+// @Success 200 {object} ParticipantResponse
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /invitations/{id}/decline [post]
+func DeclineInvitation(ctx context.Context, log *slog.Logger, client bookgrpc.Client) http.HandlerFunc {
+	return respondToInvitation(ctx, log, client, "handlers.book.DeclineInvitation", false)
+}
+
+func respondToInvitation(ctx context.Context, log *slog.Logger, client bookgrpc.Client, op string, accept bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		email, ok := authMW.UserEmail(r.Context())
+		if !ok {
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, response.Error("Unauthorized"))
+			return
+		}
+
+		bookingID := strings.TrimSpace(chi.URLParam(r, "id"))
+		if bookingID == "" {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("invalid booking ID"))
+			return
+		}
+
+		participant, err := client.RespondToInvitation(ctx, email, bookingID, accept)
+		if err != nil {
+			log.Error("failed to answer the invitation", sl.Err(err))
+
+			switch err.Error() {
+			case bookerrors.ErrInvitationNotFound.Error():
+				render.Status(r, http.StatusNotFound)
+			case bookerrors.ErrInvitationDeclined.Error(), bookerrors.ErrBookingNotActive.Error(), bookerrors.ErrBookingStarted.Error():
+				render.Status(r, http.StatusConflict)
+			default:
+				render.Status(r, http.StatusInternalServerError)
+				render.JSON(w, r, response.Error("Failed to answer the invitation"))
+				return
+			}
+
+			render.JSON(w, r, response.Error(err.Error()))
+			return
+		}
+
+		render.JSON(w, r, ParticipantResponse{
+			Participant: toParticipant(participant),
+			Response:    response.OK(),
+		})
+	}
+}
+
+func toParticipant(participant *bookingv1.Participant) Participant {
+	return Participant{
+		BookingID:   participant.GetBookingUid(),
+		Email:       participant.GetEmail(),
+		Status:      participant.GetStatus(),
+		InvitedAt:   participant.GetInvitedAt(),
+		RespondedAt: participant.GetRespondedAt(),
+	}
+}
@@ -0,0 +1,55 @@
+package book
+
+import bookingv1 "github.com/MKode312/protos/gen/go/booking"
+
+// Price is the itemized cost of a booking. Percent is the rate of a line
+// relative to the base price per hour. Discount is taken off by PromoCode.
+type Price struct {
+	PricePerHour int64       `json:"pricePerHour"`
+	PeopleAmount int64       `json:"peopleAmount"`
+	Lines        []PriceLine `json:"lines"`
+	Subtotal     int64       `json:"subtotal"`
+	SurgePercent int64       `json:"surgePercent"`
+	MinCharge    int64       `json:"minCharge"`
+	PromoCode    string      `json:"promoCode,omitempty"`
+	Discount     int64       `json:"discount"`
+	Total        int64       `json:"total"`
+}
+
+type PriceLine struct {
+	Name      string `json:"name"`
+	StartsAt  string `json:"startsAt"`
+	ExpiresAt string `json:"expiresAt"`
+	Percent   int64  `json:"percent"`
+	Amount    int64  `json:"amount"`
+}
+
+func toPrice(price *bookingv1.Price) *Price {
+	if price == nil {
+		return nil
+	}
+
+	result := &Price{
+		PricePerHour: price.GetPricePerHour(),
+		PeopleAmount: price.GetPeopleAmount(),
+		Lines:        make([]PriceLine, 0, len(price.GetLines())),
+		Subtotal:     price.GetSubtotal(),
+		SurgePercent: price.GetSurgePercent(),
+		MinCharge:    price.GetMinCharge(),
+		PromoCode:    price.GetPromoCode(),
+		Discount:     price.GetDiscount(),
+		Total:        price.GetTotal(),
+	}
+
+	for _, line := range price.GetLines() {
+		result.Lines = append(result.Lines, PriceLine{
+			Name:      line.GetName(),
+			StartsAt:  line.GetStartsAt(),
+			ExpiresAt: line.GetExpiresAt(),
+			Percent:   line.GetPercent(),
+			Amount:    line.GetAmount(),
+		})
+	}
+
+	return result
+}
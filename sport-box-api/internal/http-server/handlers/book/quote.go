@@ -0,0 +1,123 @@
+package book
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	bookgrpc "sport-box-api/internal/clients/booking/grpc"
+	authMW "sport-box-api/internal/http-server/middleware/auth"
+	"sport-box-api/internal/lib/api/response"
+	bookerrors "sport-box-api/internal/lib/errors/booking"
+	"sport-box-api/internal/lib/logger/sl"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+// QuoteRequest prices the slot for the caller, less the discount of the
+// optional PromoCode.
+type QuoteRequest struct {
+	Slot
+	PromoCode string `json:"promoCode"`
+}
+
+// QuoteResponse is the price of a booking that has not been made. Balance is
+// -1 when the wallet could not be read.
+type QuoteResponse struct {
+	Price       *Price `json:"price"`
+	Balance     int64  `json:"balance"`
+	EnoughFunds bool   `json:"enoughFunds"`
+	SlotFree    bool   `json:"slotFree"`
+	response.Response
+}
+
+// @Summary Quote a booking
+// @Description Price a booking, check the slot and the wallet without booking or paying
+// @Tags booking
+// @Accept json
+// @Produce json
+// @Param request body QuoteRequest true "Same slot as POST /book"
+// @Success 200 {object} QuoteResponse
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 422 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /book/quote [post]
+func Quote(ctx context.Context, log *slog.Logger, client bookgrpc.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.book.Quote"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		email, ok := authMW.UserEmail(r.Context())
+		if !ok {
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, response.Error("Unauthorized"))
+			return
+		}
+
+		var req QuoteRequest
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("Failed to decode request"))
+
+			return
+		}
+
+		if err := validator.New().Struct(req.Slot); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Error("invalid request", sl.Err(err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ValidationError(validateErr))
+
+			return
+		}
+
+		quote, err := client.QuotePrice(ctx, email, req.BoxName, req.PeopleAmount, req.TimeStart, req.TimeHrs, req.TimeMins, req.PromoCode)
+		if err != nil {
+			log.Error("failed to quote the price", sl.Err(err))
+
+			switch err.Error() {
+			case bookerrors.ErrBookingInPast.Error(), bookerrors.ErrInvalidTimeStart.Error(), bookerrors.ErrCapacityExceeded.Error(), bookerrors.ErrBookingTooLong.Error():
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.Error(err.Error()))
+			case bookerrors.ErrPromoCodeExpired.Error(), bookerrors.ErrPromoCodeNotApplicable.Error():
+				render.Status(r, http.StatusUnprocessableEntity)
+				render.JSON(w, r, response.Error(err.Error()))
+			case bookerrors.ErrPromoCodeNotFound.Error():
+				render.Status(r, http.StatusNotFound)
+				render.JSON(w, r, response.Error(err.Error()))
+			case bookerrors.ErrPromoCodeExhausted.Error():
+				render.Status(r, http.StatusConflict)
+				render.JSON(w, r, response.Error(err.Error()))
+			case "boxName not found":
+				render.Status(r, http.StatusNotFound)
+				render.JSON(w, r, response.Error(bookerrors.ErrBoxNotFound.Error()))
+			default:
+				render.Status(r, http.StatusInternalServerError)
+				render.JSON(w, r, response.Error("Failed to quote the price"))
+			}
+
+			return
+		}
+
+		render.JSON(w, r, QuoteResponse{
+			Price:       toPrice(quote.GetPrice()),
+			Balance:     quote.GetBalance(),
+			EnoughFunds: quote.GetEnoughFunds(),
+			SlotFree:    quote.GetSlotFree(),
+			Response:    response.OK(),
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package book
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	bookgrpc "sport-box-api/internal/clients/booking/grpc"
+	authMW "sport-box-api/internal/http-server/middleware/auth"
+	"sport-box-api/internal/lib/api/response"
+	bookerrors "sport-box-api/internal/lib/errors/booking"
+	"sport-box-api/internal/lib/logger/sl"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+// RescheduleRequest moves a booking. Empty fields keep the current box, start
+// time or duration, TimeStart is read like in Slot.
+type RescheduleRequest struct {
+	BoxName   string `json:"boxName"`
+	TimeStart string `json:"timeStart"`
+	TimeHrs   int64  `json:"timeHrs"`
+	TimeMins  int64  `json:"timeMins"`
+}
+
+type RescheduleResponse struct {
+	Booking Booking `json:"booking"`
+	Price   *Price  `json:"price,omitempty"`
+	// ChargedAmount or RefundedAmount is the price difference settled with the wallet.
+	ChargedAmount  int64 `json:"chargedAmount"`
+	RefundedAmount int64 `json:"refundedAmount"`
+	Balance        int64 `json:"balance"`
+	response.Response
+}
+
+// @Summary Reschedule booking
+// @Description Move a booking that has not started yet to another time or box, the price difference is charged or refunded
+// @Tags booking
+// @Accept json
+// @Produce json
+// @Param id path string true "Booking ID, legacy numeric IDs are accepted too"
+// @Param request body RescheduleRequest true "Reschedule request"
+// @Success 200 {object} RescheduleResponse
+// @Success 202 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 402 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /bookings/{id} [patch]
+func Reschedule(ctx context.Context, log *slog.Logger, client bookgrpc.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.book.Reschedule"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		email, ok := authMW.UserEmail(r.Context())
+		if !ok {
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, response.Error("Unauthorized"))
+			return
+		}
+
+		bookingID := strings.TrimSpace(chi.URLParam(r, "id"))
+		if bookingID == "" {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("invalid booking ID"))
+			return
+		}
+
+		var req RescheduleRequest
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("Failed to decode request"))
+
+			return
+		}
+
+		moved, err := client.RescheduleBooking(ctx, email, bookingID, req.BoxName, req.TimeStart, req.TimeHrs, req.TimeMins)
+		if err != nil {
+			log.Error("failed to reschedule booking", sl.Err(err))
+
+			switch err.Error() {
+			case bookerrors.ErrBookingInPast.Error(), bookerrors.ErrInvalidTimeStart.Error(), bookerrors.ErrCapacityExceeded.Error(), bookerrors.ErrBookingTooLong.Error(),
+				"invalid time", "box is not available for booking":
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.Error(err.Error()))
+			case bookerrors.ErrBookingNotFound.Error():
+				render.Status(r, http.StatusNotFound)
+				render.JSON(w, r, response.Error(err.Error()))
+			case "boxName not found":
+				render.Status(r, http.StatusNotFound)
+				render.JSON(w, r, response.Error(bookerrors.ErrBoxNotFound.Error()))
+			case "this booking belongs to another user":
+				render.Status(r, http.StatusForbidden)
+				render.JSON(w, r, response.Error(err.Error()))
+			case bookerrors.ErrAlreadyBooked.Error(), bookerrors.ErrBookingNotActive.Error(), bookerrors.ErrBookingStarted.Error(),
+				bookerrors.ErrCardNotFound.Error():
+				render.Status(r, http.StatusConflict)
+				render.JSON(w, r, response.Error(err.Error()))
+			case bookerrors.ErrOutsideOpeningHours.Error():
+				render.Status(r, http.StatusConflict)
+				render.JSON(w, r, response.Error(closedMessage))
+			case bookerrors.ErrMaintenance.Error():
+				render.Status(r, http.StatusConflict)
+				render.JSON(w, r, response.Error(maintenanceMessage))
+			case bookerrors.ErrNotEnoughFundsToPay.Error():
+				render.Status(r, http.StatusPaymentRequired)
+				render.JSON(w, r, response.Error(err.Error()))
+			case bookerrors.ErrPaymentPending.Error():
+				render.Status(r, http.StatusAccepted)
+				render.JSON(w, r, response.Error(paymentPendingMessage))
+			default:
+				render.Status(r, http.StatusInternalServerError)
+				render.JSON(w, r, response.Error("Failed to reschedule booking"))
+			}
+
+			return
+		}
+
+		b := moved.GetBooking()
+
+		render.JSON(w, r, RescheduleResponse{
+			Booking: Booking{
+				ID:           b.GetUid(),
+				LegacyID:     b.GetId(),
+				BoxName:      b.GetBoxName(),
+				StartsAt:     b.GetStartsAt(),
+				ExpiresAt:    b.GetExpiresAt(),
+				PeopleAmount: b.GetPeopleAmount(),
+				PricePaid:    b.GetPricePaid(),
+				Status:       b.GetStatus(),
+				SeriesID:     b.GetSeriesUid(),
+				CheckedInAt:  b.GetCheckedInAt(),
+				Access:       toAccess(b.GetAccess()),
+			},
+			Price:          toPrice(moved.GetPrice()),
+			ChargedAmount:  moved.GetChargedAmount(),
+			RefundedAmount: moved.GetRefundedAmount(),
+			Balance:        moved.GetBalance(),
+			Response:       response.OK(),
+		})
+	}
+}
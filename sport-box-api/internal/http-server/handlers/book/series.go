@@ -0,0 +1,228 @@
+package book
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	bookgrpc "sport-box-api/internal/clients/booking/grpc"
+	authMW "sport-box-api/internal/http-server/middleware/auth"
+	"sport-box-api/internal/lib/api/response"
+	bookerrors "sport-box-api/internal/lib/errors/booking"
+	"sport-box-api/internal/lib/logger/sl"
+	"strings"
+
+	bookingv1 "github.com/MKode312/protos/gen/go/booking"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+// SeriesRequest describes a recurring booking of the caller. The fields of Slot
+// describe the first occurrence, RRule repeats it, e.g. "FREQ=WEEKLY;BYDAY=TU;COUNT=10".
+// Payment is "per_occurrence" (the default) or "upfront".
+type SeriesRequest struct {
+	Slot
+	RRule   string `json:"rrule" validate:"required"`
+	Payment string `json:"payment"`
+}
+
+type Occurrence struct {
+	StartsAt  string `json:"startsAt"`
+	ExpiresAt string `json:"expiresAt"`
+	// Status is booked, conflict, payment_failed, payment_pending or failed.
+	Status    string `json:"status"`
+	BookingID string `json:"bookingId,omitempty"`
+	Price     *Price `json:"price,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+type SeriesResponse struct {
+	SeriesID    string       `json:"seriesId"`
+	RRule       string       `json:"rrule"`
+	Occurrences []Occurrence `json:"occurrences"`
+	Balance     int64        `json:"balance"`
+	response.Response
+}
+
+type SeriesCancellation struct {
+	BookingID      string        `json:"bookingId"`
+	StartsAt       string        `json:"startsAt"`
+	RefundedAmount int64         `json:"refundedAmount"`
+	RefundPolicy   *RefundPolicy `json:"refundPolicy,omitempty"`
+	Error          string        `json:"error,omitempty"`
+}
+
+type CancelSeriesResponse struct {
+	Cancellations  []SeriesCancellation `json:"cancellations"`
+	RefundedAmount int64                `json:"refundedAmount"`
+	Balance        int64                `json:"balance"`
+}
+
+// @Summary Book a recurring series
+// @Description Book every occurrence of a daily or weekly series, conflicts are reported per occurrence
+// @Tags booking
+// @Accept json
+// @Produce json
+// @Param request body SeriesRequest true "Series request"
+// @Success 200 {object} SeriesResponse
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /book/series [post]
+func NewSeries(ctx context.Context, log *slog.Logger, client bookgrpc.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.book.NewSeries"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		email, ok := authMW.UserEmail(r.Context())
+		if !ok {
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, response.Error("Unauthorized"))
+			return
+		}
+
+		var req SeriesRequest
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("Failed to decode request"))
+
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Error("invalid request", sl.Err(err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ValidationError(validateErr))
+
+			return
+		}
+
+		series, err := client.BookSeries(ctx, email, req.BoxName, req.PeopleAmount, req.TimeStart, req.TimeHrs, req.TimeMins, req.RRule, req.Payment)
+		if err != nil {
+			log.Error("failed to book a series", sl.Err(err))
+
+			switch err.Error() {
+			case bookerrors.ErrBookingInPast.Error(), bookerrors.ErrInvalidTimeStart.Error(), bookerrors.ErrCapacityExceeded.Error(), bookerrors.ErrBookingTooLong.Error(),
+				bookerrors.ErrInvalidRule.Error(), bookerrors.ErrRuleWithoutEnd.Error(), bookerrors.ErrTooManyOccurrences.Error(),
+				bookerrors.ErrInvalidPayment.Error():
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.Error(err.Error()))
+			case "boxName not found":
+				render.Status(r, http.StatusNotFound)
+				render.JSON(w, r, response.Error(bookerrors.ErrBoxNotFound.Error()))
+			default:
+				render.Status(r, http.StatusInternalServerError)
+				render.JSON(w, r, response.Error("Failed to book a series"))
+			}
+
+			return
+		}
+
+		resp := SeriesResponse{
+			SeriesID:    series.GetSeriesUid(),
+			RRule:       series.GetRrule(),
+			Occurrences: make([]Occurrence, 0, len(series.GetOccurrences())),
+			Balance:     series.GetBalance(),
+			Response:    response.OK(),
+		}
+
+		for _, o := range series.GetOccurrences() {
+			resp.Occurrences = append(resp.Occurrences, Occurrence{
+				StartsAt:  o.GetStartsAt(),
+				ExpiresAt: o.GetExpiresAt(),
+				Status:    o.GetStatus(),
+				BookingID: o.GetBookingUid(),
+				Price:     toPrice(o.GetPrice()),
+				Error:     o.GetError(),
+			})
+		}
+
+		render.JSON(w, r, resp)
+	}
+}
+
+// @Summary Cancel a series
+// @Description Cancel every booking of a series that has not ended yet, single occurrences are cancelled with DELETE /bookings/{id}
+// @Tags booking
+// @Produce json
+// @Param id path string true "Series ID"
+// @Success 200 {object} CancelSeriesResponse
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /series/{id} [delete]
+func CancelSeries(booker *bookgrpc.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		seriesID := strings.TrimSpace(chi.URLParam(r, "id"))
+		if seriesID == "" {
+			response.JSON(w, http.StatusBadRequest, response.Response{Error: "invalid series ID"})
+			return
+		}
+
+		email, ok := authMW.UserEmail(r.Context())
+		if !ok {
+			response.JSON(w, http.StatusUnauthorized, response.Response{Error: "Unauthorized"})
+			return
+		}
+
+		series, err := booker.CancelSeries(r.Context(), email, seriesID)
+		if err != nil {
+			switch {
+			case strings.Contains(err.Error(), "series not found"):
+				response.JSON(w, http.StatusNotFound, response.Response{Error: "series not found"})
+			case strings.Contains(err.Error(), "belongs to another user"):
+				response.JSON(w, http.StatusForbidden, response.Response{Error: "this series belongs to another user"})
+			case strings.Contains(err.Error(), "not active"):
+				response.JSON(w, http.StatusConflict, response.Response{Error: "this series has no bookings left to cancel"})
+			default:
+				response.JSON(w, http.StatusInternalServerError, response.Response{Error: "failed to cancel series"})
+			}
+			return
+		}
+
+		resp := CancelSeriesResponse{
+			Cancellations:  make([]SeriesCancellation, 0, len(series.GetCancellations())),
+			RefundedAmount: series.GetRefundedAmount(),
+			Balance:        series.GetBalance(),
+		}
+
+		for _, c := range series.GetCancellations() {
+			resp.Cancellations = append(resp.Cancellations, SeriesCancellation{
+				BookingID:      c.GetBookingUid(),
+				StartsAt:       c.GetStartsAt(),
+				RefundedAmount: c.GetRefundedAmount(),
+				RefundPolicy:   toRefundPolicy(c.GetRefundPolicy()),
+				Error:          c.GetError(),
+			})
+		}
+
+		response.JSON(w, http.StatusOK, resp)
+	}
+}
+
+func toRefundPolicy(policy *bookingv1.RefundPolicy) *RefundPolicy {
+	if policy == nil {
+		return nil
+	}
+
+	return &RefundPolicy{
+		Name:            policy.GetName(),
+		Percent:         policy.GetPercent(),
+		FullRefundUntil: policy.GetFullRefundUntil(),
+	}
+}
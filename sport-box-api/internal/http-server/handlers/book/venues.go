@@ -0,0 +1,168 @@
+package book
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	bookgrpc "sport-box-api/internal/clients/booking/grpc"
+	"sport-box-api/internal/lib/api/response"
+	"sport-box-api/internal/lib/logger/sl"
+	"strconv"
+
+	bookingv1 "github.com/MKode312/protos/gen/go/booking"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+type VenuesResponse struct {
+	Venues []NearbyVenue `json:"venues"`
+	response.Response
+}
+
+type Venue struct {
+	ID        int64   `json:"id"`
+	Name      string  `json:"name"`
+	Address   string  `json:"address"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	TimeZone  string  `json:"timeZone"`
+	Phone     string  `json:"phone,omitempty"`
+	Email     string  `json:"email,omitempty"`
+	Website   string  `json:"website,omitempty"`
+}
+
+type NearbyVenue struct {
+	Venue
+	DistanceKm float64         `json:"distanceKm"`
+	Boxes      []BoxDaySummary `json:"boxes"`
+}
+
+// BoxDaySummary is the availability of a box today from now on. NextFreeAt is
+// empty when no slot is left.
+type BoxDaySummary struct {
+	Box
+	Date          string `json:"date"`
+	OpensAtToday  string `json:"opensAtToday"`
+	ClosesAtToday string `json:"closesAtToday"`
+	FreeMinutes   int64  `json:"freeMinutes"`
+	FreeSlots     int64  `json:"freeSlots"`
+	NextFreeAt    string `json:"nextFreeAt,omitempty"`
+}
+
+// @Summary Nearby venues
+// @Description Venues around a point, the nearest first, with their boxes and today's availability
+// @Tags booking
+// @Produce json
+// @Param lat query number true "Latitude"
+// @Param lon query number true "Longitude"
+// @Param radius query number false "Radius in km, 10 by default"
+// @Param limit query int false "Number of venues, 20 by default"
+// @Success 200 {object} VenuesResponse
+// @Failure 400 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /venues [get]
+func GetVenues(ctx context.Context, log *slog.Logger, client bookgrpc.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.book.GetVenues"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		query := r.URL.Query()
+
+		lat, err := strconv.ParseFloat(query.Get("lat"), 64)
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("lat must be a number"))
+			return
+		}
+
+		lon, err := strconv.ParseFloat(query.Get("lon"), 64)
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("lon must be a number"))
+			return
+		}
+
+		var radius float64
+		if s := query.Get("radius"); s != "" {
+			radius, err = strconv.ParseFloat(s, 64)
+			if err != nil || radius <= 0 {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.Error("radius must be a positive number of km"))
+				return
+			}
+		}
+
+		var limit int64
+		if s := query.Get("limit"); s != "" {
+			limit, err = strconv.ParseInt(s, 10, 64)
+			if err != nil || limit <= 0 {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.Error("limit must be a positive number"))
+				return
+			}
+		}
+
+		venues, err := client.GetNearbyVenues(ctx, lat, lon, radius, limit)
+		if err != nil {
+			switch err.Error() {
+			case "invalid coordinates", "invalid radius":
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.Error(err.Error()))
+			default:
+				log.Error("failed to get venues", sl.Err(err))
+				render.Status(r, http.StatusInternalServerError)
+				render.JSON(w, r, response.Error("Failed to get venues"))
+			}
+			return
+		}
+
+		resp := VenuesResponse{
+			Venues:   make([]NearbyVenue, 0, len(venues)),
+			Response: response.OK(),
+		}
+
+		for _, venue := range venues {
+			resp.Venues = append(resp.Venues, toNearbyVenue(venue))
+		}
+
+		render.JSON(w, r, resp)
+	}
+}
+
+func toNearbyVenue(nearby *bookingv1.NearbyVenue) NearbyVenue {
+	venue := nearby.GetVenue()
+
+	result := NearbyVenue{
+		Venue: Venue{
+			ID:        venue.GetId(),
+			Name:      venue.GetName(),
+			Address:   venue.GetAddress(),
+			Latitude:  venue.GetLatitude(),
+			Longitude: venue.GetLongitude(),
+			TimeZone:  venue.GetTimeZone(),
+			Phone:     venue.GetPhone(),
+			Email:     venue.GetEmail(),
+			Website:   venue.GetWebsite(),
+		},
+		DistanceKm: nearby.GetDistanceKm(),
+		Boxes:      make([]BoxDaySummary, 0, len(nearby.GetBoxes())),
+	}
+
+	for _, summary := range nearby.GetBoxes() {
+		result.Boxes = append(result.Boxes, BoxDaySummary{
+			Box:           toBox(summary.GetBox()),
+			Date:          summary.GetDate(),
+			OpensAtToday:  summary.GetOpensAt(),
+			ClosesAtToday: summary.GetClosesAt(),
+			FreeMinutes:   summary.GetFreeMinutes(),
+			FreeSlots:     summary.GetFreeSlots(),
+			NextFreeAt:    summary.GetNextFreeAt(),
+		})
+	}
+
+	return result
+}
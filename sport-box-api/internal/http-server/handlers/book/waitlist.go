@@ -0,0 +1,331 @@
+package book
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	bookgrpc "sport-box-api/internal/clients/booking/grpc"
+	authMW "sport-box-api/internal/http-server/middleware/auth"
+	"sport-box-api/internal/lib/api/response"
+	bookerrors "sport-box-api/internal/lib/errors/booking"
+	"sport-box-api/internal/lib/logger/sl"
+	"strings"
+
+	bookingv1 "github.com/MKode312/protos/gen/go/booking"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+// WaitlistRequest asks for a taken slot for the caller. Mode is "offer" (the
+// default), which holds the freed slot until the offer is accepted, or
+// "auto_book", which books and charges as soon as the slot frees up.
+type WaitlistRequest struct {
+	Slot
+	Mode string `json:"mode"`
+}
+
+type WaitlistEntry struct {
+	ID           string `json:"id"`
+	BoxName      string `json:"boxName"`
+	StartsAt     string `json:"startsAt"`
+	ExpiresAt    string `json:"expiresAt"`
+	PeopleAmount int64  `json:"peopleAmount"`
+	Mode         string `json:"mode"`
+	// Status is waiting, offered, booked, expired, cancelled or failed.
+	Status         string `json:"status"`
+	OfferExpiresAt string `json:"offerExpiresAt,omitempty"`
+	BookingID      string `json:"bookingId,omitempty"`
+	Error          string `json:"error,omitempty"`
+	CreatedAt      string `json:"createdAt"`
+}
+
+type WaitlistEntryResponse struct {
+	Entry WaitlistEntry `json:"entry"`
+	response.Response
+}
+
+type WaitlistResponse struct {
+	Entries []WaitlistEntry `json:"entries"`
+	response.Response
+}
+
+// @Summary Join the waitlist
+// @Description Wait for a slot that is booked, the slot is offered or booked when it frees up
+// @Tags waitlist
+// @Accept json
+// @Produce json
+// @Param request body WaitlistRequest true "Waitlist request"
+// @Success 200 {object} WaitlistEntryResponse
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /waitlist [post]
+func JoinWaitlist(ctx context.Context, log *slog.Logger, client bookgrpc.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.book.JoinWaitlist"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		email, ok := authMW.UserEmail(r.Context())
+		if !ok {
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, response.Error("Unauthorized"))
+			return
+		}
+
+		var req WaitlistRequest
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("Failed to decode request"))
+
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Error("invalid request", sl.Err(err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ValidationError(validateErr))
+
+			return
+		}
+
+		entry, err := client.JoinWaitlist(ctx, email, req.BoxName, req.PeopleAmount, req.TimeStart, req.TimeHrs, req.TimeMins, req.Mode)
+		if err != nil {
+			log.Error("failed to join the waitlist", sl.Err(err))
+
+			switch err.Error() {
+			case bookerrors.ErrBookingInPast.Error(), bookerrors.ErrInvalidTimeStart.Error(), bookerrors.ErrCapacityExceeded.Error(), bookerrors.ErrBookingTooLong.Error(),
+				bookerrors.ErrInvalidWaitlistMode.Error():
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.Error(err.Error()))
+			case bookerrors.ErrSlotFree.Error():
+				render.Status(r, http.StatusConflict)
+				render.JSON(w, r, response.Error(err.Error()))
+			case bookerrors.ErrOutsideOpeningHours.Error():
+				render.Status(r, http.StatusConflict)
+				render.JSON(w, r, response.Error(closedMessage))
+			case bookerrors.ErrMaintenance.Error():
+				render.Status(r, http.StatusConflict)
+				render.JSON(w, r, response.Error(maintenanceMessage))
+			case "boxName not found":
+				render.Status(r, http.StatusNotFound)
+				render.JSON(w, r, response.Error(bookerrors.ErrBoxNotFound.Error()))
+			default:
+				render.Status(r, http.StatusInternalServerError)
+				render.JSON(w, r, response.Error("Failed to join the waitlist"))
+			}
+
+			return
+		}
+
+		render.JSON(w, r, WaitlistEntryResponse{
+			Entry:    toWaitlistEntry(entry),
+			Response: response.OK(),
+		})
+	}
+}
+
+// @Summary List waitlist entries
+// @Description List the caller's waitlist entries, newest first
+// @Tags waitlist
+// @Produce json
+// @Success 200 {object} WaitlistResponse
+// @Failure 401 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /waitlist [get]
+func GetWaitlist(ctx context.Context, log *slog.Logger, client bookgrpc.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.book.GetWaitlist"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		email, ok := authMW.UserEmail(r.Context())
+		if !ok {
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, response.Error("Unauthorized"))
+			return
+		}
+
+		entries, err := client.GetWaitlist(ctx, email)
+		if err != nil {
+			log.Error("failed to get the waitlist", sl.Err(err))
+
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("Failed to get the waitlist"))
+			return
+		}
+
+		resp := WaitlistResponse{
+			Entries:  make([]WaitlistEntry, 0, len(entries)),
+			Response: response.OK(),
+		}
+
+		for _, entry := range entries {
+			resp.Entries = append(resp.Entries, toWaitlistEntry(entry))
+		}
+
+		render.JSON(w, r, resp)
+	}
+}
+
+// @Summary Accept a waitlist offer
+// @Description Book and pay for the slot held by an offer
+// @Tags waitlist
+// @Produce json
+// @Param id path string true "Waitlist entry ID"
+// @Success 200 {object} Response
+// @Success 202 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 402 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /waitlist/{id}/accept [post]
+func AcceptWaitlistOffer(ctx context.Context, log *slog.Logger, client bookgrpc.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.book.AcceptWaitlistOffer"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		email, ok := authMW.UserEmail(r.Context())
+		if !ok {
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, response.Error("Unauthorized"))
+			return
+		}
+
+		entryID := strings.TrimSpace(chi.URLParam(r, "id"))
+		if entryID == "" {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("invalid waitlist entry ID"))
+			return
+		}
+
+		booking, err := client.AcceptWaitlistOffer(ctx, email, entryID)
+		if err != nil {
+			log.Error("failed to accept the offer", sl.Err(err))
+
+			switch err.Error() {
+			case bookerrors.ErrWaitlistNotFound.Error():
+				render.Status(r, http.StatusNotFound)
+			case bookerrors.ErrNotYourWaitlist.Error():
+				render.Status(r, http.StatusForbidden)
+			case bookerrors.ErrNoOffer.Error(), bookerrors.ErrOfferExpired.Error(), bookerrors.ErrCardNotFound.Error():
+				render.Status(r, http.StatusConflict)
+			case bookerrors.ErrNotEnoughFundsToPay.Error():
+				render.Status(r, http.StatusPaymentRequired)
+			case bookerrors.ErrPaymentPending.Error():
+				render.Status(r, http.StatusAccepted)
+				render.JSON(w, r, response.Error(paymentPendingMessage))
+				return
+			default:
+				render.Status(r, http.StatusInternalServerError)
+				render.JSON(w, r, response.Error("Failed to accept the offer"))
+				return
+			}
+
+			render.JSON(w, r, response.Error(err.Error()))
+			return
+		}
+
+		render.JSON(w, r, Response{
+			Balance:   booking.GetBalance(),
+			Success:   booking.GetSuccess(),
+			BookingID: booking.GetBookingUid(),
+			ResID:     booking.GetReserveId(),
+			Price:     toPrice(booking.GetPrice()),
+			Access:    toAccess(booking.GetAccess()),
+			Response:  response.OK(),
+		})
+	}
+}
+
+// @Summary Leave the waitlist
+// @Description Leave the waitlist, a held slot is offered to the next user
+// @Tags waitlist
+// @Produce json
+// @Param id path string true "Waitlist entry ID"
+// @Success 200 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /waitlist/{id} [delete]
+func LeaveWaitlist(ctx context.Context, log *slog.Logger, client bookgrpc.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.book.LeaveWaitlist"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		email, ok := authMW.UserEmail(r.Context())
+		if !ok {
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, response.Error("Unauthorized"))
+			return
+		}
+
+		entryID := strings.TrimSpace(chi.URLParam(r, "id"))
+		if entryID == "" {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("invalid waitlist entry ID"))
+			return
+		}
+
+		if err := client.LeaveWaitlist(ctx, email, entryID); err != nil {
+			log.Error("failed to leave the waitlist", sl.Err(err))
+
+			switch err.Error() {
+			case bookerrors.ErrWaitlistNotFound.Error():
+				render.Status(r, http.StatusNotFound)
+				render.JSON(w, r, response.Error(err.Error()))
+			case bookerrors.ErrNotYourWaitlist.Error():
+				render.Status(r, http.StatusForbidden)
+				render.JSON(w, r, response.Error(err.Error()))
+			default:
+				render.Status(r, http.StatusInternalServerError)
+				render.JSON(w, r, response.Error("Failed to leave the waitlist"))
+			}
+			return
+		}
+
+		render.JSON(w, r, response.OK())
+	}
+}
+
+func toWaitlistEntry(entry *bookingv1.WaitlistEntry) WaitlistEntry {
+	return WaitlistEntry{
+		ID:             entry.GetUid(),
+		BoxName:        entry.GetBoxName(),
+		StartsAt:       entry.GetStartsAt(),
+		ExpiresAt:      entry.GetExpiresAt(),
+		PeopleAmount:   entry.GetPeopleAmount(),
+		Mode:           entry.GetMode(),
+		Status:         entry.GetStatus(),
+		OfferExpiresAt: entry.GetOfferExpiresAt(),
+		BookingID:      entry.GetBookingUid(),
+		Error:          entry.GetError(),
+		CreatedAt:      entry.GetCreatedAt(),
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"log/slog"
 	"net/http"
 	paymgrpc "sport-box-api/internal/clients/payments/grpc"
+	"sport-box-api/internal/lib/api/idempotency"
 	"sport-box-api/internal/lib/api/response"
 	paymerrors "sport-box-api/internal/lib/errors/payments"
 	"sport-box-api/internal/lib/logger/sl"
@@ -14,6 +15,10 @@ import (
 	"github.com/go-playground/validator/v10"
 )
 
+// keyPrefix keeps the idempotency keys clients top up with apart from the
+// ones the booking service pays and refunds with in the same wallet.
+const keyPrefix = "api:"
+
 type Request struct {
 	Email  string `json:"email" validate:"required"`
 	Amount int64  `json:"amount"`
@@ -58,8 +63,29 @@ func New(ctx context.Context, log *slog.Logger, paymentsclient paymgrpc.Client)
 			return
 		}
 
-		balance, success, err := paymentsclient.AddFunds(ctx, req.Email, req.Amount)
+		idempotencyKey, err := idempotency.Key(r)
 		if err != nil {
+			log.Error("invalid idempotency key", sl.Err(err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error(err.Error()))
+
+			return
+		}
+
+		if idempotencyKey != "" {
+			idempotencyKey = keyPrefix + idempotencyKey
+		}
+
+		balance, success, err := paymentsclient.AddFunds(ctx, req.Email, req.Amount, idempotencyKey)
+		if err != nil {
+			if err.Error() == paymerrors.ErrIdempotencyKeyReused.Error() {
+				log.Error("idempotency key reused")
+				render.Status(r, http.StatusUnprocessableEntity)
+				render.JSON(w, r, response.Error("This Idempotency-Key was already used for another request"))
+				return
+			}
+
 			if err.Error() == paymerrors.ErrNotFound.Error() {
 				log.Error("invalid credentials")
 				render.Status(r, http.StatusBadRequest)
@@ -1,6 +1,7 @@
 package authMW
 
 import (
+	"context"
 	"net/http"
 	"sport-box-api/internal/lib/api/response"
 	jwtValidation "sport-box-api/internal/lib/jwt/validation"
@@ -8,6 +9,15 @@ import (
 	"github.com/go-chi/render"
 )
 
+type emailCtxKey struct{}
+
+// UserEmail returns the email of the authorized user stored by AuthorizeJWTToken.
+func UserEmail(ctx context.Context) (string, bool) {
+	email, ok := ctx.Value(emailCtxKey{}).(string)
+
+	return email, ok && email != ""
+}
+
 func AuthorizeJWTToken(next http.Handler) http.Handler {
 
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -20,13 +30,13 @@ func AuthorizeJWTToken(next http.Handler) http.Handler {
 
 		tokenString := cookie.Value
 
-		err = jwtValidation.VerifyJWTToken(tokenString)
+		email, err := jwtValidation.VerifyJWTToken(tokenString)
 		if err != nil {
 			http.Redirect(w, r, "/login", http.StatusSeeOther)
 			render.JSON(w, r, response.Error("Invalid credentials"))
 			return
 		}
 
-		next.ServeHTTP(w, r)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), emailCtxKey{}, email)))
 	})
 }
@@ -0,0 +1,34 @@
+// Package idempotency reads the idempotency key clients send with requests
+// that must not run twice, e.g. a booking retried after a timeout.
+package idempotency
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+const (
+	Header = "Idempotency-Key"
+
+	maxKeyLength = 255
+)
+
+var ErrInvalidKey = errors.New("Idempotency-Key must be at most 255 printable ASCII characters")
+
+// Key returns the idempotency key of the request, empty when there is none.
+func Key(r *http.Request) (string, error) {
+	key := strings.TrimSpace(r.Header.Get(Header))
+
+	if len(key) > maxKeyLength {
+		return "", ErrInvalidKey
+	}
+
+	for _, c := range key {
+		if c < ' ' || c > '~' {
+			return "", ErrInvalidKey
+		}
+	}
+
+	return key, nil
+}
@@ -3,9 +3,49 @@ package bookerrors
 import "errors"
 
 var (
-	ErrInvalidCredentials  = errors.New("invalid credentials")
-	ErrNotEnoughFundsToPay = errors.New("not enough funds to pay")
-	ErrCardNotFound        = errors.New("card not found")
-	ErrAlreadyBooked       = errors.New("this box is already booked")
-	ErrBookingNotFound     = errors.New("booking not found")
-)
\ No newline at end of file
+	ErrInvalidCredentials     = errors.New("invalid credentials")
+	ErrNotEnoughFundsToPay    = errors.New("not enough funds to pay")
+	ErrCardNotFound           = errors.New("card not found")
+	ErrAlreadyBooked          = errors.New("this box is already booked")
+	ErrBookingNotFound        = errors.New("booking not found")
+	ErrBoxNotFound            = errors.New("box not found")
+	ErrBookingNotActive       = errors.New("booking is not active")
+	ErrBookingInPast          = errors.New("booking start time is in the past")
+	ErrInvalidTimeStart       = errors.New("invalid time format, expected RFC 3339 or YYYY-MM-DDTHH:MM")
+	ErrCapacityExceeded       = errors.New("the amount of people exceeds the box capacity")
+	ErrSeriesNotFound         = errors.New("series not found")
+	ErrInvalidRule            = errors.New("invalid recurrence rule, expected e.g. FREQ=WEEKLY;BYDAY=TU;COUNT=10")
+	ErrRuleWithoutEnd         = errors.New("recurrence rule needs an UNTIL or COUNT")
+	ErrTooManyOccurrences     = errors.New("recurrence rule gives too many occurrences")
+	ErrInvalidPayment         = errors.New("invalid payment, expected per_occurrence or upfront")
+	ErrSlotFree               = errors.New("the slot is free, book it instead")
+	ErrInvalidWaitlistMode    = errors.New("invalid mode, expected offer or auto_book")
+	ErrWaitlistNotFound       = errors.New("waitlist entry not found")
+	ErrNotYourWaitlist        = errors.New("this waitlist entry belongs to another user")
+	ErrNoOffer                = errors.New("there is no offer for this waitlist entry")
+	ErrOfferExpired           = errors.New("waitlist offer has expired")
+	ErrBookingStarted         = errors.New("booking has already started")
+	ErrOutsideOpeningHours    = errors.New("the box is closed at this time")
+	ErrMaintenance            = errors.New("the box is closed for maintenance at this time")
+	ErrCalendarNotFound       = errors.New("calendar feed not found")
+	ErrInvalidLocale          = errors.New("unsupported locale")
+	ErrIdempotencyKeyReused   = errors.New("idempotency key was used for another request")
+	ErrRequestInProgress      = errors.New("a request with this idempotency key is in progress")
+	ErrHoldNotFound           = errors.New("hold not found")
+	ErrNotYourHold            = errors.New("this hold belongs to another user")
+	ErrHoldExpired            = errors.New("hold has expired")
+	ErrTooManyHolds           = errors.New("too many active holds")
+	ErrPromoCodeNotFound      = errors.New("promo code not found")
+	ErrPromoCodeExpired       = errors.New("promo code is not valid at this time")
+	ErrPromoCodeNotApplicable = errors.New("promo code does not apply to this booking")
+	ErrPromoCodeExhausted     = errors.New("promo code has been used up")
+	ErrInvitationNotFound     = errors.New("invitation not found")
+	ErrAlreadyInvited         = errors.New("this user is already invited to the booking")
+	ErrInvitationDeclined     = errors.New("invitation was declined")
+	ErrTooManyParticipants    = errors.New("the booking has no room for more participants")
+	ErrInviteOwner            = errors.New("the owner of the booking can't be invited")
+	ErrNotYourBooking         = errors.New("this booking belongs to another user")
+	ErrBookingTooLong         = errors.New("booking can't be longer than 24 hours")
+	ErrPaymentPending         = errors.New("payment is being processed")
+	ErrRefundPending          = errors.New("refund is being processed")
+)
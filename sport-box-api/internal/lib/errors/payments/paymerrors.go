@@ -3,7 +3,8 @@ package paymerrors
 import "errors"
 
 var (
-	ErrInvalidCredentials  = errors.New("invalid credentials")
-	ErrNotEnoughFundsToPay = errors.New("not enough funds to pay")
-	ErrNotFound            = errors.New("card not found")
-)
\ No newline at end of file
+	ErrInvalidCredentials   = errors.New("invalid credentials")
+	ErrNotEnoughFundsToPay  = errors.New("not enough funds to pay")
+	ErrNotFound             = errors.New("card not found")
+	ErrIdempotencyKeyReused = errors.New("idempotency key was used for another request")
+)
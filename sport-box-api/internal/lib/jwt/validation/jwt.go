@@ -7,7 +7,9 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
-func VerifyJWTToken(tokenString string) (error) {
+// VerifyJWTToken checks the token signature and returns the email of the user
+// the token was issued to.
+func VerifyJWTToken(tokenString string) (string, error) {
 
 	const op = "lib.jwt.validation.VerifyJWTToken"
 
@@ -18,12 +20,22 @@ func VerifyJWTToken(tokenString string) (error) {
 		return []byte(os.Getenv("APP_SECRET")), nil
 	})
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return "", fmt.Errorf("%s: %w", op, err)
 	}
 
-	if token.Valid {
-		return nil
+	if !token.Valid {
+		return "", fmt.Errorf("invalid token")
 	}
 
-	return fmt.Errorf("invalid token")
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return "", fmt.Errorf("%s: unexpected claims type", op)
+	}
+
+	email, _ := claims["email"].(string)
+	if email == "" {
+		return "", fmt.Errorf("%s: token has no email claim", op)
+	}
+
+	return email, nil
 }
\ No newline at end of file
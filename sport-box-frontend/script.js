@@ -286,19 +286,27 @@ async function createBooking(event) {
     }
 
     // Validate form inputs
-    const email = document.getElementById('userEmail').textContent;
     const boxName = document.getElementById('boxName').value;
     const peopleAmount = parseInt(document.getElementById('peopleAmount').value);
-    const timeStart = document.getElementById('timeStart').value;
+    const bookingDate = document.getElementById('bookingDate').value;
+    const time = document.getElementById('timeStart').value;
     const timeHrs = parseInt(document.getElementById('timeHrs').value);
     const timeMins = parseInt(document.getElementById('timeMins').value);
 
     // Input validation
-    if (!timeStart) {
+    if (!bookingDate) {
+        showError('booking', 'Please select a date');
+        return;
+    }
+
+    if (!time) {
         showError('booking', 'Please select a start time');
         return;
     }
 
+    // Local date and time, resolved by the server in the box's time zone
+    const timeStart = `${bookingDate}T${time}`;
+
     if (isNaN(peopleAmount) || peopleAmount < 1) {
         showError('booking', 'Please enter a valid number of people');
         return;
@@ -313,7 +321,6 @@ async function createBooking(event) {
         const response = await apiRequest('/book', {
             method: 'POST',
             body: JSON.stringify({
-                email,
                 boxName,
                 peopleAmount,
                 timeStart,
@@ -459,6 +466,9 @@ let bookingModal, paymentModal;
 
 function openBookingModal(boxName) {
     document.getElementById('boxName').value = boxName;
+    const bookingDate = document.getElementById('bookingDate');
+    const today = new Date();
+    bookingDate.min = new Date(today.getTime() - today.getTimezoneOffset() * 60000).toISOString().slice(0, 10);
     populateTimeSlots();
     bookingModal.show();
 }
@@ -538,17 +548,23 @@ async function loadBookings() {
 function createBookingItem(booking) {
     const item = document.createElement('div');
     item.className = 'booking-item';
+    const startsAt = new Date(booking.startsAt);
+    const expiresAt = new Date(booking.expiresAt);
+    const minutes = Math.round((expiresAt - startsAt) / 60000);
     item.innerHTML = `
         <div>
             <strong>${booking.boxName}</strong>
-            <p>Time: ${booking.timeStart}</p>
-            <p>Duration: ${booking.timeHrs}h ${booking.timeMins}m</p>
+            <p>Time: ${startsAt.toLocaleString()}</p>
+            <p>Duration: ${Math.floor(minutes / 60)}h ${minutes % 60}m</p>
             <p>People: ${booking.peopleAmount}</p>
+            <p>Paid: ₽${booking.pricePaid}</p>
+            <p>Status: ${booking.status}</p>
+            ${booking.status === 'active' ? `
             <div class="booking-actions">
-                <button class="btn btn-sm btn-danger" onclick="cancelBooking(${booking.id})">
+                <button class="btn btn-sm btn-danger" onclick="cancelBooking('${booking.id}')">
                     Cancel
                 </button>
-            </div>
+            </div>` : ''}
         </div>
     `;
     return item;